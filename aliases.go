@@ -0,0 +1,144 @@
+package odoo
+
+import (
+	"strings"
+)
+
+// FieldAliases renames the fields of a model: keys are Odoo field names,
+// values the names records carry instead, e.g. {"email_from": "Email"}.
+// Downstream schemas stay stable when a field is renamed between Odoo
+// versions or custom modules; only the alias map has to change.
+type FieldAliases map[string]string
+
+// WithFieldAliases renames the fields of model for the connector. Records
+// read with SearchReadRecords, SearchReadStream and SyncChanges carry the
+// aliases, and exports and sinks use them as column names. Fields, domains,
+// orders and written values may name fields by alias or by Odoo name.
+func WithFieldAliases(model string, aliases FieldAliases) Option {
+	return func(c *Connector) {
+		if c.aliases == nil {
+			c.aliases = make(map[string]*aliasMap)
+		}
+		m := &aliasMap{toAlias: make(map[string]string, len(aliases)), toField: make(map[string]string, len(aliases))}
+		for field, alias := range aliases {
+			m.toAlias[field] = alias
+			m.toField[alias] = field
+		}
+		c.aliases[model] = m
+	}
+}
+
+// aliasMap translates between the Odoo field names and the aliases of a
+// model. A nil map translates nothing.
+type aliasMap struct {
+	toAlias map[string]string
+	toField map[string]string
+}
+
+// aliasesOf returns the aliases of model, or nil if it has none
+func (c *Connector) aliasesOf(model string) *aliasMap {
+	return c.aliases[model]
+}
+
+// alias returns the alias of an Odoo field name
+func (m *aliasMap) alias(field string) string {
+	if m == nil {
+		return field
+	}
+	if alias, ok := m.toAlias[field]; ok {
+		return alias
+	}
+	return field
+}
+
+// field returns the Odoo field name of an alias. Dotted paths such as
+// "Partner.name" are translated on their first segment.
+func (m *aliasMap) field(name string) string {
+	if m == nil {
+		return name
+	}
+	first, rest, dotted := strings.Cut(name, ".")
+	if field, ok := m.toField[first]; ok {
+		if dotted {
+			return field + "." + rest
+		}
+		return field
+	}
+	return name
+}
+
+func (m *aliasMap) fields(names []string) []string {
+	if m == nil || len(names) == 0 {
+		return names
+	}
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = m.field(name)
+	}
+	return fields
+}
+
+// domain translates the field names of the domain's leaves
+func (m *aliasMap) domain(domain []interface{}) []interface{} {
+	if m == nil || len(domain) == 0 {
+		return domain
+	}
+	translated := make([]interface{}, len(domain))
+	for i, term := range domain {
+		translated[i] = term
+		if leaf, ok := term.([]interface{}); ok && len(leaf) == 3 {
+			if name, ok := leaf[0].(string); ok {
+				translated[i] = []interface{}{m.field(name), leaf[1], leaf[2]}
+			}
+		}
+	}
+	return translated
+}
+
+// order translates the field names of an order clause like "Email desc, id"
+func (m *aliasMap) order(order string) string {
+	if m == nil || order == "" {
+		return order
+	}
+	parts := strings.Split(order, ",")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		name, direction, _ := strings.Cut(part, " ")
+		parts[i] = strings.TrimSpace(m.field(name) + " " + direction)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m *aliasMap) query(opts SearchReadOptions) SearchReadOptions {
+	if m == nil {
+		return opts
+	}
+	opts.Fields = m.fields(opts.Fields)
+	opts.Domain = m.domain(opts.Domain)
+	opts.Order = m.order(opts.Order)
+	return opts
+}
+
+// values renames aliased keys of values written to Odoo to field names
+func (m *aliasMap) values(values map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return values
+	}
+	translated := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		translated[m.field(name)] = value
+	}
+	return translated
+}
+
+// record renames the fields of a record read from Odoo to their aliases
+func (m *aliasMap) record(record map[string]interface{}) map[string]interface{} {
+	if m == nil || record == nil {
+		return record
+	}
+	renamed := make(map[string]interface{}, len(record))
+	for field, value := range record {
+		renamed[m.alias(field)] = value
+	}
+	return renamed
+}
@@ -0,0 +1,39 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldAliases(t *testing.T) {
+	c := &Connector{}
+	WithFieldAliases("crm.lead", FieldAliases{"email_from": "Email", "partner_id": "Partner"})(c)
+	m := c.aliasesOf("crm.lead")
+
+	opts := m.query(SearchReadOptions{
+		Fields: []string{"name", "Email"},
+		Domain: []interface{}{"|", []interface{}{"Email", "ilike", "@example.com"}, []interface{}{"Partner.name", "=", "Azure"}},
+		Order:  "Email desc, id",
+	})
+	want := SearchReadOptions{
+		Fields: []string{"name", "email_from"},
+		Domain: []interface{}{"|", []interface{}{"email_from", "ilike", "@example.com"}, []interface{}{"partner_id.name", "=", "Azure"}},
+		Order:  "email_from desc, id",
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("query = %#v, want %#v", opts, want)
+	}
+
+	record := m.record(map[string]interface{}{"id": int64(1), "email_from": "a@example.com"})
+	if !reflect.DeepEqual(record, map[string]interface{}{"id": int64(1), "Email": "a@example.com"}) {
+		t.Errorf("record = %v", record)
+	}
+	values := m.values(map[string]interface{}{"Email": "b@example.com", "name": "B"})
+	if !reflect.DeepEqual(values, map[string]interface{}{"email_from": "b@example.com", "name": "B"}) {
+		t.Errorf("values = %v", values)
+	}
+
+	if c.aliasesOf("res.partner").record(record)["Email"] == nil {
+		t.Error("model without aliases changed the record")
+	}
+}
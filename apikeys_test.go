@@ -0,0 +1,160 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestListAPIKeys(t *testing.T) {
+	for _, tc := range []struct {
+		version int
+		fields  []interface{}
+	}{
+		{16, []interface{}{"name", "scope", "create_date"}},
+		{17, []interface{}{"name", "scope", "create_date", "expiration_date"}},
+	} {
+		c := versionServer(t, tc.version, func(model, method string, args []interface{}) interface{} {
+			if model == "res.users" {
+				return []map[string]interface{}{{"id": 2, "api_key_ids": []interface{}{5}}}
+			}
+			fields := args[6].(map[string]interface{})["fields"]
+			if !reflect.DeepEqual(fields, tc.fields) {
+				t.Errorf("Odoo %d: read fields %v, want %v", tc.version, fields, tc.fields)
+			}
+			return []map[string]interface{}{{"id": 5, "name": "sync", "scope": false, "create_date": "2024-01-01 10:00:00", "expiration_date": "2025-01-01 00:00:00"}}
+		})
+
+		keys, err := c.ListAPIKeys(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []APIKey{{ID: 5, Name: "sync", CreateDate: "2024-01-01 10:00:00", ExpirationDate: "2025-01-01 00:00:00"}}
+		if !reflect.DeepEqual(keys, want) {
+			t.Errorf("Odoo %d: got %+v, want %+v", tc.version, keys, want)
+		}
+	}
+}
+
+func TestListAPIKeysDetectionFailure(t *testing.T) {
+	var read bool
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		switch method {
+		case "authenticate":
+			return 2
+		case "version":
+			return xmlrpc.FaultError{Code: 1, String: "Odoo Server Error"}
+		}
+		if args[3] == "res.users.apikeys" {
+			read = true
+		}
+		return []map[string]interface{}{{"id": 2, "api_key_ids": []interface{}{5}}}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ListAPIKeys(context.Background()); err == nil {
+		t.Fatal("failed version detection did not fail the listing")
+	}
+	if read {
+		t.Error("keys were read without knowing the server version")
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		check    bool
+		password string
+		want     []string
+		err      bool
+	}{
+		{"recent login", false, "", []string{"res.users.apikeys.description.create", "res.users.apikeys.description.make_key"}, false},
+		{"identity check", true, "secret", []string{"res.users.apikeys.description.create", "res.users.apikeys.description.make_key", "res.users.identitycheck.write", "res.users.identitycheck.run_check"}, false},
+		{"identity check without password", true, "", []string{"res.users.apikeys.description.create", "res.users.apikeys.description.make_key"}, true},
+	} {
+		var calls []string
+		var values interface{}
+		c := versionServer(t, 17, func(model, method string, args []interface{}) interface{} {
+			calls = append(calls, model+"."+method)
+			keyAction := map[string]interface{}{"type": ActionWindow, "res_model": "res.users.apikeys.show", "context": map[string]interface{}{"default_key": "0123abcd"}}
+			switch method {
+			case "create":
+				values = args[5].([]interface{})[0]
+				return 7
+			case "make_key":
+				if tc.check {
+					return map[string]interface{}{"type": ActionWindow, "res_model": "res.users.identitycheck", "res_id": 8}
+				}
+				return keyAction
+			case "write":
+				if want := []interface{}{[]interface{}{int64(8)}, map[string]interface{}{"password": "secret"}}; !reflect.DeepEqual(args[5], want) {
+					t.Errorf("%s: identity check written with %v", tc.name, args[5])
+				}
+				return true
+			case "run_check":
+				return keyAction
+			}
+			return false
+		})
+
+		key, err := c.CreateAPIKey(context.Background(), NewAPIKey{
+			Name:      "rotation",
+			ExpiresAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600)),
+			Password:  tc.password,
+		})
+		if (err != nil) != tc.err || (!tc.err && key != "0123abcd") {
+			t.Errorf("%s: got %q, %v", tc.name, key, err)
+		}
+		if !reflect.DeepEqual(calls, tc.want) {
+			t.Errorf("%s: calls %v, want %v", tc.name, calls, tc.want)
+		}
+		if want := map[string]interface{}{"name": "rotation", "expiration_date": "2025-06-01 10:00:00"}; !reflect.DeepEqual(values, want) {
+			t.Errorf("%s: created %v, want %v", tc.name, values, want)
+		}
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	var removed interface{}
+	c := versionServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		if model+"."+method == "res.users.apikeys.remove" {
+			removed = args[5]
+		}
+		return true
+	})
+	if err := c.RevokeAPIKey(context.Background(), 5); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(removed, []interface{}{[]interface{}{int64(5)}}) {
+		t.Errorf("removed %v", removed)
+	}
+}
+
+func TestAPIKeysUnsupported(t *testing.T) {
+	var called bool
+	c := versionServer(t, 13, func(model, method string, args []interface{}) interface{} {
+		called = true
+		return true
+	})
+	ctx := context.Background()
+
+	if _, err := c.ListAPIKeys(ctx); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("listing: got %v, want ErrUnsupported", err)
+	}
+	if _, err := c.CreateAPIKey(ctx, NewAPIKey{Name: "sync"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("creation: got %v, want ErrUnsupported", err)
+	}
+	if err := c.RevokeAPIKey(ctx, 5); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("revocation: got %v, want ErrUnsupported", err)
+	}
+	if called {
+		t.Error("Odoo 13 was asked for API keys")
+	}
+}
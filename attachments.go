@@ -0,0 +1,110 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// UploadAttachment stores the content read from r as an attachment named
+// filename of the record resID of model and returns the attachment's ID.
+// The content is base64-encoded while the request is sent, like
+// WriteBinaryField does; Odoo derives the MIME type from the content and
+// name.
+func (c *Connector) UploadAttachment(ctx context.Context, model string, resID int64, filename string, r io.Reader) (int64, error) {
+	var id int64
+	err := c.execute(ctx, "ir.attachment", "create", []interface{}{map[string]interface{}{
+		"name":      filename,
+		"res_model": model,
+		"res_id":    resID,
+		"datas":     newBinaryValue(r),
+	}}, nil, &id)
+	if err != nil {
+		return 0, fmt.Errorf("attachment upload failed for model %s with id %d: %w", model, resID, err)
+	}
+	return id, nil
+}
+
+// DownloadAttachment returns the content of the attachment with the given
+// id, decoded while it is read from the response. Errors after the request
+// was answered, such as a missing attachment, are returned by Read. The
+// request's resources are released once the content has been read to the
+// end; closing the reader early aborts the download.
+func (c *Connector) DownloadAttachment(ctx context.Context, id int64) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	response, finish, err := c.openBinaryField(ctx, "ir.attachment", id, "datas")
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("attachment download failed for id %d: %w", id, err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := c.codec().decodeBinaryMember(response, "datas", pw)
+		finish(err)
+		cancel()
+		if err != nil {
+			err = fmt.Errorf("attachment download failed for id %d: %w", id, err)
+		}
+		pw.CloseWithError(err)
+	}()
+	return &cancelReader{PipeReader: pr, cancel: cancel}, nil
+}
+
+// cancelReader cancels the request feeding the pipe when it is closed
+type cancelReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Close() error {
+	r.cancel()
+	return r.PipeReader.Close()
+}
+
+// RenderReport renders the PDF report reportName (the report_name of an
+// ir.actions.report, e.g. "account.report_invoice") for the records with
+// the given ids and returns it as it is received; the caller must close it.
+// Odoo renders reports for the web client only, so RenderReport requires
+// WithSessionAuth.
+func (c *Connector) RenderReport(ctx context.Context, reportName string, ids []int64) (io.ReadCloser, error) {
+	if c.sessionPassword == "" {
+		return nil, fmt.Errorf("report %s failed: session authentication is not enabled", reportName)
+	}
+	idList := make([]string, len(ids))
+	for i, id := range ids {
+		idList[i] = strconv.FormatInt(id, 10)
+	}
+	reportURL := fmt.Sprintf("%s/report/pdf/%s/%s", c.URL, url.PathEscape(reportName), strings.Join(idList, ","))
+
+	for renew := false; ; renew = true {
+		session, err := c.webSession(ctx, renew)
+		if err != nil {
+			return nil, fmt.Errorf("report %s failed: %w", reportName, err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reportURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("report %s failed: %w", reportName, err)
+		}
+		resp, err := session.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("report %s failed: %w", reportName, err)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("report %s failed: bad status code - %d", reportName, resp.StatusCode)
+		}
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/pdf") {
+			return resp.Body, nil
+		}
+		resp.Body.Close()
+		// An expired session is redirected to the login page
+		if renew {
+			return nil, fmt.Errorf("report %s failed: no PDF received", reportName)
+		}
+	}
+}
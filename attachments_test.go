@@ -0,0 +1,161 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAttachments(t *testing.T) {
+	var created map[string]interface{}
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		switch args[4] {
+		case "create":
+			created = args[5].([]interface{})[0].(map[string]interface{})
+			return 9
+		case "read":
+			return []map[string]interface{}{{"id": 9, "datas": "JVBERi0xLjQ="}}
+		}
+		return nil
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := c.UploadAttachment(context.Background(), "res.partner", 3, "contract.pdf", strings.NewReader("%PDF-1.4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 9 || created["datas"] != "JVBERi0xLjQ=" || created["res_model"] != "res.partner" || created["name"] != "contract.pdf" {
+		t.Errorf("created attachment %d with %v", id, created)
+	}
+
+	r, err := c.DownloadAttachment(context.Background(), 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "%PDF-1.4" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	logins := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/session/authenticate":
+			logins++
+			http.SetCookie(w, &http.Cookie{Name: "session_id", Value: "s" + string(rune('0'+logins)), Path: "/"})
+			json.NewEncoder(w).Encode(map[string]interface{}{"result": map[string]interface{}{"uid": 2}})
+		case "/report/pdf/account.report_invoice/1,2":
+			// The first session is treated as expired
+			if cookie, err := r.Cookie("session_id"); err != nil || cookie.Value == "s1" {
+				http.Redirect(w, r, "/web/login", http.StatusSeeOther)
+				return
+			}
+			w.Header().Set("Content-Type", "application/pdf")
+			io.WriteString(w, "%PDF-1.4")
+		case "/web/login":
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, "<html></html>")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Connector{URL: srv.URL, DB: "db", Username: "admin", httpClient: srv.Client(), sessionPassword: "admin"}
+	r, err := c.RenderReport(context.Background(), "account.report_invoice", []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	pdf, _ := io.ReadAll(r)
+	if string(pdf) != "%PDF-1.4" || logins != 2 {
+		t.Errorf("got %q after %d logins", pdf, logins)
+	}
+}
+
+func TestUploadAttachmentRetries(t *testing.T) {
+	var calls int
+	var contents []interface{}
+	var lengths []int64
+	handler := jsonRPCHandler(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls++
+		contents = append(contents, args[5].([]interface{})[0].(map[string]interface{})["datas"])
+		if calls == 1 {
+			return httpStatus(503)
+		}
+		return 9
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lengths = append(lengths, r.ContentLength)
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	c, err := NewConnector(srv.URL, "admin", "key", "db",
+		WithProtocol(JSONRPC),
+		WithRetry(RetryPolicy{MaxAttempts: 3}),
+		WithRules(NewRules().Required("ir.attachment", "name")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A seekable reader is rewound for the retry
+	id, err := c.UploadAttachment(ctx, "res.partner", 3, "contract.pdf", strings.NewReader("%PDF-1.4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 9 || calls != 2 {
+		t.Errorf("got id %d after %d calls, want 9 after 2", id, calls)
+	}
+	for i, content := range contents {
+		if content != "JVBERi0xLjQ=" {
+			t.Errorf("attempt %d sent %v", i+1, content)
+		}
+	}
+	// The body is encoded while it is sent, so its length is unknown
+	for _, length := range lengths[1:] {
+		if length != -1 {
+			t.Errorf("request with content length %d", length)
+		}
+	}
+
+	// Other readers are sent only once
+	calls = 0
+	if _, err := c.UploadAttachment(ctx, "res.partner", 3, "contract.pdf", io.MultiReader(strings.NewReader("%PDF-1.4"))); err == nil {
+		t.Error("upload did not fail")
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+
+	// Uploads are validated like other creates
+	calls = 0
+	if _, err := c.UploadAttachment(ctx, "res.partner", 3, "", strings.NewReader("%PDF-1.4")); !errors.Is(err, ErrValidation) {
+		t.Errorf("got %v, want a validation error", err)
+	}
+	if calls != 0 {
+		t.Errorf("%d calls, want none", calls)
+	}
+}
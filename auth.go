@@ -0,0 +1,81 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// WithReauthentication re-authenticates when a call fails with an
+// authentication fault, e.g. after the API key was rotated or expired, and
+// repeats the call once. credentials, if not nil, returns the API key to
+// authenticate with, e.g. read from a secret store; otherwise the current
+// key is tried again.
+func WithReauthentication(credentials func(ctx context.Context) (string, error)) Option {
+	return func(c *Connector) {
+		c.reauth = &reauthenticator{credentials: credentials}
+	}
+}
+
+// WithRateLimit paces all calls of the connector to callsPerSecond calls and
+// recordsPerSecond written records per second, like a Throttle attached to
+// every context. A zero rate is unlimited.
+func WithRateLimit(callsPerSecond, recordsPerSecond float64) Option {
+	return func(c *Connector) {
+		c.rateLimit = NewThrottle(callsPerSecond, recordsPerSecond)
+	}
+}
+
+// reauthenticator serializes re-authentications. generation counts them so
+// concurrent calls failing with the same stale key authenticate only once.
+type reauthenticator struct {
+	credentials func(ctx context.Context) (string, error)
+
+	mu         sync.RWMutex
+	generation uint64
+}
+
+// credentials returns the API key and user ID for a call and the
+// authentication generation they belong to
+func (c *Connector) credentials() (string, int, uint64) {
+	if c.reauth == nil {
+		return c.apiKey, c.uid, 0
+	}
+	c.reauth.mu.RLock()
+	defer c.reauth.mu.RUnlock()
+	return c.apiKey, c.uid, c.reauth.generation
+}
+
+// reauthenticate authenticates again unless another call already did since
+// generation
+func (c *Connector) reauthenticate(ctx context.Context, generation uint64) error {
+	r := c.reauth
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation != generation {
+		return nil
+	}
+
+	apiKey := c.apiKey
+	if r.credentials != nil {
+		var err error
+		if apiKey, err = r.credentials(ctx); err != nil {
+			return fmt.Errorf("re-authentication failed: %w", err)
+		}
+	}
+	var uid int
+	err := c.call(ctx, serviceCommon, "authenticate", []interface{}{c.DB, c.Username, apiKey, map[string]string{}}, &uid)
+	if err != nil {
+		return fmt.Errorf("re-authentication failed: %w", err)
+	}
+	if uid == 0 {
+		return errors.New("re-authentication failed: invalid credentials")
+	}
+
+	c.apiKey, c.uid = apiKey, uid
+	r.generation++
+	c.log().LogAttrs(ctx, slog.LevelInfo, "odoo connector re-authenticated", slog.Int("uid", uid))
+	return nil
+}
@@ -0,0 +1,61 @@
+package odoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReauthentication(t *testing.T) {
+	key := "old-key"
+	var authentications int
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			authentications++
+			if args[2] != key {
+				return false
+			}
+			return 2
+		}
+		if args[2] != key {
+			e := &jsonError{Code: 200, Message: "Odoo Server Error"}
+			e.Data.Name = "odoo.exceptions.AccessDenied"
+			e.Data.Message = "Access Denied"
+			return e
+		}
+		return 7
+	})
+
+	c, err := NewConnector(srv.URL, "admin", key, "db",
+		WithProtocol(JSONRPC),
+		WithReauthentication(func(ctx context.Context) (string, error) { return key, nil }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotate the key on the server
+	key = "new-key"
+	id, err := c.CreateRecord("res.partner", map[string]interface{}{"name": "Azure"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 7 {
+		t.Errorf("id = %d, want 7", id)
+	}
+	if apiKey, _, _ := c.credentials(); authentications != 2 || apiKey != "new-key" {
+		t.Errorf("authentications = %d, API key %q", authentications, apiKey)
+	}
+	if c.APIKey != "old-key" {
+		t.Errorf("APIKey = %q, want the key the connector was created with", c.APIKey)
+	}
+
+	// A key that stays rejected fails after one re-authentication
+	key = "newer-key"
+	c.reauth.credentials = nil
+	if _, err := c.CreateRecord("res.partner", nil); ClassifyError(err) != ErrorClassAuthentication {
+		t.Errorf("err = %v, want an authentication error", err)
+	}
+	if authentications != 3 {
+		t.Errorf("authentications = %d, want 3", authentications)
+	}
+}
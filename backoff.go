@@ -0,0 +1,73 @@
+package odoo
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff computes the pause before a retry. attempt is 1 for the first
+// retry; previous is the pause returned for the preceding retry (zero before
+// the first one).
+type Backoff interface {
+	Next(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same delay before every retry
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// Next implements Backoff
+func (b ConstantBackoff) Next(int, time.Duration) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier (2 when unset) after
+// every retry, starting at Base and capped at Max (uncapped when zero)
+type ExponentialBackoff struct {
+	Base       time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Next implements Backoff
+func (b ExponentialBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	delay := float64(b.Base) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	// Uncapped delays saturate instead of overflowing into negative ones
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitterBackoff picks a random delay between Base and three times
+// the previous delay, capped at Max. Spreading the retries of concurrent
+// callers avoids them hitting the server again in lockstep.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Next implements Backoff
+func (b DecorrelatedJitterBackoff) Next(_ int, previous time.Duration) time.Duration {
+	if previous < b.Base {
+		previous = b.Base
+	}
+	upper := 3 * previous
+	delay := b.Base
+	if upper > b.Base {
+		delay += time.Duration(rand.Int63n(int64(upper - b.Base)))
+	}
+	if b.Max > 0 && delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
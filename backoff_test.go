@@ -0,0 +1,108 @@
+package odoo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff{Delay: time.Second}
+	for attempt := 1; attempt <= 3; attempt++ {
+		if got := b.Next(attempt, time.Minute); got != time.Second {
+			t.Errorf("attempt %d: got %v, want 1s", attempt, got)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		backoff ExponentialBackoff
+		want    []time.Duration
+	}{
+		{
+			name:    "default multiplier",
+			backoff: ExponentialBackoff{Base: 100 * time.Millisecond},
+			want:    []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:    "multiplier",
+			backoff: ExponentialBackoff{Base: time.Second, Multiplier: 3},
+			want:    []time.Duration{time.Second, 3 * time.Second, 9 * time.Second},
+		},
+		{
+			name:    "capped",
+			backoff: ExponentialBackoff{Base: time.Second, Max: 3 * time.Second},
+			want:    []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+	} {
+		var previous time.Duration
+		for i, want := range tc.want {
+			got := tc.backoff.Next(i+1, previous)
+			if got != want {
+				t.Errorf("%s: attempt %d: got %v, want %v", tc.name, i+1, got, want)
+			}
+			previous = got
+		}
+	}
+}
+
+func TestExponentialBackoffSaturates(t *testing.T) {
+	b := ExponentialBackoff{Base: time.Second}
+	// 2^70 seconds do not fit into a time.Duration
+	for _, attempt := range []int{40, 71, 2000} {
+		if got := b.Next(attempt, 0); got != time.Duration(math.MaxInt64) {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, time.Duration(math.MaxInt64))
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: 5 * time.Second}
+
+	var previous time.Duration
+	distinct := map[time.Duration]bool{}
+	for attempt := 1; attempt <= 1000; attempt++ {
+		got := b.Next(attempt, previous)
+		upper := 3 * previous
+		if upper < 3*b.Base {
+			upper = 3 * b.Base
+		}
+		if upper > b.Max {
+			upper = b.Max
+		}
+		if got < b.Base || got > upper {
+			t.Fatalf("attempt %d after %v: got %v, want within [%v, %v]", attempt, previous, got, b.Base, upper)
+		}
+		distinct[got] = true
+		previous = got
+	}
+	if len(distinct) < 100 {
+		t.Errorf("only %d distinct delays in 1000 attempts", len(distinct))
+	}
+
+	// Without jitter room the base delay is used as is
+	b = DecorrelatedJitterBackoff{Base: 0}
+	if got := b.Next(1, 0); got != 0 {
+		t.Errorf("zero base: got %v", got)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{Delay: time.Second}
+	if got := policy.backoff(ErrorClassUnavailable).Next(1, 0); got != time.Second {
+		t.Errorf("delay: got %v, want 1s", got)
+	}
+
+	policy.Backoff = ExponentialBackoff{Base: 10 * time.Millisecond}
+	policy.ClassBackoff = map[ErrorClass]Backoff{
+		ErrorClassRateLimited: ConstantBackoff{Delay: time.Minute},
+	}
+	if got := policy.backoff(ErrorClassUnavailable).Next(2, 0); got != 20*time.Millisecond {
+		t.Errorf("backoff: got %v, want 20ms", got)
+	}
+	if got := policy.backoff(ErrorClassRateLimited).Next(2, 0); got != time.Minute {
+		t.Errorf("class backoff: got %v, want 1m", got)
+	}
+}
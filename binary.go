@@ -0,0 +1,311 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// ReadBinaryField streams the content of the binary field of a record into w
+// and returns the number of bytes written. The base64 payload is decoded
+// while the response is parsed, so the file exists in memory only as the
+// encoded text of the response instead of as encoded string, decoded bytes
+// and caller copy. An empty field writes nothing.
+func (c *Connector) ReadBinaryField(ctx context.Context, model string, id int64, field string, w io.Writer) (int64, error) {
+	response, finish, err := c.openBinaryField(ctx, model, id, field)
+	if err != nil {
+		return 0, fmt.Errorf("binary read failed for model %s with id %d: %w", model, id, err)
+	}
+	n, err := c.codec().decodeBinaryMember(response, field, w)
+	finish(err)
+	if err != nil {
+		return n, fmt.Errorf("binary read failed for model %s with id %d: %w", model, id, err)
+	}
+	return n, nil
+}
+
+// openBinaryField sends the read of a binary field and returns the response
+// to decode it from. finish must be called with the result of decoding.
+func (c *Connector) openBinaryField(ctx context.Context, model string, id int64, field string) (io.ReadCloser, func(error), error) {
+	args := []interface{}{[]int64{id}}
+	kwargs := map[string]interface{}{"fields": []string{field}}
+
+	release, err := c.admit(ctx, "read", args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	buf := getBuffer()
+	if err := c.codec().encodeCall(buf, serviceObject, "execute_kw", c.executeArgs(model, "read", args, kwargs)); err != nil {
+		putBuffer(buf)
+		release()
+		return nil, nil, err
+	}
+
+	ctx, done := c.startCall(ctx, model, "read")
+	response, err := c.postStream(ctx, c.URL, serviceObject, buf.Bytes(), func() { putBuffer(buf) })
+	if err != nil {
+		done(err)
+		release()
+		return nil, nil, err
+	}
+	return response, func(err error) {
+		response.Close()
+		done(err)
+		release()
+	}, nil
+}
+
+// WriteBinaryField stores the content read from r in the binary field of a
+// record. The content is base64-encoded while the request is sent, so it is
+// never held in memory as a whole. The call is retried and repeated after
+// re-authentication only if r is an io.Seeker, which is rewound.
+func (c *Connector) WriteBinaryField(ctx context.Context, model string, id int64, field string, r io.Reader) error {
+	var result bool
+	err := c.execute(ctx, model, "write", []interface{}{[]int64{id}, map[string]interface{}{
+		field: newBinaryValue(r),
+	}}, nil, &result)
+	if err != nil {
+		return fmt.Errorf("binary write failed for model %s with id %d: %w", model, id, err)
+	}
+	if !result {
+		return fmt.Errorf("binary write failed for model %s with id %d: %w", model, id, errNoRecordUpdated)
+	}
+	return nil
+}
+
+// binaryValue is encoded by reading r into a base64 value. When the call is
+// sent again, r is rewound to where it started; readers that cannot seek can
+// only be sent once.
+type binaryValue struct {
+	r          io.Reader
+	sent       bool
+	rewindable bool
+	start      int64
+}
+
+func newBinaryValue(r io.Reader) *binaryValue {
+	return &binaryValue{r: r}
+}
+
+// reader returns r, rewound if it was sent before
+func (v *binaryValue) reader() (io.Reader, error) {
+	seeker, ok := v.r.(io.Seeker)
+	if !v.sent {
+		v.sent = true
+		if ok {
+			// Pipes opened as files are Seekers that fail to seek
+			start, err := seeker.Seek(0, io.SeekCurrent)
+			v.rewindable, v.start = err == nil, start
+		}
+		return v.r, nil
+	}
+	if !v.rewindable {
+		return nil, errors.New("binary content cannot be sent again")
+	}
+	if _, err := seeker.Seek(v.start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return v.r, nil
+}
+
+// resendable reports whether the call carrying v may be sent (again)
+func (v *binaryValue) resendable() bool {
+	return !v.sent || v.rewindable
+}
+
+func (v *binaryValue) encode(buf encodeBuffer) error {
+	buf.WriteString("<value><base64>")
+	if err := v.writeBase64(buf); err != nil {
+		return err
+	}
+	buf.WriteString("</base64></value>")
+	return nil
+}
+
+// encodeJSON writes the content as a base64 string
+func (v *binaryValue) encodeJSON(buf encodeBuffer) error {
+	buf.WriteByte('"')
+	if err := v.writeBase64(buf); err != nil {
+		return err
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+func (v *binaryValue) writeBase64(w io.Writer) error {
+	r, err := v.reader()
+	if err != nil {
+		return err
+	}
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := io.Copy(enc, r); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// MarshalJSON encodes the content for JSON-RPC when it is nested in a value
+// the streaming encoder hands to encoding/json, which buffers it
+func (v *binaryValue) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := v.encodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// binaryValuesOf returns the binary values in the arguments of a call
+func binaryValuesOf(v interface{}) []*binaryValue {
+	switch t := v.(type) {
+	case *binaryValue:
+		return []*binaryValue{t}
+	case []interface{}:
+		var values []*binaryValue
+		for _, item := range t {
+			values = append(values, binaryValuesOf(item)...)
+		}
+		return values
+	case map[string]interface{}:
+		var values []*binaryValue
+		for _, item := range t {
+			values = append(values, binaryValuesOf(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// resendable reports whether a call with these binary values may be retried
+func resendable(values []*binaryValue) bool {
+	for _, v := range values {
+		if !v.resendable() {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeBinaryMember scans a read response for the struct member named field
+// and writes its decoded base64 content to w
+func decodeBinaryMember(r io.Reader, field string, w io.Writer) (int64, error) {
+	dec := &valueDecoder{xml.NewDecoder(r)}
+	dec.CharsetReader = xmlrpc.CharsetReader
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return 0, fmt.Errorf("field %s not found in response", field)
+		}
+		if err != nil {
+			return 0, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "fault":
+			return 0, dec.fault()
+		case "name":
+			name, err := dec.text()
+			if err != nil {
+				return 0, err
+			}
+			if name == field {
+				return dec.binaryValue(w)
+			}
+		}
+	}
+}
+
+// binaryValue decodes the <value> following a member name. Odoo sends the
+// content as a base64 or string element, or false when the field is empty.
+func (d *valueDecoder) binaryValue(w io.Writer) (int64, error) {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return 0, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local == "value" {
+			continue
+		}
+		if start.Name.Local != "base64" && start.Name.Local != "string" {
+			return 0, d.Skip()
+		}
+
+		out := &base64Writer{w: w}
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return out.n, err
+			}
+			switch t := tok.(type) {
+			case xml.CharData:
+				if _, err := out.Write(t); err != nil {
+					return out.n, err
+				}
+			case xml.EndElement:
+				return out.n, out.Close()
+			}
+		}
+	}
+}
+
+// base64Writer decodes base64 text written to it in arbitrary chunks,
+// ignoring line breaks, and writes the decoded bytes to w. It holds at most
+// base64ChunkSize characters at a time.
+type base64Writer struct {
+	w       io.Writer
+	n       int64
+	pending []byte
+	decoded []byte
+}
+
+// base64ChunkSize is a multiple of 4 so full chunks decode without padding
+const base64ChunkSize = 32 << 10
+
+func (b *base64Writer) Write(p []byte) (int, error) {
+	if b.pending == nil {
+		b.pending = make([]byte, 0, base64ChunkSize)
+		b.decoded = make([]byte, base64.StdEncoding.DecodedLen(base64ChunkSize))
+	}
+	for _, ch := range p {
+		if ch == '\n' || ch == '\r' || ch == ' ' || ch == '\t' {
+			continue
+		}
+		b.pending = append(b.pending, ch)
+		if len(b.pending) == base64ChunkSize {
+			if err := b.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (b *base64Writer) Close() error {
+	return b.flush()
+}
+
+func (b *base64Writer) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+	n, err := base64.StdEncoding.Decode(b.decoded, b.pending)
+	b.pending = b.pending[:0]
+	if err != nil {
+		return err
+	}
+	written, err := b.w.Write(b.decoded[:n])
+	b.n += int64(written)
+	return err
+}
@@ -0,0 +1,46 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBinaryMember(t *testing.T) {
+	content := bytes.Repeat([]byte("odoo binary payload\n"), 5000)
+	encoded := base64.StdEncoding.EncodeToString(content)
+	// Python's XML-RPC marshaller wraps base64 lines at 76 characters.
+	var wrapped strings.Builder
+	for i := 0; i < len(encoded); i += 76 {
+		wrapped.WriteString(encoded[i:min(i+76, len(encoded))])
+		wrapped.WriteString("\n")
+	}
+
+	response := `<?xml version="1.0"?><methodResponse><params><param><value><array><data>` +
+		`<value><struct>` +
+		`<member><name>id</name><value><int>7</int></value></member>` +
+		`<member><name>datas</name><value><base64>` + wrapped.String() + `</base64></value></member>` +
+		`</struct></value></data></array></value></param></params></methodResponse>`
+
+	var out bytes.Buffer
+	n, err := decodeBinaryMember(strings.NewReader(response), "datas", &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(content)) || !bytes.Equal(out.Bytes(), content) {
+		t.Errorf("decoded %d bytes, want %d", n, len(content))
+	}
+}
+
+func TestDecodeBinaryMemberEmptyField(t *testing.T) {
+	response := `<?xml version="1.0"?><methodResponse><params><param><value><array><data>` +
+		`<value><struct><member><name>datas</name><value><boolean>0</boolean></value></member></struct></value>` +
+		`</data></array></value></param></params></methodResponse>`
+
+	var out bytes.Buffer
+	n, err := decodeBinaryMember(strings.NewReader(response), "datas", &out)
+	if err != nil || n != 0 {
+		t.Errorf("expected empty content, got %d bytes and error %v", n, err)
+	}
+}
@@ -0,0 +1,217 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ChangeEvent is the message body published for a change
+type ChangeEvent struct {
+	// ID identifies the event; redeliveries of the same change carry the
+	// same ID, so consumers can de-duplicate on it
+	ID        string                 `json:"id"`
+	Type      ChangeType             `json:"type"`
+	Model     string                 `json:"model"`
+	RecordID  int64                  `json:"record_id"`
+	WriteDate string                 `json:"write_date,omitempty"`
+	Record    map[string]interface{} `json:"record,omitempty"`
+}
+
+// Message is a change event ready to be handed to a message broker. Topic
+// maps to a Kafka topic, a NATS subject or an AMQP routing key, Key to the
+// Kafka partition key and ID to the NATS Nats-Msg-Id header or AMQP message
+// ID.
+type Message struct {
+	Topic string
+	Key   string
+	ID    string
+	Body  []byte
+}
+
+// Publisher delivers messages to a broker. Publish must only return once the
+// broker has acknowledged the message.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface
+type PublisherFunc func(ctx context.Context, msg Message) error
+
+// Publish calls f(ctx, msg)
+func (f PublisherFunc) Publish(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// CheckpointStore persists the sync state of each model between runs
+type CheckpointStore interface {
+	Load(ctx context.Context, model string) (SyncState, error)
+	Save(ctx context.Context, model string, state SyncState) error
+}
+
+// FileCheckpointStore keeps checkpoints as JSON files in a directory
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// Load reads the checkpoint of model; a missing file yields the zero state
+func (s FileCheckpointStore) Load(ctx context.Context, model string) (SyncState, error) {
+	var state SyncState
+	data, err := os.ReadFile(s.path(model))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	return state, json.Unmarshal(data, &state)
+}
+
+// Save writes the checkpoint of model, replacing the previous file atomically
+func (s FileCheckpointStore) Save(ctx context.Context, model string, state SyncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	tmp := s.path(model) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(model))
+}
+
+func (s FileCheckpointStore) path(model string) string {
+	return filepath.Join(s.Dir, model+".json")
+}
+
+// CDCModel selects a model to publish changes of
+type CDCModel struct {
+	Model string
+	SyncOptions
+}
+
+// CDCOptions configures a ChangePublisher
+type CDCOptions struct {
+	Models []CDCModel
+	// Topic returns the topic of an event; the default is "odoo.<model>"
+	Topic func(ChangeEvent) string
+}
+
+// ChangePublisher turns record changes into an event stream. Each poll
+// publishes the changes since the stored checkpoint and then advances it, so
+// events are delivered at least once: after a failure or crash the
+// unacknowledged changes are published again.
+type ChangePublisher struct {
+	c     *Connector
+	pub   Publisher
+	store CheckpointStore
+	opts  CDCOptions
+}
+
+// NewChangePublisher returns a change publisher for the models in opts
+func (c *Connector) NewChangePublisher(pub Publisher, store CheckpointStore, opts CDCOptions) *ChangePublisher {
+	if opts.Topic == nil {
+		opts.Topic = func(event ChangeEvent) string { return "odoo." + event.Model }
+	}
+	return &ChangePublisher{c: c, pub: pub, store: store, opts: opts}
+}
+
+// Poll publishes the pending changes of every model once
+func (p *ChangePublisher) Poll(ctx context.Context) error {
+	for _, model := range p.opts.Models {
+		if err := p.pollModel(ctx, model); err != nil {
+			return fmt.Errorf("change publishing failed for model %s: %w", model.Model, err)
+		}
+	}
+	return nil
+}
+
+func (p *ChangePublisher) pollModel(ctx context.Context, model CDCModel) error {
+	state, err := p.store.Load(ctx, model.Model)
+	if err != nil {
+		return err
+	}
+
+	state, err = p.c.SyncTo(ctx, model.Model, state, model.SyncOptions, publisherSink{p})
+	// Save the progress made before a failure as well
+	if saveErr := p.store.Save(ctx, model.Model, state); err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+// publisherSink publishes each change of a batch as it is written; Commit has
+// nothing left to do since Publish waits for the broker's acknowledgement
+type publisherSink struct {
+	p *ChangePublisher
+}
+
+func (s publisherSink) Open(ctx context.Context, schema SinkSchema) error { return nil }
+
+func (s publisherSink) WriteBatch(ctx context.Context, batch []Change) error {
+	for _, change := range batch {
+		if err := s.p.publish(ctx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s publisherSink) Commit(ctx context.Context) error { return nil }
+
+func (s publisherSink) Close() error { return nil }
+
+func (p *ChangePublisher) publish(ctx context.Context, change Change) error {
+	event := ChangeEvent{
+		Type:     change.Type,
+		Model:    change.Model,
+		RecordID: change.ID,
+		Record:   change.Record,
+	}
+	if change.Type == ChangeDeleted {
+		event.ID = fmt.Sprintf("%s/%d/deleted", change.Model, change.ID)
+	} else {
+		// The event ID is only stable across redeliveries with the write date
+		writeDate, ok := change.Record[p.c.aliasesOf(change.Model).alias("write_date")].(string)
+		if !ok {
+			return fmt.Errorf("record %d has no write_date", change.ID)
+		}
+		event.WriteDate = writeDate
+		event.ID = fmt.Sprintf("%s/%d/%s", change.Model, change.ID, writeDate)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.pub.Publish(ctx, Message{
+		Topic: p.opts.Topic(event),
+		Key:   fmt.Sprintf("%s/%d", change.Model, change.ID),
+		ID:    event.ID,
+		Body:  body,
+	})
+}
+
+// Run polls every interval until ctx is done. Errors are passed to onError,
+// which may be nil; the next poll resumes from the last checkpoint.
+func (p *ChangePublisher) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Poll(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
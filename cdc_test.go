@@ -0,0 +1,102 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChangePublisher(t *testing.T) {
+	m := &syncModel{}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	m.set(2, "Gemini", "2024-01-01 11:00:00", "2024-01-01 11:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db",
+		WithFieldAliases("res.partner", FieldAliases{"write_date": "modified_at"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var published []Message
+	fail := true
+	pub := PublisherFunc(func(ctx context.Context, msg Message) error {
+		if fail && len(published) == 1 {
+			fail = false
+			return errors.New("broker unavailable")
+		}
+		published = append(published, msg)
+		return nil
+	})
+	store := FileCheckpointStore{Dir: t.TempDir()}
+	p := c.NewChangePublisher(pub, store, CDCOptions{Models: []CDCModel{{
+		Model:       "res.partner",
+		SyncOptions: SyncOptions{Fields: []string{"name"}, BatchSize: 1, TrackDeletes: true},
+	}}})
+	ctx := context.Background()
+
+	// The first poll stops at the broker failure and keeps the checkpoint of
+	// the published change
+	if err := p.Poll(ctx); err == nil {
+		t.Fatal("poll did not fail")
+	}
+	state, err := store.Load(ctx, "res.partner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (SyncState{WriteDate: "2024-01-01 10:00:00", LastID: 1}); !reflect.DeepEqual(state, want) {
+		t.Errorf("checkpoint = %+v, want %+v", state, want)
+	}
+
+	if err := p.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	m.remove(1)
+	if err := p.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, msg := range published {
+		ids = append(ids, msg.ID)
+		if msg.Topic != "odoo.res.partner" || !strings.HasPrefix(msg.ID, msg.Key+"/") {
+			t.Errorf("message %s: topic %s, key %s", msg.ID, msg.Topic, msg.Key)
+		}
+	}
+	want := []string{"res.partner/1/2024-01-01 10:00:00", "res.partner/2/2024-01-01 11:00:00", "res.partner/1/deleted"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("published %q, want %q", ids, want)
+	}
+
+	var event ChangeEvent
+	if err := json.Unmarshal(published[1].Body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != ChangeCreated || event.RecordID != 2 || event.WriteDate != "2024-01-01 11:00:00" || event.Record["name"] != "Gemini" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestChangePublisherWithoutWriteDate(t *testing.T) {
+	m := &syncModel{omit: []string{"write_date"}}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published := 0
+	pub := PublisherFunc(func(ctx context.Context, msg Message) error {
+		published++
+		return nil
+	})
+	p := c.NewChangePublisher(pub, FileCheckpointStore{Dir: t.TempDir()}, CDCOptions{Models: []CDCModel{{Model: "res.partner"}}})
+	err = p.Poll(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no write_date") || published != 0 {
+		t.Errorf("got %v after %d messages, want a missing write_date error", err, published)
+	}
+}
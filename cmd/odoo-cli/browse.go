@@ -0,0 +1,581 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+const browseUsage = `Usage: odoo-cli browse [model] [flags]
+
+Opens a full-screen browser of the models, their fields and their records,
+starting at model if given.
+
+Keys:
+
+  up/down, j/k    move          enter       open
+  pgup/pgdown     scroll        esc, left   back
+  n/p             next/previous page of records
+  f               fields of the model         r   records of the model
+  /               filter the list             q   quit
+
+Flags:
+`
+
+// browsePageSize is the number of records loaded per page
+const browsePageSize = 100
+
+func runBrowse(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("browse", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), browseUsage)
+		fs.PrintDefaults()
+	}
+	var conn connFlags
+	conn.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	c, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	b := &browser{src: connectorSource{ctx, c}, fields: make(map[string]map[string]fieldInfo)}
+	root, err := b.modelsView()
+	if err != nil {
+		return err
+	}
+	b.push(root)
+	if model := fs.Arg(0); model != "" {
+		view, err := b.recordsView(model)
+		if err != nil {
+			return err
+		}
+		b.push(view)
+	}
+
+	t, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	for {
+		width, height := t.size()
+		t.draw(b.render(width, height))
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-t.keys:
+			if !ok || !b.handleKey(key, height) {
+				return nil
+			}
+		}
+	}
+}
+
+// browseSource is the data shown by the browser
+type browseSource interface {
+	models() ([]map[string]interface{}, error)
+	fieldsGet(model string) (map[string]interface{}, error)
+	count(model string) (int64, error)
+	records(model string, opts odoo.SearchReadOptions) ([]map[string]interface{}, error)
+}
+
+type connectorSource struct {
+	ctx context.Context
+	c   *odoo.Connector
+}
+
+func (s connectorSource) models() ([]map[string]interface{}, error) {
+	return s.c.SearchReadRecordsContext(s.ctx, "ir.model", odoo.SearchReadOptions{
+		Fields: []string{"model", "name"},
+		Domain: []interface{}{[]interface{}{"transient", "=", false}},
+		Order:  "model",
+	})
+}
+
+func (s connectorSource) fieldsGet(model string) (map[string]interface{}, error) {
+	result, err := s.c.ExecuteMethodContext(s.ctx, model, "fields_get", []interface{}{}, map[string]interface{}{
+		"attributes": []string{"string", "type", "relation", "required", "readonly", "store", "help"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields, _ := result.(map[string]interface{})
+	return fields, nil
+}
+
+func (s connectorSource) count(model string) (int64, error) {
+	result, err := s.c.ExecuteMethodContext(s.ctx, model, "search_count", []interface{}{[]interface{}{}}, nil)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := result.(int64)
+	return n, nil
+}
+
+func (s connectorSource) records(model string, opts odoo.SearchReadOptions) ([]map[string]interface{}, error) {
+	return s.c.SearchReadRecordsContext(s.ctx, model, opts)
+}
+
+// fieldInfo is the metadata of a field shown by the browser
+type fieldInfo struct {
+	name, label, typ, relation, help string
+	required, readonly, stored       bool
+}
+
+// listRow is a line of a list view; open, if set, is called on enter
+type listRow struct {
+	text string
+	open func() (*listView, error)
+}
+
+// listView is a screen of the browser: a scrollable, filterable list
+type listView struct {
+	title  string
+	header string
+	rows   []listRow
+	// keys holds view specific key bindings opening another view
+	keys map[string]func() (*listView, error)
+	// page loads the rows at offset for paged views
+	page   func(offset int) ([]listRow, error)
+	offset int
+	total  int64
+
+	cursor, top int
+	filter      string
+	filtering   bool
+}
+
+// visible returns the rows matching the filter
+func (v *listView) visible() []listRow {
+	if v.filter == "" {
+		return v.rows
+	}
+	filter := strings.ToLower(v.filter)
+	var rows []listRow
+	for _, row := range v.rows {
+		if strings.Contains(strings.ToLower(row.text), filter) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// browser is the state of the browse command: a stack of views
+type browser struct {
+	src    browseSource
+	stack  []*listView
+	status string
+	fields map[string]map[string]fieldInfo
+}
+
+func (b *browser) push(v *listView) {
+	b.stack = append(b.stack, v)
+}
+
+func (b *browser) current() *listView {
+	return b.stack[len(b.stack)-1]
+}
+
+// handleKey applies a key press and reports whether to keep running
+func (b *browser) handleKey(key string, height int) bool {
+	v := b.current()
+	b.status = ""
+	rows := v.visible()
+	pageRows := listHeight(v, height)
+
+	if v.filtering {
+		switch key {
+		case keyEnter:
+			v.filtering = false
+		case keyEscape:
+			v.filtering, v.filter = false, ""
+		case keyBackspace:
+			if r := []rune(v.filter); len(r) > 0 {
+				v.filter = string(r[:len(r)-1])
+			}
+		case keyCtrlC:
+			return false
+		default:
+			if len([]rune(key)) == 1 {
+				v.filter += key
+			}
+		}
+		v.cursor, v.top = 0, 0
+		return true
+	}
+
+	switch key {
+	case "q", keyCtrlC:
+		return false
+	case keyUp, "k":
+		v.cursor--
+	case keyDown, "j":
+		v.cursor++
+	case keyPageUp:
+		v.cursor -= pageRows
+	case keyPageDown:
+		v.cursor += pageRows
+	case keyHome, "g":
+		v.cursor = 0
+	case keyEnd, "G":
+		v.cursor = len(rows) - 1
+	case "/":
+		v.filtering = true
+	case keyEscape, keyLeft, keyBackspace, "h":
+		if v.filter != "" {
+			v.filter = ""
+		} else if len(b.stack) > 1 {
+			b.stack = b.stack[:len(b.stack)-1]
+		}
+		return true
+	case "n", "p":
+		b.turnPage(v, key == "n")
+	case keyEnter, keyRight, "l":
+		if v.cursor >= 0 && v.cursor < len(rows) && rows[v.cursor].open != nil {
+			b.open(rows[v.cursor].open)
+		}
+	default:
+		if open, ok := v.keys[key]; ok {
+			b.open(open)
+		}
+	}
+
+	if v.cursor >= len(rows) {
+		v.cursor = len(rows) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	if v.cursor < v.top {
+		v.top = v.cursor
+	}
+	if v.cursor >= v.top+pageRows {
+		v.top = v.cursor - pageRows + 1
+	}
+	return true
+}
+
+func (b *browser) open(open func() (*listView, error)) {
+	view, err := open()
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	b.push(view)
+}
+
+func (b *browser) turnPage(v *listView, next bool) {
+	if v.page == nil {
+		return
+	}
+	offset := v.offset - browsePageSize
+	if next {
+		offset = v.offset + browsePageSize
+	}
+	if offset < 0 || int64(offset) >= v.total {
+		return
+	}
+	rows, err := v.page(offset)
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	v.rows, v.offset = rows, offset
+	v.cursor, v.top, v.filter = 0, 0, ""
+}
+
+// listHeight is the number of rows a view shows on a screen of height lines
+func listHeight(v *listView, height int) int {
+	n := height - 2 // title and status lines
+	if v.header != "" {
+		n--
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// render draws the current view
+func (b *browser) render(width, height int) []string {
+	v := b.current()
+	rows := v.visible()
+
+	title := v.title
+	if v.page != nil && v.total > 0 {
+		last := v.offset + len(v.rows)
+		title += fmt.Sprintf("  [%d-%d of %d]", v.offset+1, last, v.total)
+	}
+	lines := []string{"\x1b[7m" + fit(title, width) + "\x1b[0m"}
+	if v.header != "" {
+		lines = append(lines, "\x1b[1m"+fit(v.header, width)+"\x1b[0m")
+	}
+
+	n := listHeight(v, height)
+	for i := v.top; i < v.top+n; i++ {
+		switch {
+		case i >= len(rows):
+			lines = append(lines, "")
+		case i == v.cursor:
+			lines = append(lines, "\x1b[7m"+fit(rows[i].text, width)+"\x1b[0m")
+		default:
+			lines = append(lines, fit(rows[i].text, width))
+		}
+	}
+
+	status := b.status
+	switch {
+	case v.filtering:
+		status = "/" + v.filter
+	case status == "" && v.filter != "":
+		status = fmt.Sprintf("filter: %s (%d matches, esc to clear)", v.filter, len(rows))
+	case status == "":
+		status = "enter open · esc back · / filter · q quit"
+		if v.page != nil {
+			status = "n/p page · f fields · " + status
+		} else if _, ok := v.keys["r"]; ok {
+			status = "r records · " + status
+		}
+	}
+	return append(lines, fit(status, width))
+}
+
+// modelsView lists the models of the database
+func (b *browser) modelsView() (*listView, error) {
+	models, err := b.src.models()
+	if err != nil {
+		return nil, fmt.Errorf("listing models failed: %w", err)
+	}
+	v := &listView{title: fmt.Sprintf("Models (%d)", len(models)), header: fmt.Sprintf("%-40s %s", "MODEL", "NAME")}
+	for _, m := range models {
+		model, _ := m["model"].(string)
+		name, _ := m["name"].(string)
+		v.rows = append(v.rows, listRow{
+			text: fmt.Sprintf("%-40s %s", model, name),
+			open: func() (*listView, error) { return b.fieldsView(model) },
+		})
+	}
+	return v, nil
+}
+
+// modelFields returns the field metadata of model, fetching it once
+func (b *browser) modelFields(model string) (map[string]fieldInfo, error) {
+	if fields, ok := b.fields[model]; ok {
+		return fields, nil
+	}
+	raw, err := b.src.fieldsGet(model)
+	if err != nil {
+		return nil, fmt.Errorf("reading fields of %s failed: %w", model, err)
+	}
+	fields := make(map[string]fieldInfo, len(raw))
+	for name, def := range raw {
+		attrs, _ := def.(map[string]interface{})
+		info := fieldInfo{name: name}
+		info.label, _ = attrs["string"].(string)
+		info.typ, _ = attrs["type"].(string)
+		info.relation, _ = attrs["relation"].(string)
+		info.help, _ = attrs["help"].(string)
+		info.required, _ = attrs["required"].(bool)
+		info.readonly, _ = attrs["readonly"].(bool)
+		info.stored, _ = attrs["store"].(bool)
+		fields[name] = info
+	}
+	b.fields[model] = fields
+	return fields, nil
+}
+
+// fieldsView lists the fields of model with their metadata
+func (b *browser) fieldsView(model string) (*listView, error) {
+	fields, err := b.modelFields(model)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v := &listView{
+		title:  fmt.Sprintf("%s · %d fields", model, len(fields)),
+		header: fmt.Sprintf("%-32s %-12s %-5s %-28s %s", "FIELD", "TYPE", "FLAGS", "RELATION", "LABEL"),
+		keys: map[string]func() (*listView, error){
+			"r": func() (*listView, error) { return b.recordsView(model) },
+		},
+	}
+	for _, name := range names {
+		f := fields[name]
+		flags := []byte("---")
+		if f.required {
+			flags[0] = 'R'
+		}
+		if f.readonly {
+			flags[1] = 'r'
+		}
+		if f.stored {
+			flags[2] = 's'
+		}
+		row := listRow{text: fmt.Sprintf("%-32s %-12s %-5s %-28s %s", name, f.typ, flags, f.relation, f.label)}
+		if f.help != "" {
+			row.text += " — " + f.help
+		}
+		if f.relation != "" {
+			relation := f.relation
+			row.open = func() (*listView, error) { return b.fieldsView(relation) }
+		}
+		v.rows = append(v.rows, row)
+	}
+	return v, nil
+}
+
+// recordsView pages through the records of model
+func (b *browser) recordsView(model string) (*listView, error) {
+	total, err := b.src.count(model)
+	if err != nil {
+		return nil, fmt.Errorf("counting %s records failed: %w", model, err)
+	}
+	v := &listView{
+		title:  fmt.Sprintf("%s · records", model),
+		header: fmt.Sprintf("%8s  %s", "ID", "NAME"),
+		total:  total,
+		keys: map[string]func() (*listView, error){
+			"f": func() (*listView, error) { return b.fieldsView(model) },
+		},
+	}
+	v.page = func(offset int) ([]listRow, error) {
+		records, err := b.src.records(model, odoo.SearchReadOptions{
+			Fields: []string{"id", "display_name"},
+			Offset: offset,
+			Limit:  browsePageSize,
+			Order:  "id",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s records failed: %w", model, err)
+		}
+		rows := make([]listRow, len(records))
+		for i, record := range records {
+			id, _ := record["id"].(int64)
+			rows[i] = listRow{
+				text: fmt.Sprintf("%8d  %s", id, formatName(record["display_name"])),
+				open: func() (*listView, error) { return b.recordView(model, id) },
+			}
+		}
+		return rows, nil
+	}
+	if v.rows, err = v.page(0); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// recordView shows every field of a record; relational values open the
+// related records
+func (b *browser) recordView(model string, id int64) (*listView, error) {
+	fields, err := b.modelFields(model)
+	if err != nil {
+		return nil, err
+	}
+	// Binary fields are left out; they can be megabytes of base64
+	var names []string
+	for name, f := range fields {
+		if f.typ != "binary" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	records, err := b.src.records(model, odoo.SearchReadOptions{
+		Fields: names,
+		Domain: []interface{}{[]interface{}{"id", "=", id}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s %d failed: %w", model, id, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s %d not found", model, id)
+	}
+	record := records[0]
+
+	v := &listView{
+		title:  fmt.Sprintf("%s · %d %s", model, id, formatName(record["display_name"])),
+		header: fmt.Sprintf("%-32s %s", "FIELD", "VALUE"),
+		keys: map[string]func() (*listView, error){
+			"f": func() (*listView, error) { return b.fieldsView(model) },
+		},
+	}
+	for _, name := range names {
+		value := record[name]
+		row := listRow{text: fmt.Sprintf("%-32s %s", name, formatValue(value))}
+		if f := fields[name]; f.relation != "" {
+			if ids := relatedIDs(value); len(ids) > 0 {
+				relation := fields[name].relation
+				if len(ids) == 1 {
+					row.open = func() (*listView, error) { return b.recordView(relation, ids[0]) }
+				} else {
+					row.open = func() (*listView, error) { return b.relatedView(relation, ids) }
+				}
+			}
+		}
+		v.rows = append(v.rows, row)
+	}
+	return v, nil
+}
+
+// relatedView lists the records of an x2many value
+func (b *browser) relatedView(model string, ids []int64) (*listView, error) {
+	records, err := b.src.records(model, odoo.SearchReadOptions{
+		Fields: []string{"id", "display_name"},
+		Domain: []interface{}{[]interface{}{"id", "in", ids}},
+		Order:  "id",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s records failed: %w", model, err)
+	}
+	v := &listView{title: fmt.Sprintf("%s · %d records", model, len(records)), header: fmt.Sprintf("%8s  %s", "ID", "NAME")}
+	for _, record := range records {
+		id, _ := record["id"].(int64)
+		v.rows = append(v.rows, listRow{
+			text: fmt.Sprintf("%8d  %s", id, formatName(record["display_name"])),
+			open: func() (*listView, error) { return b.recordView(model, id) },
+		})
+	}
+	return v, nil
+}
+
+// relatedIDs returns the record IDs of a many2one or x2many value
+func relatedIDs(value interface{}) []int64 {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	if len(items) == 2 {
+		if _, isName := items[1].(string); isName {
+			items = items[:1] // many2one pair
+		}
+	}
+	var ids []int64
+	for _, item := range items {
+		if id, ok := item.(int64); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func formatName(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
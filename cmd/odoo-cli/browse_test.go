@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// fakeSource serves a res.partner model with 250 records
+type fakeSource struct{}
+
+func (fakeSource) models() ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"model": "res.company", "name": "Companies"},
+		{"model": "res.partner", "name": "Contact"},
+	}, nil
+}
+
+func (fakeSource) fieldsGet(model string) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"name":       map[string]interface{}{"string": "Name", "type": "char", "required": true, "store": true},
+		"company_id": map[string]interface{}{"string": "Company", "type": "many2one", "relation": "res.company", "store": true},
+		"image_1920": map[string]interface{}{"string": "Image", "type": "binary"},
+	}, nil
+}
+
+func (fakeSource) count(model string) (int64, error) { return 250, nil }
+
+func (fakeSource) records(model string, opts odoo.SearchReadOptions) ([]map[string]interface{}, error) {
+	if len(opts.Domain) > 0 {
+		return []map[string]interface{}{{"id": int64(7), "display_name": "Partner 7", "name": "Partner 7", "company_id": []interface{}{int64(1), "My Company"}}}, nil
+	}
+	var records []map[string]interface{}
+	for id := opts.Offset + 1; id <= opts.Offset+opts.Limit && id <= 250; id++ {
+		records = append(records, map[string]interface{}{"id": int64(id), "display_name": fmt.Sprintf("Partner %d", id)})
+	}
+	return records, nil
+}
+
+func TestBrowser(t *testing.T) {
+	b := &browser{src: fakeSource{}, fields: make(map[string]map[string]fieldInfo)}
+	root, err := b.modelsView()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.push(root)
+
+	press := func(keys ...string) {
+		for _, key := range keys {
+			if !b.handleKey(key, 10) {
+				t.Fatalf("key %q quit the browser", key)
+			}
+		}
+	}
+
+	// Filter the models down to res.partner and open its fields
+	press("/", "p", "a", "r", keyEnter, keyEnter)
+	if got := b.current().title; got != "res.partner · 3 fields" {
+		t.Fatalf("title = %q", got)
+	}
+
+	// Open the records, page forward and open a record
+	press("r", "n")
+	v := b.current()
+	if v.offset != browsePageSize || !strings.HasPrefix(v.rows[0].text, "     101") {
+		t.Fatalf("second page starts at offset %d with %q", v.offset, v.rows[0].text)
+	}
+	screen := b.render(40, 10)
+	if len(screen) != 10 || !strings.Contains(screen[0], "[101-200 of 250]") {
+		t.Fatalf("unexpected screen %q", screen)
+	}
+	press(keyDown, keyEnter)
+	var fields []string
+	for _, row := range b.current().rows {
+		fields = append(fields, strings.Fields(row.text)[0])
+	}
+	if want := []string{"company_id", "name"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("record fields = %v, want %v", fields, want)
+	}
+	if b.current().rows[0].open == nil {
+		t.Error("many2one value does not open the related record")
+	}
+
+	// Back to the records, keeping the page and cursor
+	press(keyEscape)
+	if v := b.current(); v.offset != browsePageSize || v.cursor != 1 {
+		t.Errorf("back to offset %d, cursor %d", v.offset, v.cursor)
+	}
+	if b.handleKey("q", 10) {
+		t.Error("q did not quit")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[A\x1b[6~\r\x7fé\x1b\x03"))
+	want := []string{"j", keyUp, keyPageDown, keyEnter, keyBackspace, "é", keyEscape, keyCtrlC}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+const exportUsage = `Usage: odoo-cli export -model <model> [flags]
+
+Streams the records of a model to a file. CSV and JSON Lines are written to
+-output or standard output; Parquet is written to the directory -output.
+
+Example:
+
+  odoo-cli export -model sale.order -fields name,partner_id,amount_total \
+    -format csv -since 2024-01-01 -output orders.csv
+
+Flags:
+`
+
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), exportUsage)
+		fs.PrintDefaults()
+	}
+	var conn connFlags
+	conn.register(fs)
+	model := fs.String("model", "", "model to export, e.g. sale.order (required)")
+	fields := fs.String("fields", "", "comma-separated fields; defaults to all stored, non-binary fields")
+	domain := fs.String("domain", "", "domain filter, e.g. \"[('state', '=', 'sale')]\"")
+	since := fs.String("since", "", "only records written at or after this UTC date or datetime")
+	order := fs.String("order", "", "sort order, e.g. \"date_order desc\"")
+	limit := fs.Int("limit", 0, "maximum number of records (default all)")
+	format := fs.String("format", "", "csv, jsonl or parquet; defaults to the -output extension, else csv")
+	output := fs.String("output", "", "output file, or directory for parquet; defaults to standard output")
+	batchSize := fs.Int("batch-size", 500, "records per batch, or rows per row group for parquet")
+	partitionBy := fs.String("partition-by", "", "parquet only: field to partition the files by")
+	compress := fs.Bool("compress", false, "parquet only: gzip the data pages")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *model == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	query := odoo.SearchReadOptions{
+		Fields: splitList(*fields),
+		Order:  *order,
+		Limit:  *limit,
+	}
+	if *domain != "" {
+		parsed, err := odoo.ParseDomain(*domain)
+		if err != nil {
+			return fmt.Errorf("-domain: %w", err)
+		}
+		query.Domain = parsed
+	}
+	if *since != "" {
+		t, err := parseSince(*since)
+		if err != nil {
+			return err
+		}
+		query.Domain = append(query.Domain, []interface{}{"write_date", ">=", t.Format(time.DateTime)})
+	}
+
+	if *format == "" {
+		*format = formatOf(*output)
+	}
+	if *format != "parquet" && (*partitionBy != "" || *compress) {
+		return fmt.Errorf("-partition-by and -compress require -format parquet")
+	}
+
+	c, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "parquet":
+		if *output == "" {
+			return fmt.Errorf("-output directory is required for parquet")
+		}
+		export, err := c.ExportParquet(ctx, *model, odoo.ParquetOptions{
+			Query:        query,
+			Dir:          *output,
+			PartitionBy:  *partitionBy,
+			RowGroupSize: *batchSize,
+			Compress:     *compress,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d records to %d files in %s\n", export.Rows, len(export.Files), *output)
+		return nil
+	case "csv", "jsonl":
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	var w io.Writer = struct{ io.Writer }{os.Stdout} // hides Close from the sink
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		w = f
+	}
+	var sink odoo.Sink = odoo.NewJSONLSink(w)
+	if *format == "csv" {
+		sink = odoo.NewCSVSink(w)
+	}
+
+	rows, err := c.Export(ctx, *model, odoo.ExportOptions{Query: query, BatchSize: *batchSize}, sink)
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "exported %d records to %s\n", rows, *output)
+	}
+	return nil
+}
+
+// parseSince parses the -since flag: a date, a date and time or RFC 3339.
+// Dates without a zone are UTC, like Odoo's write_date.
+func parseSince(s string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, time.DateTime, "2006-01-02T15:04:05", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("-since: %q is not a date (2006-01-02) or datetime (2006-01-02 15:04:05)", s)
+}
+
+// formatOf guesses the export format from the output path
+func formatOf(output string) string {
+	switch strings.ToLower(filepath.Ext(output)) {
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	case ".parquet":
+		return "parquet"
+	}
+	if output != "" {
+		if info, err := os.Stat(output); err == nil && info.IsDir() {
+			return "parquet"
+		}
+	}
+	return "csv"
+}
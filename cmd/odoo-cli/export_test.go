@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range []string{"2024-01-01", "2024-01-01 00:00:00", "2024-01-01T00:00:00", "2024-01-01T01:00:00+01:00"} {
+		got, err := parseSince(s)
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", s, err)
+			continue
+		}
+		if !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("parseSince(%q) = %v, want %v", s, got, want)
+		}
+	}
+	if _, err := parseSince("yesterday"); err == nil {
+		t.Error("parseSince(\"yesterday\"): expected an error")
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	dir := t.TempDir()
+	for output, want := range map[string]string{
+		"":               "csv",
+		"orders.csv":     "csv",
+		"orders.JSONL":   "jsonl",
+		"orders.ndjson":  "jsonl",
+		"orders.parquet": "parquet",
+		dir:              "parquet",
+	} {
+		if got := formatOf(output); got != want {
+			t.Errorf("formatOf(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
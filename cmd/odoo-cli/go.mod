@@ -0,0 +1,13 @@
+module github.com/RolandZimmermann/go-odoo-connector/cmd/odoo-cli
+
+go 1.21
+
+require (
+	github.com/RolandZimmermann/go-odoo-connector v0.0.0-20261016175719-57a9483c6932
+	golang.org/x/term v0.21.0
+)
+
+require (
+	github.com/kolo/xmlrpc v0.0.0-20220921171641-a4b6fa1dd06b // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
@@ -0,0 +1,133 @@
+// Command odoo-cli works with Odoo data from the shell using the connector.
+//
+// Usage:
+//
+//	odoo-cli <command> [flags]
+//
+// Commands:
+//
+//	export    write the records of a model to a CSV, JSON Lines or Parquet file
+//	watch     print the records of a model as they change
+//	browse    browse models, fields and records in a full-screen terminal UI
+//	serve     serve models as a cached JSON REST API
+//
+// The connection is read from the JSON file given with -config (see
+// odoo.LoadConfig) or from the ODOO_URL, ODOO_USERNAME, ODOO_API_KEY and
+// ODOO_DB environment variables.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// command is an odoo-cli subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"export", "write the records of a model to a CSV, JSON Lines or Parquet file", runExport},
+	{"watch", "print the records of a model as they change", runWatch},
+	{"browse", "browse models, fields and records in a full-screen terminal UI", runBrowse},
+	{"serve", "serve models as a cached JSON REST API", runServe},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		err := cmd.run(ctx, args)
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "odoo-cli %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "odoo-cli: unknown command %q\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: odoo-cli <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun odoo-cli <command> -h for the flags of a command.\n")
+}
+
+// connFlags are the connection flags shared by all commands
+type connFlags struct {
+	config string
+}
+
+func (f *connFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "JSON connection config; defaults to the ODOO_* environment variables")
+}
+
+// connect returns a connector for the configured Odoo instance
+func (f *connFlags) connect(ctx context.Context) (*odoo.Connector, error) {
+	if f.config != "" {
+		return odoo.NewConnectorFromConfigContext(ctx, f.config)
+	}
+
+	config := odoo.Config{
+		URL:      os.Getenv("ODOO_URL"),
+		Username: os.Getenv("ODOO_USERNAME"),
+		APIKey:   os.Getenv("ODOO_API_KEY"),
+		DB:       os.Getenv("ODOO_DB"),
+	}
+	var missing []string
+	for name, value := range map[string]string{
+		"ODOO_URL":      config.URL,
+		"ODOO_USERNAME": config.Username,
+		"ODOO_API_KEY":  config.APIKey,
+		"ODOO_DB":       config.DB,
+	} {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no -config given and %s not set", strings.Join(missing, ", "))
+	}
+	return odoo.NewConnectorContext(ctx, config.URL, config.Username, config.APIKey, config.DB)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
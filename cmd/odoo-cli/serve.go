@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/RolandZimmermann/go-odoo-connector/odoorest"
+)
+
+const serveUsage = `Usage: odoo-cli serve [flags]
+
+Serves models as a JSON REST API (see package odoorest) with a read-through
+cache: identical searches within -cache-ttl are answered from memory and
+writes through the gateway invalidate the cached searches of their model.
+
+  /models/...     the REST API
+  /openapi.json   its OpenAPI document
+  /metrics        cache metrics in the Prometheus text format
+
+Requests to the API need "Authorization: Bearer <token>" when -token or
+ODOO_GATEWAY_TOKEN is set.
+
+Example:
+
+  odoo-cli serve -read sale.order,product.product -write res.partner -cache-ttl 1m
+
+Flags:
+`
+
+func runServe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), serveUsage)
+		fs.PrintDefaults()
+	}
+	var conn connFlags
+	conn.register(fs)
+	listen := fs.String("listen", ":8080", "address to listen on")
+	read := fs.String("read", "", "comma-separated models to serve read-only")
+	write := fs.String("write", "", "comma-separated models to serve for reading and writing")
+	cacheTTL := fs.Duration("cache-ttl", 30*time.Second, "how long searches are cached; 0 disables the cache")
+	cacheSize := fs.Int("cache-size", 1000, "maximum number of cached searches")
+	token := fs.String("token", os.Getenv("ODOO_GATEWAY_TOKEN"), "bearer token required by the API; defaults to ODOO_GATEWAY_TOKEN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	models := make(map[string][]odoorest.Operation)
+	for _, model := range splitList(*read) {
+		models[model] = odoorest.ReadOnly
+	}
+	for _, model := range splitList(*write) {
+		models[model] = odoorest.AllOperations
+	}
+	if len(models) == 0 {
+		return errors.New("no models to serve, use -read or -write")
+	}
+
+	c, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+	opts := odoorest.Options{Models: models}
+	spec, err := odoorest.OpenAPI(c, opts, odoorest.OpenAPIInfo{Title: "Odoo gateway"})
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	var client odoorest.Client = c
+	if *cacheTTL > 0 {
+		cache := odoorest.NewCachingClient(c, odoorest.CacheOptions{TTL: *cacheTTL, MaxEntries: *cacheSize})
+		client = cache
+		mux.Handle("/metrics", cache.MetricsHandler())
+	}
+	mux.Handle("/models/", requireToken(*token, odoorest.NewHandler(client, opts)))
+	mux.Handle("/openapi.json", requireToken(*token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	})))
+
+	srv := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "warning: no -token set, the API is open to anyone who can reach it")
+	}
+	fmt.Fprintf(os.Stderr, "serving %d models on %s\n", len(models), *listen)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// requireToken rejects requests without the bearer token; an empty token
+// lets every request through
+func requireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, `{"error":"missing or invalid bearer token"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
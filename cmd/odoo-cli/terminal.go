@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Key names returned by terminal.readKey besides printable characters
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyCtrlC     = "ctrl+c"
+)
+
+// terminal is a full-screen terminal in raw mode
+type terminal struct {
+	in    *os.File
+	out   *bufio.Writer
+	state *term.State
+	keys  chan string
+}
+
+// openTerminal switches the terminal to raw mode and the alternate screen
+func openTerminal() (*terminal, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, fmt.Errorf("browse needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	t := &terminal{in: os.Stdin, out: bufio.NewWriter(os.Stdout), state: state, keys: make(chan string)}
+	// Alternate screen, hidden cursor
+	t.out.WriteString("\x1b[?1049h\x1b[?25l")
+	t.out.Flush()
+	go t.readKeys()
+	return t, nil
+}
+
+// Close restores the screen and the terminal mode
+func (t *terminal) Close() error {
+	t.out.WriteString("\x1b[?25h\x1b[?1049l")
+	t.out.Flush()
+	return term.Restore(int(t.in.Fd()), t.state)
+}
+
+// size returns the width and height of the terminal
+func (t *terminal) size() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// draw replaces the screen with lines
+func (t *terminal) draw(lines []string) {
+	t.out.WriteString("\x1b[H\x1b[2J")
+	t.out.WriteString(strings.Join(lines, "\r\n"))
+	t.out.Flush()
+}
+
+func (t *terminal) readKeys() {
+	buf := make([]byte, 64)
+	for {
+		n, err := t.in.Read(buf)
+		if err != nil {
+			close(t.keys)
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			t.keys <- key
+		}
+	}
+}
+
+// escapeKeys maps the escape sequences of special keys to their names
+var escapeKeys = map[string]string{
+	"\x1b[A": keyUp, "\x1b[B": keyDown, "\x1b[C": keyRight, "\x1b[D": keyLeft,
+	"\x1bOA": keyUp, "\x1bOB": keyDown, "\x1bOC": keyRight, "\x1bOD": keyLeft,
+	"\x1b[5~": keyPageUp, "\x1b[6~": keyPageDown,
+	"\x1b[H": keyHome, "\x1b[F": keyEnd, "\x1b[1~": keyHome, "\x1b[4~": keyEnd,
+}
+
+// parseKeys splits raw terminal input into key names. Unknown escape
+// sequences are dropped.
+func parseKeys(b []byte) []string {
+	var keys []string
+	s := string(b)
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == 0x1b:
+			if len(s) == 1 {
+				return append(keys, keyEscape)
+			}
+			end := 2
+			if s[1] == '[' || s[1] == 'O' {
+				// CSI and SS3 sequences end with a byte in 0x40-0x7e
+				for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+					end++
+				}
+				end++
+			}
+			if end > len(s) {
+				end = len(s)
+			}
+			if key, ok := escapeKeys[s[:end]]; ok {
+				keys = append(keys, key)
+			} else if s[1] != '[' && s[1] != 'O' {
+				// Escape followed by a regular key
+				keys = append(keys, keyEscape)
+				end = 1
+			}
+			s = s[end:]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyEnter)
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyBackspace)
+		case c == 0x03:
+			keys = append(keys, keyCtrlC)
+		case c < 0x20:
+			// Other control characters are ignored
+		default:
+			r, size := utf8.DecodeRuneInString(s)
+			keys = append(keys, string(r))
+			s = s[size:]
+			continue
+		}
+		s = s[1:]
+	}
+	return keys
+}
+
+// fit truncates or pads s to width columns, replacing control characters
+// such as newlines in text fields with spaces
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		runes := []rune(s)
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-n)
+}
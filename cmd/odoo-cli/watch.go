@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+const watchUsage = `Usage: odoo-cli watch <model> [flags]
+
+Prints records of a model as they are created, updated or deleted. Updates of
+records seen before show only the fields that changed.
+
+Example:
+
+  odoo-cli watch crm.lead -fields name,stage_id,user_id
+
+Flags:
+`
+
+func runWatch(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), watchUsage)
+		fs.PrintDefaults()
+	}
+	var conn connFlags
+	conn.register(fs)
+	fields := fs.String("fields", "", "comma-separated fields to show; defaults to all fields")
+	domain := fs.String("domain", "", "domain filter, e.g. \"[('type', '=', 'opportunity')]\"")
+	interval := fs.Duration("interval", 2*time.Second, "polling interval")
+	deletes := fs.Bool("deletes", false, "report deleted records, at the cost of a search per poll")
+	fromStart := fs.Bool("from-start", false, "report every existing record first")
+	jsonOutput := fs.Bool("json", false, "print changes as JSON lines")
+
+	// Allow flags after the model: watch crm.lead -fields name
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	model := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	opts := odoo.SyncOptions{Fields: splitList(*fields), TrackDeletes: *deletes}
+	if *domain != "" {
+		parsed, err := odoo.ParseDomain(*domain)
+		if err != nil {
+			return fmt.Errorf("-domain: %w", err)
+		}
+		opts.Domain = parsed
+	}
+
+	c, err := conn.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	var state odoo.SyncState
+	if !*fromStart {
+		if state, err = latestState(ctx, c, model, opts); err != nil {
+			return err
+		}
+	}
+
+	p := &changePrinter{w: os.Stdout, json: *jsonOutput, fields: opts.Fields, seen: make(map[int64]map[string]interface{})}
+	fmt.Fprintf(os.Stderr, "watching %s, press Ctrl+C to stop\n", model)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		next, err := c.SyncChanges(ctx, model, state, opts, p.print)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			// Keep watching through transient failures
+			fmt.Fprintf(os.Stderr, "odoo-cli watch: %v\n", err)
+		}
+		state = next
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestState returns the sync state positioned after the most recently
+// written record, so that only later changes are reported
+func latestState(ctx context.Context, c *odoo.Connector, model string, opts odoo.SyncOptions) (odoo.SyncState, error) {
+	var state odoo.SyncState
+	latest, err := c.SearchReadRecordsContext(ctx, model, odoo.SearchReadOptions{
+		Fields: []string{"id", "write_date"},
+		Domain: opts.Domain,
+		Order:  "write_date desc, id desc",
+		Limit:  1,
+	})
+	if err != nil {
+		return state, err
+	}
+	if len(latest) > 0 {
+		state.WriteDate, _ = latest[0]["write_date"].(string)
+		state.LastID, _ = latest[0]["id"].(int64)
+	}
+	if opts.TrackDeletes {
+		// Record the existing IDs without reporting anything
+		return c.SyncChanges(ctx, model, state, opts, func(odoo.Change) error { return nil })
+	}
+	return state, nil
+}
+
+// changePrinter prints changes and remembers the last seen values of each
+// record to show updates as differences
+type changePrinter struct {
+	w      io.Writer
+	json   bool
+	fields []string
+	seen   map[int64]map[string]interface{}
+}
+
+func (p *changePrinter) print(change odoo.Change) error {
+	record := change.Record
+	if record != nil && len(p.fields) > 0 {
+		// Drop the bookkeeping fields SyncChanges adds
+		shown := make(map[string]interface{}, len(p.fields)+1)
+		shown["id"] = record["id"]
+		for _, name := range p.fields {
+			shown[name] = record[name]
+		}
+		record = shown
+	}
+	previous := p.seen[change.ID]
+	if change.Type == odoo.ChangeDeleted {
+		delete(p.seen, change.ID)
+	} else {
+		p.seen[change.ID] = record
+	}
+
+	if p.json {
+		line, err := json.Marshal(map[string]interface{}{
+			"type":   change.Type,
+			"model":  change.Model,
+			"id":     change.ID,
+			"record": record,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", line)
+		return err
+	}
+
+	_, err := fmt.Fprintf(p.w, "%s %-7s %s %d%s\n", time.Now().Format(time.TimeOnly), change.Type, change.Model, change.ID, describeChange(previous, record))
+	return err
+}
+
+// describeChange formats the fields of record, or only those that differ
+// from previous when it is known
+func describeChange(previous, record map[string]interface{}) string {
+	names := make([]string, 0, len(record))
+	for name := range record {
+		if name == "id" {
+			continue
+		}
+		if previous != nil && reflect.DeepEqual(previous[name], record[name]) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		if previous != nil {
+			fmt.Fprintf(&b, "\n    %s: %s -> %s", name, formatValue(previous[name]), formatValue(record[name]))
+		} else {
+			fmt.Fprintf(&b, "\n    %s: %s", name, formatValue(record[name]))
+		}
+	}
+	return b.String()
+}
+
+// formatValue formats a field value for the terminal: many2one pairs as
+// "name (id)" and Odoo's false for empty values as a dash
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case bool:
+		if !v {
+			return "-"
+		}
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []interface{}:
+		if len(v) == 2 {
+			if name, ok := v[1].(string); ok {
+				return fmt.Sprintf("%s (%v)", name, v[0])
+			}
+		}
+	}
+	return fmt.Sprint(value)
+}
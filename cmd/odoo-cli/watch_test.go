@@ -0,0 +1,15 @@
+package main
+
+import "testing"
+
+func TestDescribeChange(t *testing.T) {
+	previous := map[string]interface{}{"id": int64(7), "name": "Lead", "stage_id": []interface{}{int64(1), "New"}, "user_id": false}
+	record := map[string]interface{}{"id": int64(7), "name": "Lead", "stage_id": []interface{}{int64(3), "Won"}, "user_id": false}
+
+	if got, want := describeChange(nil, record), "\n    name: \"Lead\"\n    stage_id: Won (3)\n    user_id: -"; got != want {
+		t.Errorf("describeChange(nil, record) = %q, want %q", got, want)
+	}
+	if got, want := describeChange(previous, record), "\n    stage_id: New (1) -> Won (3)"; got != want {
+		t.Errorf("describeChange(previous, record) = %q, want %q", got, want)
+	}
+}
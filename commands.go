@@ -0,0 +1,59 @@
+package odoo
+
+// Command is a write command of a one2many or many2many field, the tuples
+// Odoo expects instead of plain values for x2many fields. Pass them through
+// Commands:
+//
+//	values := map[string]interface{}{
+//		"tag_ids":   odoo.Commands(odoo.CommandSet(tagIDs)),
+//		"child_ids": odoo.Commands(odoo.CommandCreate(contact), odoo.CommandLink(42)),
+//	}
+type Command []interface{}
+
+// CommandCreate creates a record from values and links it: (0, 0, values)
+func CommandCreate(values map[string]interface{}) Command {
+	return Command{0, 0, values}
+}
+
+// CommandUpdate writes values to the linked record id: (1, id, values)
+func CommandUpdate(id int64, values map[string]interface{}) Command {
+	return Command{1, id, values}
+}
+
+// CommandDelete deletes the record id and removes the link: (2, id, 0)
+func CommandDelete(id int64) Command {
+	return Command{2, id, 0}
+}
+
+// CommandUnlink removes the link to the record id, keeping the record:
+// (3, id, 0)
+func CommandUnlink(id int64) Command {
+	return Command{3, id, 0}
+}
+
+// CommandLink links the existing record id: (4, id, 0)
+func CommandLink(id int64) Command {
+	return Command{4, id, 0}
+}
+
+// CommandClear removes all links: (5, 0, 0)
+func CommandClear() Command {
+	return Command{5, 0, 0}
+}
+
+// CommandSet replaces the linked records with ids: (6, 0, ids)
+func CommandSet(ids []int64) Command {
+	if ids == nil {
+		ids = []int64{}
+	}
+	return Command{6, 0, ids}
+}
+
+// Commands returns the value of an x2many field applying cmds in order
+func Commands(cmds ...Command) []interface{} {
+	value := make([]interface{}, len(cmds))
+	for i, cmd := range cmds {
+		value[i] = []interface{}(cmd)
+	}
+	return value
+}
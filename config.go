@@ -1,6 +1,7 @@
 package odoo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,6 +13,11 @@ type Config struct {
 	Username string `json:"username"`
 	APIKey   string `json:"api_key"`
 	DB       string `json:"db"`
+	// TLS optionally pins the server certificate or presents a client
+	// certificate
+	TLS *TLSOptions `json:"tls,omitempty"`
+	// Protocol is "xmlrpc" (the default) or "jsonrpc"
+	Protocol Protocol `json:"protocol,omitempty"`
 }
 
 // LoadConfig loads configuration from a JSON file
@@ -39,16 +45,32 @@ func LoadConfig(path string) (*Config, error) {
 	if config.DB == "" {
 		return nil, fmt.Errorf("database name is required in config")
 	}
+	if err := validateProtocol(config.Protocol); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 
 	return &config, nil
 }
 
 // NewConnectorFromConfig creates a new Odoo connector using configuration
 func NewConnectorFromConfig(configPath string) (*Connector, error) {
+	return NewConnectorFromConfigContext(context.Background(), configPath)
+}
+
+// NewConnectorFromConfigContext is NewConnectorFromConfig with a context
+// bounding the authentication call
+func NewConnectorFromConfigContext(ctx context.Context, configPath string) (*Connector, error) {
 	config, err := LoadConfig(configPath)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewConnector(config.URL, config.Username, config.APIKey, config.DB)
+	var opts []Option
+	if config.TLS != nil {
+		opts = append(opts, WithTLS(*config.TLS))
+	}
+	if config.Protocol != "" {
+		opts = append(opts, WithProtocol(config.Protocol))
+	}
+	return NewConnectorContext(ctx, config.URL, config.Username, config.APIKey, config.DB, opts...)
 }
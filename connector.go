@@ -1,5 +1,6 @@
 /*
-Package odoo provides a connector for interacting with Odoo's XML-RPC API.
+Package odoo provides a connector for interacting with Odoo's XML-RPC and
+JSON-RPC APIs.
 
 Example usage:
 
@@ -76,22 +77,53 @@ Common Operators:
 package odoo
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-
-	"github.com/kolo/xmlrpc"
+	"sync"
+	"time"
 )
 
 // Connector represents an Odoo API connection
 type Connector struct {
 	URL      string
 	Username string
-	APIKey   string
-	DB       string
-	UID      int
-	common   *xmlrpc.Client
-	models   *xmlrpc.Client
+	// APIKey and UID are the credentials the connector authenticated with
+	// when it was created. Re-authentication does not update them.
+	APIKey string
+	DB     string
+	UID    int
+
+	httpClient *http.Client
+	tlsOptions *TLSOptions
+	protocol   Protocol
+
+	versionMu     sync.Mutex
+	serverVersion int
+	features      features
+
+	logger *slog.Logger
+	hooks  []Hook
+
+	retry       RetryPolicy
+	retryBudget *retryBudget
+	hedge       HedgePolicy
+	dispatcher  *dispatcher
+
+	reauth    *reauthenticator
+	rateLimit *Throttle
+
+	// apiKey and uid are the current credentials, guarded by reauth.mu
+	apiKey string
+	uid    int
+
+	rules   *Rules
+	aliases map[string]*aliasMap
+
+	sessionPassword string
+	sessionMu       sync.Mutex
+	session         *webSession
 }
 
 // SearchReadOptions contains options for searching and reading records
@@ -101,33 +133,42 @@ type SearchReadOptions struct {
 	Offset int
 	Limit  int
 	Order  string
+	// AllFields makes SearchReadInto read every field when Fields is empty
+	// instead of deriving Fields from the struct tags. Other methods read
+	// every field without Fields anyway.
+	AllFields bool
 }
 
 // NewConnector creates and initializes a new Odoo connector
-func NewConnector(url, username, apiKey, db string) (*Connector, error) {
+func NewConnector(url, username, apiKey, db string, opts ...Option) (*Connector, error) {
+	return NewConnectorContext(context.Background(), url, username, apiKey, db, opts...)
+}
+
+// NewConnectorContext is NewConnector with a context bounding the
+// authentication call
+func NewConnectorContext(ctx context.Context, url, username, apiKey, db string, opts ...Option) (*Connector, error) {
 	c := &Connector{
 		URL:      url,
 		Username: username,
 		APIKey:   apiKey,
 		DB:       db,
 	}
-
-	// Initialize XML-RPC clients
-	var err error
-	transport := &http.Transport{}
-	c.common, err = xmlrpc.NewClient(fmt.Sprintf("%s/xmlrpc/2/common", url), transport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to common endpoint: %w", err)
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := validateProtocol(c.protocol); err != nil {
+		return nil, err
 	}
 
-	c.models, err = xmlrpc.NewClient(fmt.Sprintf("%s/xmlrpc/2/object", url), transport)
+	transport, err := newTransport(c.tlsOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to models endpoint: %w", err)
+		return nil, err
 	}
+	c.httpClient = &http.Client{Transport: transport}
 
 	// Authenticate and get user ID
 	var uid int
-	err = c.common.Call("authenticate", []interface{}{db, username, apiKey, map[string]string{}}, &uid)
+	err = c.call(ctx, serviceCommon, "authenticate", []interface{}{db, username, apiKey, map[string]string{}}, &uid)
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
@@ -135,15 +176,38 @@ func NewConnector(url, username, apiKey, db string) (*Connector, error) {
 		return nil, fmt.Errorf("authentication failed: invalid credentials")
 	}
 
-	c.UID = uid
-	log.Printf("Successfully initialized Odoo connector with UID: %d", uid)
+	c.UID, c.apiKey, c.uid = uid, apiKey, uid
+	c.log().Info("odoo connector initialized", "url", url, "db", db, "uid", uid)
 	return c, nil
 }
 
 // SearchReadRecords searches and reads records from Odoo
 func (c *Connector) SearchReadRecords(model string, opts SearchReadOptions) ([]map[string]interface{}, error) {
+	return c.SearchReadRecordsContext(context.Background(), model, opts)
+}
+
+// SearchReadRecordsContext is SearchReadRecords with a context; cancelling
+// it aborts the HTTP request
+func (c *Connector) SearchReadRecordsContext(ctx context.Context, model string, opts SearchReadOptions) ([]map[string]interface{}, error) {
 	var result []map[string]interface{}
 
+	err := c.searchRead(ctx, model, opts, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("search_read failed for model %s: %w", model, err)
+	}
+
+	aliases := c.aliasesOf(model)
+	for i, record := range result {
+		result[i] = aliases.record(record)
+	}
+	return result, nil
+}
+
+// searchRead calls search_read with the aliases of model translated and
+// decodes the records into result
+func (c *Connector) searchRead(ctx context.Context, model string, opts SearchReadOptions, result interface{}) error {
+	opts = c.aliasesOf(model).query(opts)
 	if opts.Domain == nil {
 		opts.Domain = []interface{}{}
 	}
@@ -155,28 +219,19 @@ func (c *Connector) SearchReadRecords(model string, opts SearchReadOptions) ([]m
 		"order":  opts.Order,
 	}
 
-	err := c.models.Call("execute_kw", []interface{}{
-		c.DB, c.UID, c.APIKey,
-		model, "search_read",
-		[]interface{}{opts.Domain},
-		params,
-	}, &result)
-
-	if err != nil {
-		return nil, fmt.Errorf("search_read failed for model %s: %w", model, err)
-	}
-
-	return result, nil
+	return c.execute(ctx, model, "search_read", []interface{}{opts.Domain}, params, result)
 }
 
 // CreateRecord creates a new record in Odoo
 func (c *Connector) CreateRecord(model string, values map[string]interface{}) (int64, error) {
+	return c.CreateRecordContext(context.Background(), model, values)
+}
+
+// CreateRecordContext is CreateRecord with a context
+func (c *Connector) CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error) {
 	var id int64
-	err := c.models.Call("execute_kw", []interface{}{
-		c.DB, c.UID, c.APIKey,
-		model, "create",
-		[]interface{}{values},
-	}, &id)
+	values = c.aliasesOf(model).values(values)
+	err := c.execute(ctx, model, "create", []interface{}{values}, nil, &id)
 
 	if err != nil {
 		return 0, fmt.Errorf("create failed for model %s: %w", model, err)
@@ -187,19 +242,21 @@ func (c *Connector) CreateRecord(model string, values map[string]interface{}) (i
 
 // UpdateRecord updates an existing record in Odoo
 func (c *Connector) UpdateRecord(model string, id int64, values map[string]interface{}) error {
+	return c.UpdateRecordContext(context.Background(), model, id, values)
+}
+
+// UpdateRecordContext is UpdateRecord with a context
+func (c *Connector) UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error {
 	var result bool
-	err := c.models.Call("execute_kw", []interface{}{
-		c.DB, c.UID, c.APIKey,
-		model, "write",
-		[]interface{}{[]int64{id}, values},
-	}, &result)
+	values = c.aliasesOf(model).values(values)
+	err := c.execute(ctx, model, "write", []interface{}{[]int64{id}, values}, nil, &result)
 
 	if err != nil {
 		return fmt.Errorf("update failed for model %s with id %d: %w", model, id, err)
 	}
 
 	if !result {
-		return fmt.Errorf("update failed for model %s with id %d: no record updated", model, id)
+		return fmt.Errorf("update failed for model %s with id %d: %w", model, id, errNoRecordUpdated)
 	}
 
 	return nil
@@ -207,19 +264,20 @@ func (c *Connector) UpdateRecord(model string, id int64, values map[string]inter
 
 // DeleteRecord deletes a record from Odoo
 func (c *Connector) DeleteRecord(model string, id int64) error {
+	return c.DeleteRecordContext(context.Background(), model, id)
+}
+
+// DeleteRecordContext is DeleteRecord with a context
+func (c *Connector) DeleteRecordContext(ctx context.Context, model string, id int64) error {
 	var result bool
-	err := c.models.Call("execute_kw", []interface{}{
-		c.DB, c.UID, c.APIKey,
-		model, "unlink",
-		[]interface{}{[]int64{id}},
-	}, &result)
+	err := c.execute(ctx, model, "unlink", []interface{}{[]int64{id}}, nil, &result)
 
 	if err != nil {
 		return fmt.Errorf("delete failed for model %s with id %d: %w", model, id, err)
 	}
 
 	if !result {
-		return fmt.Errorf("delete failed for model %s with id %d: no record deleted", model, id)
+		return fmt.Errorf("delete failed for model %s with id %d: %w", model, id, errNoRecordDeleted)
 	}
 
 	return nil
@@ -227,22 +285,166 @@ func (c *Connector) DeleteRecord(model string, id int64) error {
 
 // ExecuteMethod executes a custom method on an Odoo model
 func (c *Connector) ExecuteMethod(model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	return c.ExecuteMethodContext(context.Background(), model, method, args, kwargs)
+}
+
+// ExecuteMethodContext is ExecuteMethod with a context
+func (c *Connector) ExecuteMethodContext(ctx context.Context, model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
 	var result interface{}
 
-	callArgs := []interface{}{
-		c.DB, c.UID, c.APIKey,
-		model, method,
-		args,
+	err := c.execute(ctx, model, method, args, kwargs, &result)
+	if err != nil {
+		return nil, fmt.Errorf("method execution failed for %s.%s: %w", model, method, err)
+	}
+
+	return result, nil
+}
+
+// execute calls method on model through execute_kw and decodes the response
+// into result, retrying transient failures according to the retry policy.
+// Every model call of the connector goes through it.
+func (c *Connector) execute(ctx context.Context, model, method string, args []interface{}, kwargs map[string]interface{}, result interface{}) error {
+	if err := c.validate(ctx, model, method, args); err != nil {
+		return err
+	}
+
+	// Binary content read from a stream may not be sendable again
+	binaries := binaryValuesOf(args)
+
+	var delay time.Duration
+	reauthenticated := false
+	for attempt := 1; ; attempt++ {
+		_, _, generation := c.credentials()
+		err := c.executeOnce(ctx, model, method, args, kwargs, result)
+		if err != nil && !resendable(binaries) {
+			return err
+		}
+		if err != nil && c.reauth != nil && !reauthenticated && ClassifyError(err) == ErrorClassAuthentication {
+			reauthenticated = true
+			if reauthErr := c.reauthenticate(ctx, generation); reauthErr != nil {
+				c.log().LogAttrs(ctx, slog.LevelWarn, "odoo re-authentication failed", slog.String("error", reauthErr.Error()))
+				return err
+			}
+			// Repeating the call after re-authenticating is not a retry
+			attempt--
+			continue
+		}
+		if err == nil || !c.shouldRetry(ctx, model, method, err, attempt) {
+			return err
+		}
+
+		delay = c.retry.backoff(ClassifyError(err)).Next(attempt, delay)
+		c.log().LogAttrs(ctx, slog.LevelDebug, "odoo call retry",
+			slog.String("model", model),
+			slog.String("method", method),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", delay),
+		)
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func (c *Connector) executeOnce(ctx context.Context, model, method string, args []interface{}, kwargs map[string]interface{}, result interface{}) error {
+	release, err := c.admit(ctx, method, args)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, done := c.startCall(ctx, model, method)
+	err = c.send(ctx, method, c.executeArgs(model, method, args, kwargs), result)
+	done(err)
+	return err
+}
+
+// admit waits until a call may be sent: for the throttle attached to ctx and
+// for a dispatcher slot. The returned function frees the slot.
+func (c *Connector) admit(ctx context.Context, method string, args []interface{}) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.rateLimit != nil {
+		if err := c.rateLimit.Wait(ctx, recordCount(method, args)); err != nil {
+			return nil, err
+		}
+	}
+	if throttle := throttleFrom(ctx); throttle != nil {
+		if err := throttle.Wait(ctx, recordCount(method, args)); err != nil {
+			return nil, err
+		}
 	}
 
+	if c.dispatcher == nil {
+		return func() {}, nil
+	}
+	if err := c.dispatcher.acquire(ctx, priorityFrom(ctx)); err != nil {
+		return nil, err
+	}
+	return c.dispatcher.release, nil
+}
+
+// executeArgs builds the parameters of an execute_kw call
+func (c *Connector) executeArgs(model, method string, args []interface{}, kwargs map[string]interface{}) []interface{} {
+	callArgs := make([]interface{}, 6, 7)
+	apiKey, uid, _ := c.credentials()
+	callArgs[0], callArgs[1], callArgs[2] = c.DB, uid, apiKey
+	callArgs[3], callArgs[4], callArgs[5] = model, method, args
+
 	if kwargs != nil {
 		callArgs = append(callArgs, kwargs)
 	}
+	return callArgs
+}
 
-	err := c.models.Call("execute_kw", callArgs, &result)
+func (c *Connector) logCall(ctx context.Context, model, method string, duration time.Duration, err error) {
 	if err != nil {
-		return nil, fmt.Errorf("method execution failed for %s.%s: %w", model, method, err)
+		c.log().LogAttrs(ctx, slog.LevelWarn, "odoo call failed",
+			slog.String("model", model),
+			slog.String("method", method),
+			slog.Duration("duration", duration),
+			slog.String("error", err.Error()),
+		)
+		return
 	}
 
-	return result, nil
+	c.log().LogAttrs(ctx, slog.LevelDebug, "odoo call",
+		slog.String("model", model),
+		slog.String("method", method),
+		slog.Duration("duration", duration),
+	)
+}
+
+// send encodes an execute_kw call, posts it (hedged for read-only methods when
+// configured) and decodes the response into result
+func (c *Connector) send(ctx context.Context, method string, callArgs []interface{}, result interface{}) error {
+	if len(binaryValuesOf(callArgs)) > 0 {
+		resp, err := c.postEncoding(ctx, serviceObject, "execute_kw", callArgs)
+		if err != nil {
+			return err
+		}
+		return resp.Unmarshal(result)
+	}
+
+	buf := getBuffer()
+	if err := c.codec().encodeCall(buf, serviceObject, "execute_kw", callArgs); err != nil {
+		putBuffer(buf)
+		return err
+	}
+
+	var resp response
+	var err error
+	if len(c.hedge.Endpoints) > 0 && readOnlyMethods[method] {
+		// Concurrent hedged requests share the body, which is therefore
+		// left to the garbage collector instead of the pool.
+		resp, err = c.hedgedPost(ctx, serviceObject, buf.Bytes())
+	} else {
+		resp, err = c.post(ctx, c.URL, serviceObject, buf.Bytes(), func() { putBuffer(buf) })
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Unmarshal(result)
 }
@@ -0,0 +1,209 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// httpStatus makes the test servers answer with an HTTP error instead of a
+// result
+type httpStatus int
+
+// xmlRPCServer answers /xmlrpc/2 calls with the result of handle. service is
+// the last path element, args the call's parameters with integers decoded as
+// int64. A returned xmlrpc.FaultError is sent as a fault.
+func xmlRPCServer(t *testing.T, handle func(service, method string, args []interface{}) interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(xmlRPCHandler(t, handle))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// xmlRPCHandler is the handler of xmlRPCServer, for tests that inspect the
+// HTTP requests too
+func xmlRPCHandler(t *testing.T, handle func(service, method string, args []interface{}) interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/xmlrpc/2/") {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+		var call struct {
+			Method string            `xml:"methodName"`
+			Params []xmlRPCTestValue `xml:"params>param>value"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Error(err)
+		}
+		args := make([]interface{}, len(call.Params))
+		for i, param := range call.Params {
+			args[i] = param.decode(t)
+		}
+
+		result := handle(strings.TrimPrefix(r.URL.Path, "/xmlrpc/2/"), call.Method, args)
+		if code, ok := result.(httpStatus); ok {
+			w.WriteHeader(int(code))
+			return
+		}
+		var body bytes.Buffer
+		body.WriteString(`<?xml version="1.0"?><methodResponse>`)
+		if fault, ok := result.(xmlrpc.FaultError); ok {
+			body.WriteString("<fault>")
+			writeXMLRPCValue(t, &body, map[string]interface{}{"faultCode": fault.Code, "faultString": fault.String})
+			body.WriteString("</fault>")
+		} else {
+			body.WriteString("<params><param>")
+			writeXMLRPCValue(t, &body, result)
+			body.WriteString("</param></params>")
+		}
+		body.WriteString("</methodResponse>")
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(body.Bytes())
+	})
+}
+
+// xmlRPCTestValue is an XML-RPC <value> of a request
+type xmlRPCTestValue struct {
+	Text    string `xml:",chardata"`
+	Typed   []byte `xml:",innerxml"`
+	Members []struct {
+		Name  string          `xml:"name"`
+		Value xmlRPCTestValue `xml:"value"`
+	} `xml:"struct>member"`
+	Items []xmlRPCTestValue `xml:"array>data>value"`
+}
+
+func (v xmlRPCTestValue) decode(t *testing.T) interface{} {
+	var typed struct {
+		XMLName xml.Name
+		Text    string `xml:",chardata"`
+	}
+	if err := xml.Unmarshal(v.Typed, &typed); err != nil {
+		// A value without type element is a string
+		return v.Text
+	}
+	switch typed.XMLName.Local {
+	case "struct":
+		members := make(map[string]interface{}, len(v.Members))
+		for _, member := range v.Members {
+			members[member.Name] = member.Value.decode(t)
+		}
+		return members
+	case "array":
+		items := make([]interface{}, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = item.decode(t)
+		}
+		return items
+	case "nil":
+		return nil
+	case "int", "i4", "i8":
+		n, err := strconv.ParseInt(strings.TrimSpace(typed.Text), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		return n
+	case "double":
+		f, err := strconv.ParseFloat(strings.TrimSpace(typed.Text), 64)
+		if err != nil {
+			t.Error(err)
+		}
+		return f
+	case "boolean":
+		return strings.TrimSpace(typed.Text) == "1"
+	}
+	return typed.Text
+}
+
+// writeXMLRPCValue writes v as an XML-RPC <value>
+func writeXMLRPCValue(t *testing.T, w *bytes.Buffer, v interface{}) {
+	w.WriteString("<value>")
+	rv := reflect.ValueOf(v)
+	switch {
+	case v == nil:
+		w.WriteString("<nil/>")
+	case rv.Kind() == reflect.Bool:
+		if rv.Bool() {
+			w.WriteString("<boolean>1</boolean>")
+		} else {
+			w.WriteString("<boolean>0</boolean>")
+		}
+	case rv.CanInt():
+		fmt.Fprintf(w, "<int>%d</int>", rv.Int())
+	case rv.CanUint():
+		fmt.Fprintf(w, "<int>%d</int>", rv.Uint())
+	case rv.CanFloat():
+		fmt.Fprintf(w, "<double>%s</double>", strconv.FormatFloat(rv.Float(), 'f', -1, 64))
+	case rv.Kind() == reflect.String:
+		w.WriteString("<string>")
+		xml.EscapeText(w, []byte(rv.String()))
+		w.WriteString("</string>")
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		w.WriteString("<array><data>")
+		for i := 0; i < rv.Len(); i++ {
+			writeXMLRPCValue(t, w, rv.Index(i).Interface())
+		}
+		w.WriteString("</data></array>")
+	case rv.Kind() == reflect.Map:
+		keys := make([]string, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		w.WriteString("<struct>")
+		for _, key := range keys {
+			w.WriteString("<member><name>")
+			xml.EscapeText(w, []byte(key))
+			w.WriteString("</name>")
+			writeXMLRPCValue(t, w, rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+			w.WriteString("</member>")
+		}
+		w.WriteString("</struct>")
+	default:
+		t.Errorf("cannot encode %T as XML-RPC", v)
+	}
+	w.WriteString("</value>")
+}
+
+// versionServer reports the given Odoo version and answers execute_kw calls
+// through handle
+func versionServer(t *testing.T, version int, handle func(model, method string, args []interface{}) interface{}) *Connector {
+	t.Helper()
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		switch method {
+		case "authenticate":
+			return 2
+		case "version":
+			return map[string]interface{}{"server_version_info": []interface{}{version, 0, 0, "final", 0, ""}}
+		}
+		return handle(args[3].(string), args[4].(string), args)
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// fakeClock is a clock for the time-based components that only moves when
+// told to
+type fakeClock struct {
+	t time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
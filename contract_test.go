@@ -0,0 +1,126 @@
+//go:build odoointegration
+
+package odoo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RolandZimmermann/go-odoo-connector"
+	"github.com/RolandZimmermann/go-odoo-connector/odootest"
+)
+
+// The contract tests run the connector's operations against a real server:
+//
+//	ODOO_TEST_URL=http://localhost:8069 ODOO_TEST_MASTER_PASSWORD=admin go test -tags odoointegration -run Contract
+
+func TestContractCRUD(t *testing.T) {
+	env := odootest.New(t, odootest.OptionsFromEnv(t))
+	c := env.Connector
+	env.Seed(
+		odootest.Fixture{Ref: "parent", Model: "res.partner", Values: map[string]interface{}{"name": "Azure Interior", "is_company": true}},
+		odootest.Fixture{Ref: "child", Model: "res.partner", Values: map[string]interface{}{"name": "Brandon Freeman", "parent_id": odootest.Ref("parent")}},
+	)
+
+	records, err := c.SearchReadRecords("res.partner", odoo.SearchReadOptions{
+		Fields: []string{"name", "parent_id"},
+		Domain: []interface{}{[]interface{}{"id", "=", env.ID("child")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0]["name"] != "Brandon Freeman" {
+		t.Fatalf("unexpected records %v", records)
+	}
+	if parent, ok := records[0]["parent_id"].([]interface{}); !ok || parent[0] != env.ID("parent") {
+		t.Errorf("parent_id = %v, want [%d ...]", records[0]["parent_id"], env.ID("parent"))
+	}
+
+	if err := c.UpdateRecord("res.partner", env.ID("child"), map[string]interface{}{"name": "B. Freeman"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteRecord("res.partner", env.ID("child")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContractStreamMatchesBuffered(t *testing.T) {
+	env := odootest.New(t, odootest.OptionsFromEnv(t))
+	opts := odoo.SearchReadOptions{Fields: []string{"name", "active", "create_date"}, Order: "id"}
+
+	buffered, err := env.Connector.SearchReadRecords("res.partner", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streamed int
+	err = env.Connector.SearchReadStream(context.Background(), "res.partner", opts, func(record map[string]interface{}) error {
+		if record["id"] != buffered[streamed]["id"] {
+			t.Errorf("record %d: id %v, want %v", streamed, record["id"], buffered[streamed]["id"])
+		}
+		streamed++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if streamed != len(buffered) {
+		t.Errorf("streamed %d records, want %d", streamed, len(buffered))
+	}
+}
+
+func TestContractCurrentUser(t *testing.T) {
+	env := odootest.New(t, odootest.OptionsFromEnv(t))
+	user, err := env.Connector.CurrentUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Login != env.Login {
+		t.Errorf("login = %q, want %q", user.Login, env.Login)
+	}
+	ok, err := env.Connector.UserHasGroup(context.Background(), "base.group_system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("administrator is not in base.group_system")
+	}
+}
+
+func TestContractSyncChanges(t *testing.T) {
+	env := odootest.New(t, odootest.OptionsFromEnv(t))
+	c := env.Connector
+	ctx := context.Background()
+	opts := odoo.SyncOptions{
+		Fields:       []string{"name"},
+		Domain:       []interface{}{[]interface{}{"name", "=like", "Sync %"}},
+		TrackDeletes: true,
+	}
+	collect := func(state odoo.SyncState) (odoo.SyncState, map[odoo.ChangeType]int) {
+		counts := make(map[odoo.ChangeType]int)
+		state, err := c.SyncChanges(ctx, "res.partner", state, opts, func(change odoo.Change) error {
+			counts[change.Type]++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return state, counts
+	}
+
+	env.Seed(
+		odootest.Fixture{Ref: "a", Model: "res.partner", Values: map[string]interface{}{"name": "Sync A"}},
+		odootest.Fixture{Ref: "b", Model: "res.partner", Values: map[string]interface{}{"name": "Sync B"}},
+	)
+	state, counts := collect(odoo.SyncState{})
+	if counts[odoo.ChangeCreated] != 2 {
+		t.Errorf("first sync: %v, want 2 created", counts)
+	}
+
+	if err := c.DeleteRecord("res.partner", env.ID("b")); err != nil {
+		t.Fatal(err)
+	}
+	_, counts = collect(state)
+	if counts[odoo.ChangeDeleted] != 1 || counts[odoo.ChangeCreated] != 0 {
+		t.Errorf("second sync: %v, want 1 deleted", counts)
+	}
+}
@@ -0,0 +1,86 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// CronJob is a scheduled action (ir.cron)
+type CronJob struct {
+	ID     int64
+	Name   string
+	Model  string
+	Active bool
+	// IntervalNumber and IntervalType ("minutes", "hours", "days", "weeks"
+	// or "months") define how often the job runs.
+	IntervalNumber int64
+	IntervalType   string
+	Priority       int64
+	// NextCall and LastCall are UTC datetimes as formatted by the server.
+	// LastCall is only available on Odoo 15 and later.
+	NextCall string
+	LastCall string
+}
+
+// ListCronJobs returns the scheduled actions ordered by their next execution,
+// including the inactive ones if includeInactive is set
+func (c *Connector) ListCronJobs(ctx context.Context, includeInactive bool) ([]CronJob, error) {
+	fields := []string{"name", "model_name", "active", "interval_number", "interval_type", "priority", "nextcall"}
+	lastCall, err := c.SupportsContext(ctx, FeatureCronLastCall)
+	if err != nil {
+		return nil, fmt.Errorf("cron listing failed: %w", err)
+	}
+	if lastCall {
+		fields = append(fields, "lastcall")
+	}
+
+	var records []map[string]interface{}
+	err = c.execute(ctx, "ir.cron", "search_read", []interface{}{[]interface{}{}}, map[string]interface{}{
+		"fields":  fields,
+		"order":   "nextcall, priority",
+		"context": map[string]interface{}{"active_test": !includeInactive},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("cron listing failed: %w", err)
+	}
+
+	jobs := make([]CronJob, 0, len(records))
+	for _, record := range records {
+		job := CronJob{}
+		job.ID, _ = toInt64(record["id"])
+		job.Name, _ = record["name"].(string)
+		job.Model, _ = record["model_name"].(string)
+		job.Active, _ = record["active"].(bool)
+		job.IntervalNumber, _ = toInt64(record["interval_number"])
+		job.IntervalType, _ = record["interval_type"].(string)
+		job.Priority, _ = toInt64(record["priority"])
+		job.NextCall, _ = record["nextcall"].(string)
+		job.LastCall, _ = record["lastcall"].(string)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// EnableCron activates the scheduled action id
+func (c *Connector) EnableCron(ctx context.Context, id int64) error {
+	return c.setCronActive(ctx, id, true)
+}
+
+// DisableCron deactivates the scheduled action id
+func (c *Connector) DisableCron(ctx context.Context, id int64) error {
+	return c.setCronActive(ctx, id, false)
+}
+
+func (c *Connector) setCronActive(ctx context.Context, id int64, active bool) error {
+	var result bool
+	err := c.execute(ctx, "ir.cron", "write", []interface{}{[]int64{id}, map[string]interface{}{
+		"active": active,
+	}}, nil, &result)
+	if err != nil {
+		return fmt.Errorf("cron update failed for id %d: %w", id, err)
+	}
+	if !result {
+		return fmt.Errorf("cron update failed for id %d: %w", id, errNoRecordUpdated)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestListCronJobs(t *testing.T) {
+	for _, tc := range []struct {
+		version         int
+		includeInactive bool
+		lastCall        string
+	}{
+		{14, false, ""},
+		{16, true, "2024-01-01 09:00:00"},
+	} {
+		var kwargs map[string]interface{}
+		c := versionServer(t, tc.version, func(model, method string, args []interface{}) interface{} {
+			kwargs = args[6].(map[string]interface{})
+			record := map[string]interface{}{
+				"id": 4, "name": "Mail: Email Queue Manager", "model_name": "mail.mail", "active": true,
+				"interval_number": 1, "interval_type": "hours", "priority": 10,
+				"nextcall": "2024-01-01 10:00:00",
+			}
+			for _, field := range kwargs["fields"].([]interface{}) {
+				if field == "lastcall" {
+					record["lastcall"] = "2024-01-01 09:00:00"
+				}
+			}
+			return []map[string]interface{}{record}
+		})
+
+		jobs, err := c.ListCronJobs(context.Background(), tc.includeInactive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []CronJob{{
+			ID: 4, Name: "Mail: Email Queue Manager", Model: "mail.mail", Active: true,
+			IntervalNumber: 1, IntervalType: "hours", Priority: 10,
+			NextCall: "2024-01-01 10:00:00", LastCall: tc.lastCall,
+		}}
+		if !reflect.DeepEqual(jobs, want) {
+			t.Errorf("Odoo %d: got %+v, want %+v", tc.version, jobs, want)
+		}
+		if want := map[string]interface{}{"active_test": !tc.includeInactive}; !reflect.DeepEqual(kwargs["context"], want) {
+			t.Errorf("Odoo %d: context %v, want %v", tc.version, kwargs["context"], want)
+		}
+	}
+}
+
+func TestListCronJobsDetectionFailure(t *testing.T) {
+	var listed bool
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		switch method {
+		case "authenticate":
+			return 2
+		case "version":
+			return xmlrpc.FaultError{Code: 1, String: "Odoo Server Error"}
+		}
+		listed = true
+		return []map[string]interface{}{}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ListCronJobs(context.Background(), false); err == nil {
+		t.Fatal("failed version detection did not fail the listing")
+	}
+	if listed {
+		t.Error("jobs were listed without knowing the server version")
+	}
+}
+
+func TestSetCronActive(t *testing.T) {
+	var writes []interface{}
+	c := versionServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		params := args[5].([]interface{})
+		writes = append(writes, params[1])
+		return params[0].([]interface{})[0] != int64(99)
+	})
+	ctx := context.Background()
+
+	if err := c.EnableCron(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DisableCron(ctx, 4); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{map[string]interface{}{"active": true}, map[string]interface{}{"active": false}}
+	if !reflect.DeepEqual(writes, want) {
+		t.Errorf("writes %v, want %v", writes, want)
+	}
+	if err := c.DisableCron(ctx, 99); err == nil {
+		t.Error("update of a missing job did not fail")
+	}
+}
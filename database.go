@@ -0,0 +1,74 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NewDatabase describes a database to create with CreateDatabase
+type NewDatabase struct {
+	Name string
+	// Login and Password of the administrator (default admin/admin)
+	Login    string
+	Password string
+	// Lang is the installed language (default en_US)
+	Lang string
+	// Demo loads demonstration data
+	Demo bool
+}
+
+// dbClient is shared by the db service calls so they reuse their connections
+var dbClient = &http.Client{}
+
+// dbService returns a connector for the unauthenticated db service of the
+// server at url
+func dbService(url string) *Connector {
+	return &Connector{URL: url, httpClient: dbClient}
+}
+
+// CreateDatabase creates a database on the server at url. masterPassword is
+// the server's admin_passwd. Creating a database can take minutes; bound ctx
+// accordingly.
+func CreateDatabase(ctx context.Context, url, masterPassword string, db NewDatabase) error {
+	if db.Login == "" {
+		db.Login = "admin"
+	}
+	if db.Password == "" {
+		db.Password = "admin"
+	}
+	if db.Lang == "" {
+		db.Lang = "en_US"
+	}
+
+	var ok bool
+	err := dbService(url).call(ctx, serviceDB, "create_database", []interface{}{
+		masterPassword, db.Name, db.Demo, db.Lang, db.Password, db.Login,
+	}, &ok)
+	if err != nil {
+		return fmt.Errorf("create database %s failed: %w", db.Name, err)
+	}
+	return nil
+}
+
+// DropDatabase drops a database on the server at url
+func DropDatabase(ctx context.Context, url, masterPassword, name string) error {
+	var ok bool
+	if err := dbService(url).call(ctx, serviceDB, "drop", []interface{}{masterPassword, name}, &ok); err != nil {
+		return fmt.Errorf("drop database %s failed: %w", name, err)
+	}
+	if !ok {
+		return fmt.Errorf("drop database %s failed: database not dropped", name)
+	}
+	return nil
+}
+
+// ListDatabases lists the databases of the server at url. It fails when the
+// server hides the database list (list_db = False).
+func ListDatabases(ctx context.Context, url string) ([]string, error) {
+	var names []string
+	if err := dbService(url).call(ctx, serviceDB, "list", []interface{}{}, &names); err != nil {
+		return nil, fmt.Errorf("list databases failed: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,39 @@
+package odoo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListDatabases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/xmlrpc/2/db" || !strings.Contains(string(body), "<methodName>list</methodName>") {
+			t.Errorf("request to %s: %s", r.URL.Path, body)
+		}
+		io.WriteString(w, `<?xml version="1.0"?><methodResponse><params><param><value><array><data>`+
+			`<value><string>prod</string></value><value><string>staging</string></value>`+
+			`</data></array></value></param></params></methodResponse>`)
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		names, err := ListDatabases(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, []string{"prod", "staging"}) {
+			t.Errorf("got %v", names)
+		}
+	}
+
+	// Every call shares one client and thereby its idle connections
+	if dbService(srv.URL).httpClient != dbService(srv.URL).httpClient {
+		t.Error("db service calls do not share their HTTP client")
+	}
+}
@@ -0,0 +1,68 @@
+package odoo
+
+import "time"
+
+// DateRange is a half-open time range [Start, End) in a time zone, e.g.
+// "today in Europe/Berlin". Odoo stores datetimes in UTC, so searching a
+// local day needs the UTC bounds of that day, which Domain computes:
+//
+//	berlin, _ := time.LoadLocation("Europe/Berlin")
+//	opts := odoo.SearchReadOptions{Domain: odoo.Today(berlin).Domain("create_date")}
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Day returns the calendar day containing t in loc. Days around daylight
+// saving time changes last 23 or 25 hours.
+func Day(t time.Time, loc *time.Location) DateRange {
+	t = t.In(loc)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return DateRange{Start: start, End: start.AddDate(0, 0, 1)}
+}
+
+// Today returns the current day in loc
+func Today(loc *time.Location) DateRange {
+	return Day(time.Now(), loc)
+}
+
+// Week returns the week, Monday to Sunday, containing t in loc
+func Week(t time.Time, loc *time.Location) DateRange {
+	day := Day(t, loc)
+	offset := (int(day.Start.Weekday()) + 6) % 7
+	start := day.Start.AddDate(0, 0, -offset)
+	return DateRange{Start: start, End: start.AddDate(0, 0, 7)}
+}
+
+// Month returns the calendar month containing t in loc
+func Month(t time.Time, loc *time.Location) DateRange {
+	t = t.In(loc)
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	return DateRange{Start: start, End: start.AddDate(0, 1, 0)}
+}
+
+// Year returns the calendar year containing t in loc
+func Year(t time.Time, loc *time.Location) DateRange {
+	t = t.In(loc)
+	start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, loc)
+	return DateRange{Start: start, End: start.AddDate(1, 0, 0)}
+}
+
+// Domain returns the domain clauses matching values of the datetime field
+// within the range, with the bounds converted to UTC
+func (r DateRange) Domain(field string) []interface{} {
+	return []interface{}{
+		[]interface{}{field, ">=", r.Start.UTC().Format(time.DateTime)},
+		[]interface{}{field, "<", r.End.UTC().Format(time.DateTime)},
+	}
+}
+
+// DateDomain returns the domain clauses matching values of the date field
+// within the range. Date fields have no time zone, so the local dates of the
+// range are compared.
+func (r DateRange) DateDomain(field string) []interface{} {
+	return []interface{}{
+		[]interface{}{field, ">=", r.Start.Format(time.DateOnly)},
+		[]interface{}{field, "<", r.End.Format(time.DateOnly)},
+	}
+}
@@ -0,0 +1,41 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDateRange(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	// Sunday of the switch to summer time, shortly after midnight UTC
+	at := time.Date(2024, 3, 31, 0, 30, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name  string
+		got   []interface{}
+		start string
+		end   string
+	}{
+		{"day", Day(at, berlin).Domain("create_date"), "2024-03-30 23:00:00", "2024-03-31 22:00:00"},
+		{"week", Week(at, berlin).Domain("create_date"), "2024-03-24 23:00:00", "2024-03-31 22:00:00"},
+		{"month", Month(at, berlin).Domain("create_date"), "2024-02-29 23:00:00", "2024-03-31 22:00:00"},
+		{"year", Year(at, berlin).Domain("create_date"), "2023-12-31 23:00:00", "2024-12-31 23:00:00"},
+		{"dates", Week(at, berlin).DateDomain("date_order"), "2024-03-25", "2024-04-01"},
+	} {
+		field := "create_date"
+		if tc.name == "dates" {
+			field = "date_order"
+		}
+		want := []interface{}{
+			[]interface{}{field, ">=", tc.start},
+			[]interface{}{field, "<", tc.end},
+		}
+		if !reflect.DeepEqual(tc.got, want) {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package odoo
+
+import (
+	"fmt"
+)
+
+// Domain is a search domain built from criteria. It is a []interface{} in
+// Odoo's prefix notation and can be assigned to SearchReadOptions.Domain
+// directly:
+//
+//	opts := odoo.SearchReadOptions{Domain: odoo.NewDomain(
+//		odoo.Eq("type", "lead"),
+//		odoo.Or(odoo.Ilike("name", "azure"), odoo.In("stage_id", stageIDs)),
+//	)}
+type Domain []interface{}
+
+// Criterion is a condition or a combination of conditions of a domain
+type Criterion struct {
+	terms []interface{}
+}
+
+// NewDomain returns the domain matching all criteria
+func NewDomain(criteria ...Criterion) Domain {
+	return Domain(And(criteria...).terms)
+}
+
+// And returns the domain matching d and all criteria
+func (d Domain) And(criteria ...Criterion) Domain {
+	return NewDomain(append([]Criterion{d.criterion()}, criteria...)...)
+}
+
+// Or returns the domain matching d or any of the criteria. An empty d is
+// ignored rather than matching every record, so NewDomain().Or(a, b)
+// matches a or b.
+func (d Domain) Or(criteria ...Criterion) Domain {
+	if len(d) == 0 {
+		return Domain(Or(criteria...).terms)
+	}
+	return Domain(Or(append([]Criterion{d.criterion()}, criteria...)...).terms)
+}
+
+// Validate checks the structure of the domain: the operands of '&', '|'
+// and '!' and the operators of the conditions. Conditions built with the
+// typed constructors are always valid; Cond accepts any operator.
+func (d Domain) Validate() error {
+	return ValidateDomain(d)
+}
+
+func (d Domain) criterion() Criterion {
+	// Combined domains are wrapped in explicit '&' operators, which an
+	// implicitly combined top level lacks
+	terms, err := explicitTerms(d)
+	if err != nil {
+		return Criterion{terms: d}
+	}
+	return Criterion{terms: terms}
+}
+
+// Cond returns the condition field operator value. Prefer the typed
+// constructors; an unknown operator is reported by Domain.Validate.
+func Cond(field, operator string, value interface{}) Criterion {
+	return Criterion{terms: []interface{}{[]interface{}{field, operator, value}}}
+}
+
+// Eq matches records whose field equals value
+func Eq(field string, value interface{}) Criterion { return Cond(field, "=", value) }
+
+// Ne matches records whose field differs from value
+func Ne(field string, value interface{}) Criterion { return Cond(field, "!=", value) }
+
+// Gt matches records whose field is greater than value
+func Gt(field string, value interface{}) Criterion { return Cond(field, ">", value) }
+
+// Gte matches records whose field is greater than or equal to value
+func Gte(field string, value interface{}) Criterion { return Cond(field, ">=", value) }
+
+// Lt matches records whose field is less than value
+func Lt(field string, value interface{}) Criterion { return Cond(field, "<", value) }
+
+// Lte matches records whose field is less than or equal to value
+func Lte(field string, value interface{}) Criterion { return Cond(field, "<=", value) }
+
+// Like matches records whose field contains pattern, case-sensitively
+func Like(field, pattern string) Criterion { return Cond(field, "like", pattern) }
+
+// Ilike matches records whose field contains pattern, ignoring case
+func Ilike(field, pattern string) Criterion { return Cond(field, "ilike", pattern) }
+
+// NotLike matches records whose field does not contain pattern
+func NotLike(field, pattern string) Criterion { return Cond(field, "not like", pattern) }
+
+// NotIlike matches records whose field does not contain pattern, ignoring
+// case
+func NotIlike(field, pattern string) Criterion { return Cond(field, "not ilike", pattern) }
+
+// In matches records whose field is one of values, a slice
+func In(field string, values interface{}) Criterion { return Cond(field, "in", values) }
+
+// NotIn matches records whose field is none of values, a slice
+func NotIn(field string, values interface{}) Criterion { return Cond(field, "not in", values) }
+
+// ChildOf matches records below id (or ids) in the hierarchy of field
+func ChildOf(field string, id interface{}) Criterion { return Cond(field, "child_of", id) }
+
+// ParentOf matches records above id (or ids) in the hierarchy of field
+func ParentOf(field string, id interface{}) Criterion { return Cond(field, "parent_of", id) }
+
+// IsSet matches records whose field has a value
+func IsSet(field string) Criterion { return Cond(field, "!=", false) }
+
+// IsNotSet matches records whose field is empty
+func IsNotSet(field string) Criterion { return Cond(field, "=", false) }
+
+// And matches records matching all criteria. Without criteria it matches
+// every record.
+func And(criteria ...Criterion) Criterion {
+	return combine("&", criteria)
+}
+
+// Or matches records matching any of the criteria. An empty criterion such
+// as And() matches every record, and so does the result. Without criteria Or
+// is empty as well.
+func Or(criteria ...Criterion) Criterion {
+	for _, c := range criteria {
+		if len(c.terms) == 0 {
+			return Criterion{}
+		}
+	}
+	return combine("|", criteria)
+}
+
+// Not matches records not matching c
+func Not(c Criterion) Criterion {
+	if len(c.terms) == 0 {
+		return c
+	}
+	return Criterion{terms: append([]interface{}{"!"}, c.terms...)}
+}
+
+// combine joins the non-empty criteria with n-1 prefix operators
+func combine(operator string, criteria []Criterion) Criterion {
+	var terms []interface{}
+	n := 0
+	for _, c := range criteria {
+		if len(c.terms) == 0 {
+			continue
+		}
+		terms = append(terms, c.terms...)
+		n++
+	}
+	if n == 0 {
+		return Criterion{}
+	}
+	prefix := make([]interface{}, n-1, n-1+len(terms))
+	for i := range prefix {
+		prefix[i] = operator
+	}
+	return Criterion{terms: append(prefix, terms...)}
+}
+
+// domainOperators are the condition operators Odoo accepts
+var domainOperators = map[string]bool{
+	"=": true, "!=": true, "<>": true, ">": true, ">=": true, "<": true, "<=": true,
+	"=?": true, "=like": true, "=ilike": true, "like": true, "not like": true,
+	"ilike": true, "not ilike": true, "in": true, "not in": true,
+	"child_of": true, "parent_of": true, "any": true, "not any": true,
+}
+
+// ValidateDomain checks the structure of a domain: every '&' and '|' has two
+// operands and every '!' one, and every condition is a [field, operator,
+// value] triple with a known operator
+func ValidateDomain(domain []interface{}) error {
+	_, err := explicitTerms(domain)
+	return err
+}
+
+// explicitTerms validates domain and returns it with the implicit '&'
+// operators of its top level made explicit
+func explicitTerms(domain []interface{}) ([]interface{}, error) {
+	var criteria []Criterion
+	for i := 0; i < len(domain); {
+		next, err := parseTerm(domain, i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain: %w", err)
+		}
+		criteria = append(criteria, Criterion{terms: domain[i:next]})
+		i = next
+	}
+	return And(criteria...).terms, nil
+}
+
+// parseTerm checks the term starting at index i with its operands and
+// returns the index after it
+func parseTerm(domain []interface{}, i int) (int, error) {
+	if i >= len(domain) {
+		return 0, fmt.Errorf("missing operand at term %d", i)
+	}
+	switch term := domain[i].(type) {
+	case string:
+		operands := 0
+		switch term {
+		case "&", "|":
+			operands = 2
+		case "!":
+			operands = 1
+		default:
+			return 0, fmt.Errorf("term %d: unknown operator %q", i, term)
+		}
+		next := i + 1
+		for ; operands > 0; operands-- {
+			var err error
+			if next, err = parseTerm(domain, next); err != nil {
+				return 0, err
+			}
+		}
+		return next, nil
+	case []interface{}:
+		if len(term) != 3 {
+			return 0, fmt.Errorf("term %d: condition %v is not a [field, operator, value] triple", i, term)
+		}
+		operator, _ := term[1].(string)
+		if _, isField := term[0].(string); !isField {
+			// Odoo's constant leaves (1, '=', 1) and (0, '=', 1)
+			if _, isInt := toInt64(term[0]); !isInt || operator != "=" {
+				return 0, fmt.Errorf("term %d: condition %v has no field name", i, term)
+			}
+		}
+		if !domainOperators[operator] {
+			return 0, fmt.Errorf("term %d: unknown operator %v in condition %v", i, term[1], term)
+		}
+		return i + 1, nil
+	}
+	return 0, fmt.Errorf("term %d: unexpected %v (%T)", i, domain[i], domain[i])
+}
@@ -0,0 +1,325 @@
+package odoo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDomainDepth bounds the nesting of lists in a parsed domain
+const maxDomainDepth = 64
+
+// DomainParseError is returned by ParseDomain for malformed input. Offset is
+// the 0-based byte offset of the problem, Line and Column its 1-based
+// position.
+type DomainParseError struct {
+	Offset int
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *DomainParseError) Error() string {
+	return fmt.Sprintf("invalid domain at line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseDomain parses a domain written the way Odoo stores filters and
+// actions, i.e. as a Python literal:
+//
+//	[('state', '=', 'sale'), '|', ('amount_total', '>', 1000), ('user_id', '=', False)]
+//
+// Conditions may be tuples or lists; values may be strings, integers,
+// floats, True, False, None (parsed as false) and lists or tuples of those.
+// Expressions such as uid or context_today() are not evaluated and are
+// rejected. The prefix operators '&', '|' and '!' are checked for missing
+// operands.
+//
+// ParseDomain never panics; malformed input yields a *DomainParseError.
+func ParseDomain(s string) ([]interface{}, error) {
+	p := &domainParser{src: s}
+	p.skipSpace()
+	start := p.pos
+	if p.pos >= len(p.src) || p.src[p.pos] != '[' {
+		return nil, p.errorf(start, "domain must be a list")
+	}
+	value, err := p.value(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected %s after domain", p.describe())
+	}
+
+	domain := value.([]interface{})
+	if err := p.checkDomain(domain); err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+type domainParser struct {
+	src string
+	pos int
+	// terms holds the offset of every element of the top-level list
+	terms []int
+}
+
+func (p *domainParser) errorf(offset int, format string, args ...interface{}) *DomainParseError {
+	if offset > len(p.src) {
+		offset = len(p.src)
+	}
+	line := 1 + strings.Count(p.src[:offset], "\n")
+	lineStart := strings.LastIndexByte(p.src[:offset], '\n') + 1
+	return &DomainParseError{
+		Offset: offset,
+		Line:   line,
+		Column: utf8.RuneCountInString(p.src[lineStart:offset]) + 1,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// describe names the input at the current position for error messages
+func (p *domainParser) describe() string {
+	if p.pos >= len(p.src) {
+		return "end of input"
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return strconv.QuoteRune(r)
+}
+
+func (p *domainParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// value parses a literal at the current position
+func (p *domainParser) value(depth int) (interface{}, error) {
+	if p.pos >= len(p.src) {
+		return nil, p.errorf(p.pos, "unexpected end of input")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '[' || c == '(':
+		return p.list(depth)
+	case c == '\'' || c == '"':
+		return p.str()
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		return p.number()
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return p.name()
+	}
+	return nil, p.errorf(p.pos, "unexpected %s", p.describe())
+}
+
+func (p *domainParser) list(depth int) (interface{}, error) {
+	start := p.pos
+	if depth >= maxDomainDepth {
+		return nil, p.errorf(start, "nesting deeper than %d levels", maxDomainDepth)
+	}
+	closing := byte(']')
+	if p.src[p.pos] == '(' {
+		closing = ')'
+	}
+	p.pos++
+
+	items := []interface{}{}
+	for {
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == closing {
+			p.pos++
+			return items, nil
+		}
+		if depth == 0 {
+			p.terms = append(p.terms, p.pos)
+		}
+		item, err := p.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf(start, "unclosed %q", p.src[start])
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case closing:
+		default:
+			return nil, p.errorf(p.pos, "expected ',' or %q, found %s", closing, p.describe())
+		}
+	}
+}
+
+func (p *domainParser) str() (interface{}, error) {
+	start := p.pos
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\n':
+			return nil, p.errorf(p.pos, "newline in string")
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return nil, p.errorf(start, "unterminated string")
+			}
+			r, size, err := p.escape()
+			if err != nil {
+				return nil, err
+			}
+			b.WriteRune(r)
+			p.pos += size
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return nil, p.errorf(start, "unterminated string")
+}
+
+// escape decodes the escape sequence at the current position, returning the
+// rune and the length of the sequence
+func (p *domainParser) escape() (rune, int, error) {
+	switch c := p.src[p.pos+1]; c {
+	case '\\', '\'', '"':
+		return rune(c), 2, nil
+	case 'n':
+		return '\n', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case 'r':
+		return '\r', 2, nil
+	case '0':
+		return 0, 2, nil
+	case 'x', 'u', 'U':
+		digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+		end := p.pos + 2 + digits
+		if end > len(p.src) {
+			return 0, 0, p.errorf(p.pos, "truncated \\%c escape", c)
+		}
+		code, err := strconv.ParseUint(p.src[p.pos+2:end], 16, 32)
+		if err != nil || code > utf8.MaxRune {
+			return 0, 0, p.errorf(p.pos, "invalid \\%c escape", c)
+		}
+		return rune(code), 2 + digits, nil
+	}
+	return 0, 0, p.errorf(p.pos, "unknown escape sequence")
+}
+
+func (p *domainParser) number() (interface{}, error) {
+	start := p.pos
+	if p.src[p.pos] == '-' || p.src[p.pos] == '+' {
+		p.pos++
+	}
+	isFloat := false
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '.' || c == 'e' || c == 'E' {
+			isFloat = true
+		} else if (c == '-' || c == '+') && (p.src[p.pos-1] == 'e' || p.src[p.pos-1] == 'E') {
+			// exponent sign
+		} else if c < '0' || c > '9' {
+			if c != '_' {
+				break
+			}
+		}
+		p.pos++
+	}
+
+	text := p.src[start:p.pos]
+	if isFloat {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, p.errorf(start, "invalid number %q", text)
+		}
+		return f, nil
+	}
+	n, err := strconv.ParseInt(text, 0, 64)
+	if err != nil {
+		return nil, p.errorf(start, "invalid integer %q", text)
+	}
+	return n, nil
+}
+
+func (p *domainParser) name() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c != '_' && c != '.' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+
+	switch name := p.src[start:p.pos]; name {
+	case "True":
+		return true, nil
+	case "False", "None":
+		return false, nil
+	default:
+		return nil, p.errorf(start, "unsupported expression %q: only literals can be parsed", name)
+	}
+}
+
+// checkDomain validates the terms of a parsed domain: conditions are
+// (field, operator, value) triples and prefix operators have their operands
+func (p *domainParser) checkDomain(domain []interface{}) error {
+	operands := 0
+	for i := len(domain) - 1; i >= 0; i-- {
+		offset := p.terms[i]
+		switch term := domain[i].(type) {
+		case string:
+			switch term {
+			case "!":
+				if operands < 1 {
+					return p.errorf(offset, "operator '!' is missing its operand")
+				}
+			case "&", "|":
+				if operands < 2 {
+					return p.errorf(offset, "operator %q is missing operands", term)
+				}
+				operands--
+			default:
+				return p.errorf(offset, "unknown operator %q", term)
+			}
+		case []interface{}:
+			if len(term) != 3 {
+				return p.errorf(offset, "condition must have 3 elements, found %d", len(term))
+			}
+			if _, ok := term[0].(string); !ok {
+				if !isConstantLeaf(term) {
+					return p.errorf(offset, "condition field must be a string")
+				}
+			}
+			if _, ok := term[1].(string); !ok {
+				return p.errorf(offset, "condition operator must be a string")
+			}
+			operands++
+		default:
+			return p.errorf(offset, "domain term must be a condition or an operator")
+		}
+	}
+	return nil
+}
+
+// isConstantLeaf reports whether a condition is one of the TRUE_LEAF (1, '=',
+// 1) and FALSE_LEAF (0, '=', 1) constants Odoo uses
+func isConstantLeaf(term []interface{}) bool {
+	left, ok := term[0].(int64)
+	right, _ := term[2].(int64)
+	return ok && (left == 0 || left == 1) && term[1] == "=" && right == 1
+}
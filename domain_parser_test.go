@@ -0,0 +1,107 @@
+package odoo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseDomain(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []interface{}
+	}{
+		{`[]`, []interface{}{}},
+		{
+			`[('state', '=', 'sale'), '|', ("amount_total", '>', 1000.5), ['user_id', '=', False],]`,
+			[]interface{}{
+				[]interface{}{"state", "=", "sale"},
+				"|",
+				[]interface{}{"amount_total", ">", 1000.5},
+				[]interface{}{"user_id", "=", false},
+			},
+		},
+		{
+			`[('id', 'in', [1, 2, -3]), '!', ('name', 'ilike', 'it\'sé'), (1, '=', 1)]`,
+			[]interface{}{
+				[]interface{}{"id", "in", []interface{}{int64(1), int64(2), int64(-3)}},
+				"!",
+				[]interface{}{"name", "ilike", "it'sé"},
+				[]interface{}{int64(1), "=", int64(1)},
+			},
+		},
+		{"[\n  ('active', '=', True),\n  ('parent_id', '=', None)\n]", []interface{}{
+			[]interface{}{"active", "=", true},
+			[]interface{}{"parent_id", "=", false},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDomain(tt.in)
+		if err != nil {
+			t.Errorf("ParseDomain(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseDomain(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDomainErrors(t *testing.T) {
+	tests := []struct {
+		in           string
+		line, column int
+	}{
+		{``, 1, 1},
+		{`('a', '=', 1)`, 1, 1},
+		{`[('a', '=', 1)`, 1, 1},
+		{`[('a', '=', 'x)]`, 1, 13},
+		{`[('a', '=', uid)]`, 1, 13},
+		{`[('a', '=')]`, 1, 2},
+		{`['|', ('a', '=', 1)]`, 1, 2},
+		{`['^', ('a', '=', 1)]`, 1, 2},
+		{"[('a', '=', 1),\n ('b' '=', 2)]", 2, 7},
+		{`[('a', '=', 1)] x`, 1, 17},
+		{`[('a', '=', 0x)]`, 1, 14},
+	}
+	for _, tt := range tests {
+		_, err := ParseDomain(tt.in)
+		var perr *DomainParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseDomain(%q): expected a DomainParseError, got %v", tt.in, err)
+			continue
+		}
+		if perr.Line != tt.line || perr.Column != tt.column {
+			t.Errorf("ParseDomain(%q): error at %d:%d, want %d:%d (%v)", tt.in, perr.Line, perr.Column, tt.line, tt.column, err)
+		}
+	}
+}
+
+func FuzzParseDomain(f *testing.F) {
+	for _, seed := range []string{
+		`[]`,
+		`[('state', '=', 'sale'), '|', ('amount', '>', 1.5e3), ('user_id', '=', False)]`,
+		`[('id', 'in', (1, 2, 3)), '!', ('name', 'ilike', "\x41é")]`,
+		`[[[[[[[[`,
+		`[('a', '=', '\`,
+		`['&', '&', ('a', '=', 1)]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		domain, err := ParseDomain(s)
+		if err != nil {
+			var perr *DomainParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			if perr.Offset < 0 || perr.Offset > len(s) || perr.Line < 1 || perr.Column < 1 {
+				t.Fatalf("invalid error position %+v", perr)
+			}
+			return
+		}
+		if domain == nil {
+			t.Fatal("nil domain without error")
+		}
+	})
+}
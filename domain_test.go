@@ -0,0 +1,76 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDomainBuilder(t *testing.T) {
+	ids := []int64{1, 2}
+	for _, tc := range []struct {
+		name string
+		got  Domain
+		want []interface{}
+	}{
+		{"empty", NewDomain(), nil},
+		{"single", NewDomain(Eq("type", "lead")), []interface{}{
+			[]interface{}{"type", "=", "lead"},
+		}},
+		{"nested", NewDomain().And(Eq("type", "lead"), Or(Ilike("name", "foo"), In("stage_id", ids), IsNotSet("user_id"))), []interface{}{
+			"&", []interface{}{"type", "=", "lead"},
+			"|", "|", []interface{}{"name", "ilike", "foo"}, []interface{}{"stage_id", "in", ids}, []interface{}{"user_id", "=", false},
+		}},
+		{"not", NewDomain(Not(And(Gt("amount", 10), Lte("amount", 20)))), []interface{}{
+			"!", "&", []interface{}{"amount", ">", 10}, []interface{}{"amount", "<=", 20},
+		}},
+		{"or", NewDomain(Eq("a", 1), Eq("b", 2)).Or(Eq("c", 3)), []interface{}{
+			"|", "&", []interface{}{"a", "=", 1}, []interface{}{"b", "=", 2}, []interface{}{"c", "=", 3},
+		}},
+		{"or several", NewDomain(Eq("a", 1)).Or(Eq("b", 2), Eq("c", 3)), []interface{}{
+			"|", "|", []interface{}{"a", "=", 1}, []interface{}{"b", "=", 2}, []interface{}{"c", "=", 3},
+		}},
+		{"or empty", NewDomain().Or(Eq("a", 1), Eq("b", 2)), []interface{}{
+			"|", []interface{}{"a", "=", 1}, []interface{}{"b", "=", 2},
+		}},
+		{"and raw", Domain{[]interface{}{"a", "=", 1}, []interface{}{"b", "=", 2}}.And(Eq("c", 3)), []interface{}{
+			"&", "&", []interface{}{"a", "=", 1}, []interface{}{"b", "=", 2}, []interface{}{"c", "=", 3},
+		}},
+		{"empty criteria", NewDomain(Or(), And(Eq("a", 1))), []interface{}{
+			[]interface{}{"a", "=", 1},
+		}},
+		{"or match all", NewDomain(Eq("a", 1), Or(And(), Eq("b", 2))), []interface{}{
+			[]interface{}{"a", "=", 1},
+		}},
+		{"or empty criterion", NewDomain(Eq("a", 1)).Or(And()), nil},
+		{"empty or empty criterion", NewDomain().Or(Eq("a", 1), And()), nil},
+	} {
+		if !reflect.DeepEqual([]interface{}(tc.got), tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+		if err := tc.got.Validate(); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+	}
+
+	// A Domain is usable as SearchReadOptions.Domain
+	_ = SearchReadOptions{Domain: NewDomain(Eq("active", true))}
+}
+
+func TestValidateDomain(t *testing.T) {
+	for _, domain := range [][]interface{}{
+		NewDomain(Cond("name", "==", "x")),
+		{"|", []interface{}{"a", "=", 1}},
+		{"&"},
+		{[]interface{}{"a", "="}},
+		{[]interface{}{1, "in", 1}},
+		{"x"},
+		{42},
+	} {
+		if err := ValidateDomain(domain); err == nil {
+			t.Errorf("%v: no error", domain)
+		}
+	}
+	if err := ValidateDomain([]interface{}{[]interface{}{1, "=", 1}, "!", []interface{}{"a", "not ilike", "b"}}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,301 @@
+package odoo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// Request bodies are encoded into pooled buffers: high-frequency small calls
+// otherwise allocate a new buffer, plus one per nested value, on every call.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer keeps occasional huge requests (e.g. attachments) from
+// pinning their memory in the pool
+const maxPooledBuffer = 1 << 20
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// encodeBuffer is what requests are encoded into: a pooled bytes.Buffer, or
+// a bufio.Writer for requests encoded while they are sent
+type encodeBuffer interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+	AvailableBuffer() []byte
+}
+
+// encodeMethodCall writes an XML-RPC methodCall to buf. The output is
+// equivalent to xmlrpc.EncodeMethodCall but written in place, with fast paths
+// for the types used in execute_kw calls and reflection only for the rest.
+func encodeMethodCall(buf encodeBuffer, method string, args ...interface{}) error {
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?><methodCall><methodName>`)
+	buf.WriteString(method)
+	buf.WriteString(`</methodName>`)
+
+	if args != nil {
+		buf.WriteString("<params>")
+		for _, arg := range args {
+			buf.WriteString("<param>")
+			if arg != nil {
+				if err := encodeValue(buf, arg); err != nil {
+					return err
+				}
+			}
+			buf.WriteString("</param>")
+		}
+		buf.WriteString("</params>")
+	}
+
+	buf.WriteString("</methodCall>")
+	return nil
+}
+
+func encodeValue(buf encodeBuffer, v interface{}) error {
+	switch t := v.(type) {
+	case nil:
+		buf.WriteString("<value/>")
+	case string:
+		buf.WriteString("<value><string>")
+		escapeString(buf, t)
+		buf.WriteString("</string></value>")
+	case xmlrpc.Base64:
+		buf.WriteString("<value><base64>")
+		escapeString(buf, string(t))
+		buf.WriteString("</base64></value>")
+	case bool:
+		if t {
+			buf.WriteString("<value><boolean>1</boolean></value>")
+		} else {
+			buf.WriteString("<value><boolean>0</boolean></value>")
+		}
+	case int:
+		writeInt(buf, int64(t))
+	case int64:
+		writeInt(buf, t)
+	case int32:
+		writeInt(buf, int64(t))
+	case float64:
+		buf.WriteString("<value><double>")
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), t, 'f', -1, 64))
+		buf.WriteString("</double></value>")
+	case time.Time:
+		buf.WriteString("<value><dateTime.iso8601>")
+		buf.Write(t.AppendFormat(buf.AvailableBuffer(), "20060102T15:04:05"))
+		buf.WriteString("</dateTime.iso8601></value>")
+	case []interface{}:
+		buf.WriteString("<value><array><data>")
+		for _, item := range t {
+			if err := encodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array></value>")
+	case []int64:
+		buf.WriteString("<value><array><data>")
+		for _, item := range t {
+			writeInt(buf, item)
+		}
+		buf.WriteString("</data></array></value>")
+	case []int:
+		buf.WriteString("<value><array><data>")
+		for _, item := range t {
+			writeInt(buf, int64(item))
+		}
+		buf.WriteString("</data></array></value>")
+	case []string:
+		buf.WriteString("<value><array><data>")
+		for _, item := range t {
+			buf.WriteString("<value><string>")
+			escapeString(buf, item)
+			buf.WriteString("</string></value>")
+		}
+		buf.WriteString("</data></array></value>")
+	case map[string]interface{}:
+		buf.WriteString("<value><struct>")
+		for key, item := range t {
+			writeMemberName(buf, key)
+			if err := encodeValue(buf, item); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct></value>")
+	case *binaryValue:
+		return t.encode(buf)
+	case map[string]string:
+		buf.WriteString("<value><struct>")
+		for key, item := range t {
+			writeMemberName(buf, key)
+			buf.WriteString("<value><string>")
+			escapeString(buf, item)
+			buf.WriteString("</string></value></member>")
+		}
+		buf.WriteString("</struct></value>")
+	default:
+		return encodeReflect(buf, reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// encodeReflect handles the types without a fast path, following the rules
+// of the xmlrpc package (including its struct tags)
+func encodeReflect(buf encodeBuffer, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			buf.WriteString("<value/>")
+			return nil
+		}
+		return encodeValue(buf, val.Elem().Interface())
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		buf.WriteString("<value><struct>")
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty := field.Tag.Get("xmlrpc"), false
+			if name == "-" {
+				continue
+			}
+			if strings.HasSuffix(name, ",omitempty") {
+				name, omitEmpty = strings.TrimSuffix(name, ",omitempty"), true
+			}
+			if omitEmpty && val.Field(i).IsZero() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			writeMemberName(buf, name)
+			if err := encodeValue(buf, val.Field(i).Interface()); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct></value>")
+	case reflect.Map:
+		if val.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("xmlrpc encode error: only maps with string keys are supported")
+		}
+		buf.WriteString("<value><struct>")
+		iter := val.MapRange()
+		for iter.Next() {
+			writeMemberName(buf, iter.Key().String())
+			if err := encodeValue(buf, iter.Value().Interface()); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct></value>")
+	case reflect.Slice, reflect.Array:
+		buf.WriteString("<value><array><data>")
+		for i := 0; i < val.Len(); i++ {
+			if err := encodeValue(buf, val.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array></value>")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeInt(buf, val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString("<value><i4>")
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), val.Uint(), 10))
+		buf.WriteString("</i4></value>")
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString("<value><double>")
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), val.Float(), 'f', -1, val.Type().Bits()))
+		buf.WriteString("</double></value>")
+	case reflect.Bool:
+		return encodeValue(buf, val.Bool())
+	case reflect.String:
+		return encodeValue(buf, val.String())
+	default:
+		return fmt.Errorf("xmlrpc encode error: unsupported type %s", val.Type())
+	}
+	return nil
+}
+
+func writeInt(buf encodeBuffer, i int64) {
+	buf.WriteString("<value><int>")
+	buf.Write(strconv.AppendInt(buf.AvailableBuffer(), i, 10))
+	buf.WriteString("</int></value>")
+}
+
+func writeMemberName(buf encodeBuffer, name string) {
+	buf.WriteString("<member><name>")
+	escapeString(buf, name)
+	buf.WriteString("</name>")
+}
+
+// escapeString writes s with the escaping of xml.EscapeText without
+// converting it to a byte slice first
+func escapeString(buf encodeBuffer, s string) {
+	last := 0
+	for i := 0; i < len(s); {
+		r, width := utf8.DecodeRuneInString(s[i:])
+		var esc string
+		switch r {
+		case '"':
+			esc = "&#34;"
+		case '\'':
+			esc = "&#39;"
+		case '&':
+			esc = "&amp;"
+		case '<':
+			esc = "&lt;"
+		case '>':
+			esc = "&gt;"
+		case '\t':
+			esc = "&#x9;"
+		case '\n':
+			esc = "&#xA;"
+		case '\r':
+			esc = "&#xD;"
+		default:
+			if !isXMLChar(r) || (r == utf8.RuneError && width == 1) {
+				esc = "\uFFFD"
+				break
+			}
+			i += width
+			continue
+		}
+		buf.WriteString(s[last:i])
+		buf.WriteString(esc)
+		i += width
+		last = i
+	}
+	buf.WriteString(s[last:])
+}
+
+// isXMLChar reports whether r is in the XML character range
+func isXMLChar(r rune) bool {
+	return r == 0x09 || r == 0x0A || r == 0x0D ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
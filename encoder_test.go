@@ -0,0 +1,92 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func executeKwArgs() []interface{} {
+	return []interface{}{
+		"db", 2, "key",
+		"crm.lead", "search_read",
+		[]interface{}{[]interface{}{
+			[]interface{}{"type", "=", "lead"},
+			"|",
+			[]interface{}{"name", "ilike", `R&D <"quoted">`},
+			[]interface{}{"stage_id", "in", []int64{1, 2, 3}},
+		}},
+		map[string]interface{}{
+			"fields":  []string{"id", "name", "email_from"},
+			"offset":  0,
+			"limit":   80,
+			"order":   "create_date desc",
+			"context": map[string]interface{}{"lang": "en_US", "active_test": false, "ratio": 0.5},
+			"since":   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+}
+
+// decodeParams parses the parameters of an encoded methodCall
+func decodeParams(t testing.TB, data []byte) []interface{} {
+	dec := &valueDecoder{xml.NewDecoder(bytes.NewReader(data))}
+	var params []interface{}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return params
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "value" {
+			v, err := dec.value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			params = append(params, v)
+		}
+	}
+}
+
+func TestEncodeMethodCallMatchesXMLRPC(t *testing.T) {
+	args := executeKwArgs()
+
+	want, err := xmlrpc.EncodeMethodCall("execute_kw", args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := encodeMethodCall(&buf, "execute_kw", args...); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decodeParams(t, want), decodeParams(t, buf.Bytes())) {
+		t.Errorf("encoded params differ:\nwant: %s\ngot:  %s", want, buf.Bytes())
+	}
+}
+
+func BenchmarkEncodeExecuteKw(b *testing.B) {
+	args := executeKwArgs()
+
+	b.Run("xmlrpc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := xmlrpc.EncodeMethodCall("execute_kw", args...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := getBuffer()
+			if err := encodeMethodCall(buf, "execute_kw", args...); err != nil {
+				b.Fatal(err)
+			}
+			putBuffer(buf)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package odoo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error is a fault reported by the Odoo server. Connector methods wrap it,
+// so use errors.As or the Is* predicates to inspect it:
+//
+//	var fault *odoo.Error
+//	if errors.As(err, &fault) {
+//		log.Print(fault.ExceptionType, fault.ServerTraceback)
+//	}
+type Error struct {
+	// FaultCode is the XML-RPC fault code (1 server error, 2 user error,
+	// 3 access denied, 4 access error) or the JSON-RPC error code
+	FaultCode int
+	// ExceptionType is the Python exception, e.g.
+	// "odoo.exceptions.AccessError", when the server reports it
+	ExceptionType string
+	// Message is the message of the exception
+	Message string
+	// ServerTraceback is the Python traceback, which Odoo only sends for
+	// unexpected server errors
+	ServerTraceback string
+}
+
+func (e *Error) Error() string {
+	if e.ExceptionType == "" {
+		return fmt.Sprintf("Fault(%d): %s", e.FaultCode, e.Message)
+	}
+	return fmt.Sprintf("Fault(%d): %s: %s", e.FaultCode, e.ExceptionType, e.Message)
+}
+
+// Sentinel errors matched by errors.Is for server faults of the
+// corresponding Odoo exceptions
+var (
+	// ErrAccessDenied is a rejected login or API key (AccessDenied)
+	ErrAccessDenied = errors.New("odoo: access denied")
+	// ErrAccess is a missing access right or record rule (AccessError)
+	ErrAccess = errors.New("odoo: access error")
+	// ErrMissingRecord is a record that does not exist or has been deleted
+	// (MissingError)
+	ErrMissingRecord = errors.New("odoo: missing record")
+	// ErrValidation is a constraint violation (ValidationError), also
+	// matched by the client-side *ValidationError. XML-RPC reports
+	// validation errors as plain user errors, so over XML-RPC only ErrUser
+	// matches them.
+	ErrValidation = errors.New("odoo: validation error")
+	// ErrUser is an error meant for the user (UserError), including all of
+	// the above
+	ErrUser = errors.New("odoo: user error")
+)
+
+// Writes and deletes the server reports as having changed no record, which
+// match ErrMissingRecord and ErrUser like a MissingError
+var (
+	errNoRecordUpdated error = noRecordError("no record updated")
+	errNoRecordDeleted error = noRecordError("no record deleted")
+)
+
+type noRecordError string
+
+func (e noRecordError) Error() string {
+	return string(e)
+}
+
+func (e noRecordError) Is(target error) bool {
+	return target == ErrMissingRecord || target == ErrUser
+}
+
+// Odoo's XML-RPC fault codes
+const (
+	faultCodeServerError  = 1
+	faultCodeWarning      = 2
+	faultCodeAccessDenied = 3
+	faultCodeAccessError  = 4
+)
+
+// missingRecordMessage starts the message of Odoo's MissingError, which
+// XML-RPC reports as a plain warning
+const missingRecordMessage = "Record does not exist or has been deleted"
+
+// Is matches the sentinel error of the exception
+func (e *Error) Is(target error) bool {
+	name := e.ExceptionType[strings.LastIndex(e.ExceptionType, ".")+1:]
+	switch target {
+	case ErrAccessDenied:
+		return name == "AccessDenied"
+	case ErrAccess:
+		return name == "AccessError"
+	case ErrMissingRecord:
+		return name == "MissingError"
+	case ErrValidation:
+		return name == "ValidationError"
+	case ErrUser:
+		switch name {
+		case "UserError", "AccessDenied", "AccessError", "MissingError", "ValidationError", "RedirectWarning", "CacheMiss":
+			return true
+		}
+	}
+	return false
+}
+
+// IsAccessDenied reports whether err is a rejected login or API key
+func IsAccessDenied(err error) bool {
+	return errors.Is(err, ErrAccessDenied)
+}
+
+// IsAccessError reports whether err is a missing access right or record
+// rule
+func IsAccessError(err error) bool {
+	return errors.Is(err, ErrAccess)
+}
+
+// IsMissingRecord reports whether err is about a record that does not exist
+// or has been deleted
+func IsMissingRecord(err error) bool {
+	return errors.Is(err, ErrMissingRecord)
+}
+
+// IsValidationError reports whether err is a constraint violation, reported
+// by client-side validation or by the server over JSON-RPC. Over XML-RPC the
+// server sends constraint violations without their exception type, so they
+// only satisfy IsUserError.
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsUserError reports whether err is an error meant for the user rather than
+// a server failure
+func IsUserError(err error) bool {
+	return errors.Is(err, ErrUser)
+}
+
+// newFault converts an XML-RPC fault into an *Error. Odoo sends the message
+// of user errors and the traceback of unexpected errors as the fault string.
+func newFault(code int, faultString string) *Error {
+	e := &Error{FaultCode: code, Message: faultString}
+	switch code {
+	case faultCodeWarning:
+		e.ExceptionType = "odoo.exceptions.UserError"
+		if strings.HasPrefix(faultString, missingRecordMessage) {
+			e.ExceptionType = "odoo.exceptions.MissingError"
+		}
+	case faultCodeAccessDenied:
+		e.ExceptionType = "odoo.exceptions.AccessDenied"
+	case faultCodeAccessError:
+		e.ExceptionType = "odoo.exceptions.AccessError"
+	default:
+		if strings.HasPrefix(faultString, "Traceback") {
+			e.ServerTraceback = faultString
+			e.ExceptionType, e.Message = parseTraceback(faultString)
+		}
+	}
+	return e
+}
+
+// parseTraceback returns the exception type and message of a Python
+// traceback, which follow the last indented line of the stack
+func parseTraceback(traceback string) (string, string) {
+	lines := strings.Split(strings.TrimRight(traceback, "\n"), "\n")
+	last := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, " ") {
+			last = i + 1
+		}
+	}
+	exception := strings.Join(lines[last:], "\n")
+	typ, message, found := strings.Cut(exception, ": ")
+	if !found || strings.ContainsAny(typ, " \n") {
+		// An exception without message, e.g. "KeyError"
+		return strings.TrimSpace(exception), ""
+	}
+	return typ, message
+}
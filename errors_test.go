@@ -0,0 +1,60 @@
+package odoo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFaultErrors(t *testing.T) {
+	traceback := "Traceback (most recent call last):\n" +
+		"  File \"/odoo/odoo/service/model.py\", line 156, in wrapper\n" +
+		"    return f(dbname, *args, **kwargs)\n" +
+		"odoo.exceptions.ValidationError: The VAT number is invalid.\n" +
+		"Expected format: BE0477472701\n"
+
+	tests := []struct {
+		name     string
+		err      *Error
+		typ      string
+		message  string
+		sentinel error
+	}{
+		{"traceback", newFault(1, traceback), "odoo.exceptions.ValidationError", "The VAT number is invalid.\nExpected format: BE0477472701", ErrValidation},
+		{"access error", newFault(4, "You are not allowed to modify 'Contact' (res.partner) records."), "odoo.exceptions.AccessError", "You are not allowed to modify 'Contact' (res.partner) records.", ErrAccess},
+		{"access denied", newFault(3, "Access Denied"), "odoo.exceptions.AccessDenied", "Access Denied", ErrAccessDenied},
+		{"missing record", newFault(2, "Record does not exist or has been deleted.\n(Record: res.partner(99,), User: 2)"), "odoo.exceptions.MissingError", "Record does not exist or has been deleted.\n(Record: res.partner(99,), User: 2)", ErrMissingRecord},
+		{"user error", newFault(2, "You cannot delete a posted journal entry."), "odoo.exceptions.UserError", "You cannot delete a posted journal entry.", ErrUser},
+	}
+	for _, tt := range tests {
+		if tt.err.ExceptionType != tt.typ || tt.err.Message != tt.message {
+			t.Errorf("%s: got %q, %q, want %q, %q", tt.name, tt.err.ExceptionType, tt.err.Message, tt.typ, tt.message)
+		}
+		wrapped := fmt.Errorf("update failed for model res.partner with id 1: %w", tt.err)
+		if !errors.Is(wrapped, tt.sentinel) {
+			t.Errorf("%s: errors.Is(%v) = false", tt.name, tt.sentinel)
+		}
+		if !IsUserError(wrapped) {
+			t.Errorf("%s: not a user error", tt.name)
+		}
+	}
+
+	if IsAccessError(newFault(1, "Traceback (most recent call last):\n  File \"x\"\nKeyError: 'name'")) {
+		t.Error("KeyError is an access error")
+	}
+	if !IsValidationError(&ValidationError{Model: "res.partner", Method: "create"}) {
+		t.Error("client-side validation error does not match ErrValidation")
+	}
+
+	// XML-RPC sends a ValidationError as a warning with the message only
+	validation := newFault(2, "The VAT number is invalid.")
+	if IsValidationError(validation) || !IsUserError(validation) {
+		t.Errorf("XML-RPC validation fault: IsValidationError %v, IsUserError %v", IsValidationError(validation), IsUserError(validation))
+	}
+
+	for _, err := range []error{errNoRecordUpdated, errNoRecordDeleted} {
+		if wrapped := fmt.Errorf("update failed for model res.partner with id 1: %w", err); !IsMissingRecord(wrapped) || !IsUserError(wrapped) {
+			t.Errorf("%v: IsMissingRecord %v, IsUserError %v", err, IsMissingRecord(wrapped), IsUserError(wrapped))
+		}
+	}
+}
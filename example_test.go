@@ -62,3 +62,14 @@ func ExampleNewConnectorFromConfig() {
 		fmt.Printf("Lead: %v\n", lead["name"])
 	}
 }
+
+func ExampleFieldsOf() {
+	type Lead struct {
+		ID    int64  `odoo:"id"`
+		Name  string `odoo:"name"`
+		Email string `odoo:"email_from"`
+	}
+
+	fmt.Println(odoo.FieldsOf([]Lead{}))
+	// Output: [id name email_from]
+}
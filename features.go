@@ -0,0 +1,162 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnsupported is returned by operations that need a feature the server
+// does not support
+var ErrUnsupported = errors.New("odoo: unsupported by the server")
+
+// Feature is a server capability that depends on the Odoo version or on the
+// installed modules
+type Feature string
+
+const (
+	// FeatureWebSearchRead is web_search_read with a nested field
+	// specification (Odoo 17)
+	FeatureWebSearchRead Feature = "web_search_read"
+	// FeatureOnchangeSpecification is onchange taking a nested field
+	// specification and a list of changed fields (Odoo 17)
+	FeatureOnchangeSpecification Feature = "onchange_specification"
+	// FeatureJSON2 is the /json/2 endpoint with bearer API keys (Odoo 19)
+	FeatureJSON2 Feature = "json2"
+	// FeatureAPIKeys is res.users.apikeys (Odoo 14)
+	FeatureAPIKeys Feature = "api_keys"
+	// FeatureAPIKeyExpiration is the expiration date of API keys (Odoo 17)
+	FeatureAPIKeyExpiration Feature = "api_key_expiration"
+	// FeatureCronLastCall is ir.cron's lastcall field (Odoo 15)
+	FeatureCronLastCall Feature = "cron_lastcall"
+	// FeaturePortalGrantPerUser is the portal wizard granting access line
+	// by line (Odoo 15)
+	FeaturePortalGrantPerUser Feature = "portal_grant_per_user"
+	// FeatureSplitUserGroups is res.users' group_ids and all_group_ids
+	// replacing groups_id (Odoo 19)
+	FeatureSplitUserGroups Feature = "split_user_groups"
+	// FeaturePasswordEmails is sending password reset and invitation emails,
+	// which needs the auth_signup module
+	FeaturePasswordEmails Feature = "password_emails"
+)
+
+// featureSpec tells how a feature is detected: by the major server version
+// and, if set, by probing the server
+type featureSpec struct {
+	minVersion int
+	probe      func(ctx context.Context, c *Connector) (bool, error)
+}
+
+var featureSpecs = map[Feature]featureSpec{
+	FeatureWebSearchRead:         {minVersion: 17},
+	FeatureOnchangeSpecification: {minVersion: 17},
+	FeatureJSON2:                 {minVersion: 19},
+	FeatureAPIKeys:               {minVersion: 14},
+	FeatureAPIKeyExpiration:      {minVersion: 17},
+	FeatureCronLastCall:          {minVersion: 15},
+	FeaturePortalGrantPerUser:    {minVersion: 15},
+	FeatureSplitUserGroups:       {minVersion: 19},
+	FeaturePasswordEmails:        {probe: moduleInstalledProbe("auth_signup")},
+}
+
+// features caches the detected features of a connector
+type features struct {
+	mu        sync.Mutex
+	detected  map[Feature]bool
+	overrides map[Feature]bool
+}
+
+// WithFeatures forces features on or off instead of detecting them, e.g.
+// to pin behaviour in tests or to work around a misdetected server
+func WithFeatures(overrides map[Feature]bool) Option {
+	return func(c *Connector) {
+		c.features.mu.Lock()
+		defer c.features.mu.Unlock()
+		if c.features.overrides == nil {
+			c.features.overrides = make(map[Feature]bool, len(overrides))
+		}
+		for feature, enabled := range overrides {
+			c.features.overrides[feature] = enabled
+		}
+	}
+}
+
+// Supports reports whether the server supports feature, so callers can
+// branch on capabilities instead of on error messages:
+//
+//	if c.Supports(odoo.FeatureWebSearchRead) {
+//		...
+//	}
+//
+// Features are detected once per connector. Failing detection reports the
+// feature as unsupported; use SupportsContext to tell the cases apart.
+func (c *Connector) Supports(feature Feature) bool {
+	supported, _ := c.SupportsContext(context.Background(), feature)
+	return supported
+}
+
+// SupportsContext reports whether the server supports feature
+func (c *Connector) SupportsContext(ctx context.Context, feature Feature) (bool, error) {
+	c.features.mu.Lock()
+	if supported, ok := c.features.overrides[feature]; ok {
+		c.features.mu.Unlock()
+		return supported, nil
+	}
+	if supported, ok := c.features.detected[feature]; ok {
+		c.features.mu.Unlock()
+		return supported, nil
+	}
+	c.features.mu.Unlock()
+
+	spec, ok := featureSpecs[feature]
+	if !ok {
+		return false, fmt.Errorf("unknown feature %q", feature)
+	}
+	supported := true
+	if spec.minVersion > 0 {
+		major, err := c.majorVersion(ctx)
+		if err != nil {
+			return false, fmt.Errorf("feature detection failed for %s: %w", feature, err)
+		}
+		supported = major >= spec.minVersion
+	}
+	if supported && spec.probe != nil {
+		var err error
+		if supported, err = spec.probe(ctx, c); err != nil {
+			return false, fmt.Errorf("feature detection failed for %s: %w", feature, err)
+		}
+	}
+
+	c.features.mu.Lock()
+	defer c.features.mu.Unlock()
+	if c.features.detected == nil {
+		c.features.detected = make(map[Feature]bool)
+	}
+	c.features.detected[feature] = supported
+	return supported, nil
+}
+
+// requireFeature fails with ErrUnsupported unless the server supports feature
+func (c *Connector) requireFeature(ctx context.Context, feature Feature) error {
+	supported, err := c.SupportsContext(ctx, feature)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("%w: %s", ErrUnsupported, feature)
+	}
+	return nil
+}
+
+// moduleInstalledProbe detects a feature by its module being installed
+func moduleInstalledProbe(module string) func(ctx context.Context, c *Connector) (bool, error) {
+	return func(ctx context.Context, c *Connector) (bool, error) {
+		var count int64
+		err := c.execute(ctx, "ir.module.module", "search_count", []interface{}{[]interface{}{
+			[]interface{}{"name", "=", module},
+			[]interface{}{"state", "=", ModuleInstalled},
+		}}, nil, &count)
+		return count > 0, err
+	}
+}
@@ -0,0 +1,27 @@
+package odoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSupports(t *testing.T) {
+	c := &Connector{serverVersion: 17}
+	WithFeatures(map[Feature]bool{FeatureSplitUserGroups: true})(c)
+
+	for feature, want := range map[Feature]bool{
+		FeatureWebSearchRead:    true,
+		FeatureAPIKeyExpiration: true,
+		FeatureJSON2:            false,
+		// Forced on although the server is older
+		FeatureSplitUserGroups: true,
+	} {
+		if got := c.Supports(feature); got != want {
+			t.Errorf("Supports(%s) = %v, want %v", feature, got, want)
+		}
+	}
+
+	if _, err := c.SupportsContext(context.Background(), Feature("teleport")); err == nil {
+		t.Error("unknown feature did not fail")
+	}
+}
@@ -0,0 +1,94 @@
+package odoo
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structField maps a struct field to an Odoo field through its `odoo` tag:
+//
+//	type Lead struct {
+//		ID      int64  `odoo:"id"`
+//		Name    string `odoo:"name"`
+//		Email   string `odoo:"email_from"`
+//		Ignored string `odoo:"-"`
+//	}
+//
+// Fields without a tag are not mapped. Embedded structs are flattened.
+type structField struct {
+	name  string
+	index []int
+	opts  string
+}
+
+var structFieldsCache sync.Map // reflect.Type -> []structField
+
+// structFieldsOf returns the mapped fields of a struct type
+func structFieldsOf(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	var fields []structField
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldIndex := append(append([]int(nil), index...), i)
+			tag, hasTag := field.Tag.Lookup("odoo")
+			if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+				collect(field.Type, fieldIndex)
+				continue
+			}
+			if !field.IsExported() || !hasTag || tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				continue
+			}
+			fields = append(fields, structField{name: name, index: fieldIndex, opts: opts})
+		}
+	}
+	collect(t, nil)
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// structFieldsByName returns the mapped fields of a struct type by Odoo field
+// name
+func structFieldsByName(t reflect.Type) map[string]structField {
+	fields := make(map[string]structField)
+	for _, f := range structFieldsOf(t) {
+		fields[f.name] = f
+	}
+	return fields
+}
+
+// FieldsOf returns the Odoo field names declared with `odoo` tags on the
+// struct (or pointer, slice or array of structs) v, in declaration order.
+// Passing them as SearchReadOptions.Fields fetches exactly the consumed
+// fields instead of every field of wide models such as res.partner:
+//
+//	opts := odoo.SearchReadOptions{Fields: odoo.FieldsOf(Lead{})}
+//
+// SearchReadInto does this by itself when Fields is empty; no other method
+// derives fields from struct tags.
+func FieldsOf(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := structFieldsOf(t)
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.name)
+	}
+	return names
+}
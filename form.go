@@ -0,0 +1,111 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// FieldChange is a single user edit replayed by SimulateForm
+type FieldChange struct {
+	Field string
+	Value interface{}
+}
+
+// SimulateForm reproduces what the web client does when a user fills in a new
+// record: it loads the defaults (default_get), applies initialValues, runs the
+// initial onchange and then replays changes one by one, each followed by the
+// onchange of the edited field. The returned values can be passed to
+// CreateRecord so that API-created records match UI-created ones.
+//
+// Only top-level fields take part in the onchange specification; lines of
+// one2many fields are returned as the command lists produced by the server.
+func (c *Connector) SimulateForm(ctx context.Context, model string, initialValues map[string]interface{}, changes []FieldChange) (map[string]interface{}, error) {
+	specification, err := c.SupportsContext(ctx, FeatureOnchangeSpecification)
+	if err != nil {
+		return nil, fmt.Errorf("form simulation failed for model %s: %w", model, err)
+	}
+
+	var fields map[string]interface{}
+	err = c.execute(ctx, model, "fields_get", []interface{}{}, map[string]interface{}{
+		"attributes": []string{"type"},
+	}, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("form simulation failed for model %s: %w", model, err)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	values := make(map[string]interface{})
+	if err := c.execute(ctx, model, "default_get", []interface{}{names}, nil, &values); err != nil {
+		return nil, fmt.Errorf("form simulation failed for model %s: %w", model, err)
+	}
+	for name, value := range initialValues {
+		values[name] = value
+	}
+
+	// Odoo 17 replaced the flat {"field": "1"} onchange specification by a
+	// nested fields specification and takes a list of changed field names.
+	spec := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if specification {
+			spec[name] = map[string]interface{}{}
+		} else {
+			spec[name] = "1"
+		}
+	}
+	onchange := func(field string) error {
+		var changed interface{} = field
+		if specification {
+			changed = []string{}
+			if field != "" {
+				changed = []string{field}
+			}
+		}
+
+		var result map[string]interface{}
+		if err := c.execute(ctx, model, "onchange", []interface{}{[]int64{}, values, changed, spec}, nil, &result); err != nil {
+			return err
+		}
+		updates, _ := result["value"].(map[string]interface{})
+		for name, value := range updates {
+			values[name] = normalizeFormValue(value)
+		}
+		return nil
+	}
+
+	if err := onchange(""); err != nil {
+		return nil, fmt.Errorf("form simulation failed for model %s: %w", model, err)
+	}
+	for _, change := range changes {
+		values[change.Field] = change.Value
+		if err := onchange(change.Field); err != nil {
+			return nil, fmt.Errorf("form simulation failed for model %s on field %s: %w", model, change.Field, err)
+		}
+	}
+
+	delete(values, "id")
+	return values, nil
+}
+
+// normalizeFormValue turns many2one values returned by onchange ([id, name]
+// pairs before Odoo 17, {"id": ...} dicts afterwards) into plain IDs, the form
+// in which the server expects them back.
+func normalizeFormValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) == 2 {
+			if id, ok := toInt64(v[0]); ok {
+				if _, ok := v[1].(string); ok {
+					return id
+				}
+			}
+		}
+	case map[string]interface{}:
+		if id, ok := toInt64(v["id"]); ok && len(v) <= 2 {
+			return id
+		}
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSimulateForm(t *testing.T) {
+	for _, tc := range []struct {
+		version   int
+		initial   interface{}
+		changed   interface{}
+		spec      interface{}
+		pricelist interface{}
+	}{
+		{16, "", "partner_id", "1", []interface{}{3, "Public"}},
+		{17, []interface{}{}, []interface{}{"partner_id"}, map[string]interface{}{}, map[string]interface{}{"id": 3, "display_name": "Public"}},
+	} {
+		var onchanges []interface{}
+		srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+			switch method {
+			case "authenticate":
+				return 2
+			case "version":
+				return map[string]interface{}{"server_version_info": []interface{}{tc.version, 0, 0, "final", 0, ""}}
+			}
+			switch args[4] {
+			case "fields_get":
+				return map[string]interface{}{"name": map[string]interface{}{}, "partner_id": map[string]interface{}{}, "pricelist_id": map[string]interface{}{}}
+			case "default_get":
+				return map[string]interface{}{"name": "New", "partner_id": false, "pricelist_id": false}
+			case "onchange":
+				params := args[5].([]interface{})
+				if spec := params[3].(map[string]interface{}); !reflect.DeepEqual(spec["name"], tc.spec) {
+					t.Errorf("Odoo %d: specification %v", tc.version, spec)
+				}
+				onchanges = append(onchanges, params[2])
+				if len(onchanges) == 1 {
+					return map[string]interface{}{"value": map[string]interface{}{"id": false}}
+				}
+				if params[1].(map[string]interface{})["partner_id"] != int64(7) {
+					t.Errorf("Odoo %d: onchange values %v", tc.version, params[1])
+				}
+				return map[string]interface{}{"value": map[string]interface{}{"pricelist_id": tc.pricelist}}
+			}
+			return false
+		})
+		c, err := NewConnector(srv.URL, "admin", "key", "db")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := c.SimulateForm(context.Background(), "sale.order",
+			map[string]interface{}{"name": "Quote"},
+			[]FieldChange{{Field: "partner_id", Value: 7}},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []interface{}{tc.initial, tc.changed}; !reflect.DeepEqual(onchanges, want) {
+			t.Errorf("Odoo %d: onchanges for %v, want %v", tc.version, onchanges, want)
+		}
+		// Many2one values come back as plain IDs and the id is dropped
+		want := map[string]interface{}{"name": "Quote", "partner_id": 7, "pricelist_id": int64(3)}
+		if !reflect.DeepEqual(values, want) {
+			t.Errorf("Odoo %d: values %#v, want %#v", tc.version, values, want)
+		}
+	}
+}
+
+func TestNormalizeFormValue(t *testing.T) {
+	for _, tc := range []struct {
+		value, want interface{}
+	}{
+		{[]interface{}{int64(3), "Public"}, int64(3)},
+		{map[string]interface{}{"id": int64(3), "display_name": "Public"}, int64(3)},
+		{[]interface{}{int64(1), int64(2)}, []interface{}{int64(1), int64(2)}},
+		{map[string]interface{}{"id": int64(3), "name": "a", "code": "b"}, map[string]interface{}{"id": int64(3), "name": "a", "code": "b"}},
+		{"text", "text"},
+	} {
+		if got := normalizeFormValue(tc.value); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("normalizeFormValue(%v) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Group is a res.groups record together with its external identifier
+type Group struct {
+	ID       int64
+	Name     string
+	FullName string
+	XMLID    string
+}
+
+// CurrentUserGroups lists the groups of the integration user, including the
+// groups implied by them.
+func (c *Connector) CurrentUserGroups(ctx context.Context) ([]Group, error) {
+	user, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.GroupIDs) == 0 {
+		return nil, nil
+	}
+
+	var records []map[string]interface{}
+	err = c.execute(ctx, "res.groups", "read", []interface{}{user.GroupIDs}, map[string]interface{}{
+		"fields": []string{"name", "full_name"},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("group lookup failed: %w", err)
+	}
+
+	var refs []map[string]interface{}
+	err = c.execute(ctx, "ir.model.data", "search_read", []interface{}{[]interface{}{
+		[]interface{}{"model", "=", "res.groups"},
+		[]interface{}{"res_id", "in", user.GroupIDs},
+	}}, map[string]interface{}{
+		"fields": []string{"module", "name", "res_id"},
+	}, &refs)
+	if err != nil {
+		return nil, fmt.Errorf("group lookup failed: %w", err)
+	}
+	xmlids := make(map[int64]string, len(refs))
+	for _, ref := range refs {
+		id, _ := toInt64(ref["res_id"])
+		module, _ := ref["module"].(string)
+		name, _ := ref["name"].(string)
+		xmlids[id] = module + "." + name
+	}
+
+	groups := make([]Group, 0, len(records))
+	for _, record := range records {
+		group := Group{}
+		group.ID, _ = toInt64(record["id"])
+		group.Name, _ = record["name"].(string)
+		group.FullName, _ = record["full_name"].(string)
+		group.XMLID = xmlids[group.ID]
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// UserHasGroup reports whether the integration user belongs to the group with
+// the given external identifier, e.g. "sales_team.group_sale_manager". Use it
+// to gate features on ERP permissions before starting an operation.
+func (c *Connector) UserHasGroup(ctx context.Context, xmlid string) (bool, error) {
+	groupID, err := c.xmlidToResID(ctx, "res.groups", xmlid)
+	if err != nil {
+		return false, fmt.Errorf("group check failed for %s: %w", xmlid, err)
+	}
+
+	user, err := c.CurrentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range user.GroupIDs {
+		if id == groupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// xmlidToResID resolves an external identifier ("module.name") of the given
+// model to its database ID.
+func (c *Connector) xmlidToResID(ctx context.Context, model, xmlid string) (int64, error) {
+	id, found, err := c.resolveXMLID(ctx, model, xmlid)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("external identifier %s not found", xmlid)
+	}
+	return id, nil
+}
+
+// resolveXMLID looks up the record of model with the external identifier
+// xmlid, reporting whether it exists
+func (c *Connector) resolveXMLID(ctx context.Context, model, xmlid string) (int64, bool, error) {
+	module, name, ok := strings.Cut(xmlid, ".")
+	if !ok {
+		return 0, false, fmt.Errorf("invalid external identifier %q: expected module.name", xmlid)
+	}
+
+	var refs []map[string]interface{}
+	err := c.execute(ctx, "ir.model.data", "search_read", []interface{}{[]interface{}{
+		[]interface{}{"module", "=", module},
+		[]interface{}{"name", "=", name},
+		[]interface{}{"model", "=", model},
+	}}, map[string]interface{}{
+		"fields": []string{"res_id"},
+		"limit":  1,
+	}, &refs)
+	if err != nil || len(refs) == 0 {
+		return 0, false, err
+	}
+
+	id, _ := toInt64(refs[0]["res_id"])
+	return id, true, nil
+}
@@ -0,0 +1,70 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCurrentUserGroups(t *testing.T) {
+	c := userServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		switch model + "." + method {
+		case "res.groups.read":
+			return []map[string]interface{}{
+				{"id": 10, "name": "Internal User", "full_name": "User types / Internal User"},
+				{"id": 11, "name": "Administrator", "full_name": "Sales / Administrator"},
+			}
+		case "ir.model.data.search_read":
+			return []map[string]interface{}{
+				{"module": "base", "name": "group_user", "res_id": 10},
+				{"module": "sales_team", "name": "group_sale_manager", "res_id": 11},
+			}
+		}
+		t.Errorf("unexpected call %s.%s", model, method)
+		return false
+	})
+
+	groups, err := c.CurrentUserGroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Group{
+		{ID: 10, Name: "Internal User", FullName: "User types / Internal User", XMLID: "base.group_user"},
+		{ID: 11, Name: "Administrator", FullName: "Sales / Administrator", XMLID: "sales_team.group_sale_manager"},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("got %+v, want %+v", groups, want)
+	}
+}
+
+func TestUserHasGroup(t *testing.T) {
+	c := userServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		domain := args[5].([]interface{})[0].([]interface{})
+		switch domain[1].([]interface{})[2] {
+		case "group_sale_manager":
+			return []map[string]interface{}{{"res_id": 11}}
+		case "group_system":
+			return []map[string]interface{}{{"res_id": 4}}
+		}
+		return []map[string]interface{}{}
+	})
+	ctx := context.Background()
+
+	for xmlid, want := range map[string]bool{
+		"sales_team.group_sale_manager": true,
+		"base.group_system":             false,
+	} {
+		got, err := c.UserHasGroup(ctx, xmlid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("UserHasGroup(%s) = %v, want %v", xmlid, got, want)
+		}
+	}
+	for _, xmlid := range []string{"base.group_unknown", "group_system"} {
+		if _, err := c.UserHasGroup(ctx, xmlid); err == nil {
+			t.Errorf("UserHasGroup(%s) did not fail", xmlid)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"net/rpc"
+	"strings"
+	"time"
+)
+
+// HedgePolicy configures hedged reads. When a read-only call has not been
+// answered after Delay, the same request is sent to the next endpoint and the
+// first response wins; the slower requests are cancelled. This trims the
+// latency tail on flaky links at the cost of some duplicate reads.
+type HedgePolicy struct {
+	// Endpoints are the base URLs of further servers of the same database,
+	// e.g. other nodes behind a load balancer.
+	Endpoints []string
+	// Delay is the latency threshold after which the next endpoint is tried.
+	Delay time.Duration
+}
+
+// WithHedging enables hedged requests for read-only methods such as
+// search_read. Writes are always sent to the primary URL only.
+func WithHedging(policy HedgePolicy) Option {
+	return func(c *Connector) {
+		c.hedge = policy
+	}
+}
+
+// hedgedPost sends body to the primary URL and, after each hedge delay
+// without an answer, to the next hedge endpoint. It returns the first answer
+// of a server, a fault included, or the first error once all requests
+// failed.
+func (c *Connector) hedgedPost(ctx context.Context, service string, body []byte) (response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type answer struct {
+		resp response
+		err  error
+	}
+	endpoints := append([]string{c.URL}, c.hedge.Endpoints...)
+	answers := make(chan answer, len(endpoints))
+	send := func(url string) {
+		resp, err := c.post(ctx, url, service, body, nil)
+		answers <- answer{resp, err}
+	}
+
+	go send(endpoints[0])
+	inFlight, next := 1, 1
+
+	timer := time.NewTimer(c.hedge.Delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for inFlight > 0 {
+		select {
+		case a := <-answers:
+			inFlight--
+			if a.err == nil || !hedgeable(a.err) {
+				return a.resp, a.err
+			}
+			if firstErr == nil {
+				firstErr = a.err
+			}
+			// A failed request is hedged right away instead of waiting.
+			if inFlight == 0 && next < len(endpoints) && ctx.Err() == nil {
+				go send(endpoints[next])
+				inFlight, next = inFlight+1, next+1
+			}
+		case <-timer.C:
+			if next < len(endpoints) {
+				go send(endpoints[next])
+				inFlight, next = inFlight+1, next+1
+				timer.Reset(c.hedge.Delay)
+			}
+		}
+	}
+	return nil, firstErr
+}
+
+// hedgeable reports whether a failed request may succeed on another
+// endpoint: the server was not reached or failed with a 5xx status. Faults
+// and other statuses answer the request the same on every endpoint.
+func hedgeable(err error) bool {
+	var fault *Error
+	if errors.As(err, &fault) {
+		return false
+	}
+	var serverErr rpc.ServerError
+	if errors.As(err, &serverErr) {
+		status, ok := strings.CutPrefix(string(serverErr), "request error: bad status code - ")
+		return ok && strings.HasPrefix(status, "5")
+	}
+	return true
+}
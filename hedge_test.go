@@ -0,0 +1,145 @@
+package odoo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestHedging(t *testing.T) {
+	handle := func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		if args[4] == "create" {
+			return 7
+		}
+		return []map[string]interface{}{{"id": 1, "name": "Azure Interior"}}
+	}
+
+	// The primary answers authentication and writes, but hangs on reads until
+	// the request is cancelled
+	cancelled := make(chan struct{}, 1)
+	var primaryReads, hedgeCalls atomic.Int32
+	primaryHandler := xmlRPCHandler(t, handle)
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, []byte(">search_read<")) {
+			primaryReads.Add(1)
+			<-r.Context().Done()
+			cancelled <- struct{}{}
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		primaryHandler.ServeHTTP(w, r)
+	}))
+	defer primary.Close()
+
+	hedgeHandler := xmlRPCHandler(t, handle)
+	hedge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hedgeCalls.Add(1)
+		hedgeHandler.ServeHTTP(w, r)
+	}))
+	defer hedge.Close()
+
+	c, err := NewConnector(primary.URL, "admin", "key", "db",
+		WithHedging(HedgePolicy{Endpoints: []string{hedge.URL}, Delay: 10 * time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := c.SearchReadRecords("res.partner", SearchReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0]["name"] != "Azure Interior" {
+		t.Errorf("got %v", records)
+	}
+	if primaryReads.Load() != 1 || hedgeCalls.Load() != 1 {
+		t.Errorf("%d primary reads and %d hedged calls, want 1 each", primaryReads.Load(), hedgeCalls.Load())
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Error("primary request was not cancelled")
+	}
+
+	// Writes are never hedged, however slow
+	hedgeCalls.Store(0)
+	if _, err := c.CreateRecord("res.partner", map[string]interface{}{"name": "Gemini"}); err != nil {
+		t.Fatal(err)
+	}
+	if hedgeCalls.Load() != 0 {
+		t.Errorf("write sent to %d hedge endpoints", hedgeCalls.Load())
+	}
+}
+
+func TestHedgingAfterFailure(t *testing.T) {
+	var primaryCalls atomic.Int32
+	primaryHandler := xmlRPCHandler(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		primaryCalls.Add(1)
+		return httpStatus(http.StatusBadGateway)
+	})
+	primary := httptest.NewServer(primaryHandler)
+	defer primary.Close()
+	hedge := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		return []map[string]interface{}{{"id": 1}}
+	})
+
+	// A failed request is hedged right away rather than after the delay
+	c, err := NewConnector(primary.URL, "admin", "key", "db",
+		WithHedging(HedgePolicy{Endpoints: []string{hedge.URL}, Delay: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := c.SearchReadRecords("res.partner", SearchReadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if primaryCalls.Load() != 1 || time.Since(start) > time.Minute {
+		t.Errorf("%d primary calls in %v", primaryCalls.Load(), time.Since(start))
+	}
+}
+
+func TestHedgingReturnsAnswers(t *testing.T) {
+	fault := xmlrpc.FaultError{Code: 4, String: "odoo.exceptions.AccessError: not allowed"}
+	for _, answer := range []interface{}{fault, httpStatus(http.StatusForbidden)} {
+		primary := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+			if method == "authenticate" {
+				return 2
+			}
+			return answer
+		})
+		var hedgeCalls atomic.Int32
+		hedge := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+			hedgeCalls.Add(1)
+			return []map[string]interface{}{{"id": 1}}
+		})
+
+		// Faults and 4xx statuses are the server's answer, not failures of
+		// the endpoint
+		c, err := NewConnector(primary.URL, "admin", "key", "db",
+			WithHedging(HedgePolicy{Endpoints: []string{hedge.URL}, Delay: time.Hour}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.SearchReadRecords("res.partner", SearchReadOptions{}); err == nil {
+			t.Errorf("%v: no error", answer)
+		}
+		if hedgeCalls.Load() != 0 {
+			t.Errorf("%v: hedged to %d endpoints", answer, hedgeCalls.Load())
+		}
+	}
+}
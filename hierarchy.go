@@ -0,0 +1,159 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DescendantsOf returns the IDs of all records below id in the hierarchy of
+// model (e.g. product.category), resolved server-side with a single child_of
+// search. The record itself is not included.
+func (c *Connector) DescendantsOf(ctx context.Context, model string, id int64) ([]int64, error) {
+	ids, err := c.searchHierarchy(ctx, model, "child_of", id)
+	if err != nil {
+		return nil, fmt.Errorf("descendants lookup failed for model %s with id %d: %w", model, id, err)
+	}
+	return ids, nil
+}
+
+// AncestorsOf returns the IDs of all records above id in the hierarchy of
+// model, resolved server-side with a single parent_of search. The record
+// itself is not included.
+func (c *Connector) AncestorsOf(ctx context.Context, model string, id int64) ([]int64, error) {
+	ids, err := c.searchHierarchy(ctx, model, "parent_of", id)
+	if err != nil {
+		return nil, fmt.Errorf("ancestors lookup failed for model %s with id %d: %w", model, id, err)
+	}
+	return ids, nil
+}
+
+func (c *Connector) searchHierarchy(ctx context.Context, model, operator string, id int64) ([]int64, error) {
+	var ids []int64
+	domain := []interface{}{
+		[]interface{}{"id", operator, []int64{id}},
+		[]interface{}{"id", "!=", id},
+	}
+	if err := c.execute(ctx, model, "search", []interface{}{domain}, nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ParentPaths reads the parent_path field of the given records in one call and
+// returns the parsed paths keyed by record ID. Each path lists the ancestors
+// root first and ends with the record itself. Only models with a parent store
+// (_parent_store = True) have this field.
+func (c *Connector) ParentPaths(ctx context.Context, model string, ids []int64) (map[int64][]int64, error) {
+	var records []map[string]interface{}
+	err := c.execute(ctx, model, "read", []interface{}{ids}, map[string]interface{}{
+		"fields": []string{"parent_path"},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("parent_path read failed for model %s: %w", model, err)
+	}
+
+	paths := make(map[int64][]int64, len(records))
+	for _, record := range records {
+		id, _ := toInt64(record["id"])
+		path, _ := record["parent_path"].(string)
+		parsed, err := ParseParentPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent_path for model %s with id %d: %w", model, id, err)
+		}
+		paths[id] = parsed
+	}
+	return paths, nil
+}
+
+// ParseParentPath splits a parent_path value such as "1/5/12/" into its record
+// IDs, root first. An empty path yields an empty slice.
+func ParseParentPath(path string) ([]int64, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	ids := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed parent_path %q: %w", path, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// IsDescendantPath reports whether the record with the given parent_path lies
+// below ancestorID. A record is not considered its own descendant.
+func IsDescendantPath(path string, ancestorID int64) bool {
+	ids, err := ParseParentPath(path)
+	if err != nil || len(ids) == 0 {
+		return false
+	}
+	for _, id := range ids[:len(ids)-1] {
+		if id == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetParentChain returns the record id followed by each of its ancestors up to
+// the root, as linked by parentField (e.g. category breadcrumbs read from the
+// end). Every record carries its id, display_name and parentField.
+//
+// When parentField is empty or "parent_id" the whole chain is fetched with a
+// single parent_of search_read; other fields are walked one level per read.
+func (c *Connector) GetParentChain(ctx context.Context, model string, id int64, parentField string) ([]map[string]interface{}, error) {
+	if parentField == "" {
+		parentField = "parent_id"
+	}
+	fields := []string{"id", "display_name", parentField}
+
+	byID := make(map[int64]map[string]interface{})
+	if parentField == "parent_id" {
+		var records []map[string]interface{}
+		domain := []interface{}{[]interface{}{"id", "parent_of", []int64{id}}}
+		err := c.execute(ctx, model, "search_read", []interface{}{domain}, map[string]interface{}{
+			"fields": fields,
+		}, &records)
+		if err != nil {
+			return nil, fmt.Errorf("parent chain lookup failed for model %s with id %d: %w", model, id, err)
+		}
+		for _, record := range records {
+			if recordID, ok := toInt64(record["id"]); ok {
+				byID[recordID] = record
+			}
+		}
+	}
+
+	chain := make([]map[string]interface{}, 0, len(byID))
+	seen := make(map[int64]bool)
+	for next := id; next != 0; {
+		if seen[next] {
+			return nil, fmt.Errorf("parent chain lookup failed for model %s with id %d: cycle detected at id %d", model, id, next)
+		}
+		seen[next] = true
+
+		record, ok := byID[next]
+		if !ok {
+			var records []map[string]interface{}
+			err := c.execute(ctx, model, "read", []interface{}{[]int64{next}}, map[string]interface{}{
+				"fields": fields,
+			}, &records)
+			if err != nil {
+				return nil, fmt.Errorf("parent chain lookup failed for model %s with id %d: %w", model, id, err)
+			}
+			if len(records) == 0 {
+				return nil, fmt.Errorf("parent chain lookup failed for model %s with id %d: record %d not found", model, id, next)
+			}
+			record = records[0]
+		}
+
+		chain = append(chain, record)
+		next, _ = many2oneID(record[parentField])
+	}
+	return chain, nil
+}
@@ -0,0 +1,158 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestHierarchySearch(t *testing.T) {
+	var domain interface{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		domain = args[5].([]interface{})[0]
+		return []int64{5, 12}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		operator string
+		lookup   func(context.Context, string, int64) ([]int64, error)
+	}{
+		{"child_of", c.DescendantsOf},
+		{"parent_of", c.AncestorsOf},
+	} {
+		ids, err := tc.lookup(ctx, "product.category", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids, []int64{5, 12}) {
+			t.Errorf("%s: got %v", tc.operator, ids)
+		}
+		want := []interface{}{
+			[]interface{}{"id", tc.operator, []interface{}{int64(1)}},
+			[]interface{}{"id", "!=", int64(1)},
+		}
+		if !reflect.DeepEqual(domain, want) {
+			t.Errorf("%s: domain %v, want %v", tc.operator, domain, want)
+		}
+	}
+}
+
+func TestParentPaths(t *testing.T) {
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		return []map[string]interface{}{
+			{"id": 12, "parent_path": "1/5/12/"},
+			{"id": 1, "parent_path": "1/"},
+		}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := c.ParentPaths(context.Background(), "product.category", []int64{1, 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int64][]int64{1: {1}, 12: {1, 5, 12}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got %v, want %v", paths, want)
+	}
+}
+
+func TestParseParentPath(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want []int64
+		err  bool
+	}{
+		{"1/5/12/", []int64{1, 5, 12}, false},
+		{"7/", []int64{7}, false},
+		{"", []int64{}, false},
+		{"1/x/", nil, true},
+	} {
+		got, err := ParseParentPath(tc.path)
+		if (err != nil) != tc.err || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseParentPath(%q) = %v, %v", tc.path, got, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		path     string
+		ancestor int64
+		want     bool
+	}{
+		{"1/5/12/", 1, true},
+		{"1/5/12/", 5, true},
+		{"1/5/12/", 12, false},
+		{"1/5/12/", 3, false},
+		{"", 1, false},
+	} {
+		if got := IsDescendantPath(tc.path, tc.ancestor); got != tc.want {
+			t.Errorf("IsDescendantPath(%q, %d) = %v", tc.path, tc.ancestor, got)
+		}
+	}
+}
+
+func TestGetParentChain(t *testing.T) {
+	// 12 -> 5 -> 1 via parent_id; 12 -> 7 -> 12 via a looping manager_id
+	records := map[int64]map[string]interface{}{
+		1:  {"id": 1, "display_name": "All", "parent_id": false, "manager_id": false},
+		5:  {"id": 5, "display_name": "All / Saleable", "parent_id": []interface{}{1, "All"}, "manager_id": false},
+		7:  {"id": 7, "display_name": "Boss", "parent_id": false, "manager_id": []interface{}{12, "Office"}},
+		12: {"id": 12, "display_name": "All / Saleable / Office", "parent_id": []interface{}{5, "All / Saleable"}, "manager_id": []interface{}{7, "Boss"}},
+	}
+	var calls []string
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls = append(calls, args[4].(string))
+		switch args[4] {
+		case "search_read":
+			return []map[string]interface{}{records[1], records[5], records[12]}
+		case "read":
+			id := args[5].([]interface{})[0].([]interface{})[0].(int64)
+			return []map[string]interface{}{records[id]}
+		}
+		return false
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	chain, err := c.GetParentChain(ctx, "product.category", 12, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []interface{}
+	for _, record := range chain {
+		names = append(names, record["display_name"])
+	}
+	if want := []interface{}{"All / Saleable / Office", "All / Saleable", "All"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("chain %v, want %v", names, want)
+	}
+	if !reflect.DeepEqual(calls, []string{"search_read"}) {
+		t.Errorf("parent_id chain took calls %v, want one search_read", calls)
+	}
+
+	calls = nil
+	if _, err := c.GetParentChain(ctx, "hr.employee", 12, "manager_id"); err == nil {
+		t.Error("cycle was not detected")
+	}
+	if !reflect.DeepEqual(calls, []string{"read", "read"}) {
+		t.Errorf("manager_id chain took calls %v, want a read per level", calls)
+	}
+}
@@ -0,0 +1,71 @@
+package odoo
+
+import (
+	"context"
+	"time"
+)
+
+// Call describes an execute_kw call observed by a Hook
+type Call struct {
+	Model  string
+	Method string
+}
+
+// Hook observes every execute_kw call of a connector, including each retry,
+// e.g. to record metrics or tracing spans. Hooks must be safe for concurrent
+// use.
+type Hook interface {
+	// BeforeCall runs before the request is sent. The returned context is
+	// used for the request and passed to AfterCall, e.g. to carry a span.
+	BeforeCall(ctx context.Context, call Call) context.Context
+	// AfterCall runs once the response has been decoded, with the duration
+	// of the call and its error, if any
+	AfterCall(ctx context.Context, call Call, duration time.Duration, err error)
+}
+
+// WithHook adds a hook observing every call. Several hooks run in the order
+// they were added, and AfterCall in the reverse order.
+func WithHook(hook Hook) Option {
+	return func(c *Connector) {
+		c.hooks = append(c.hooks, hook)
+	}
+}
+
+// HookFuncs adapts a pair of functions to a Hook; either may be nil
+type HookFuncs struct {
+	Before func(ctx context.Context, call Call) context.Context
+	After  func(ctx context.Context, call Call, duration time.Duration, err error)
+}
+
+// BeforeCall implements Hook
+func (h HookFuncs) BeforeCall(ctx context.Context, call Call) context.Context {
+	if h.Before == nil {
+		return ctx
+	}
+	return h.Before(ctx, call)
+}
+
+// AfterCall implements Hook
+func (h HookFuncs) AfterCall(ctx context.Context, call Call, duration time.Duration, err error) {
+	if h.After != nil {
+		h.After(ctx, call, duration, err)
+	}
+}
+
+// startCall runs the BeforeCall hooks and returns the context for the call
+// and the function to call with its result, which logs it and runs the
+// AfterCall hooks
+func (c *Connector) startCall(ctx context.Context, model, method string) (context.Context, func(error)) {
+	call := Call{Model: model, Method: method}
+	for _, hook := range c.hooks {
+		ctx = hook.BeforeCall(ctx, call)
+	}
+	start := time.Now()
+	return ctx, func(err error) {
+		duration := time.Since(start)
+		c.logCall(ctx, model, method, duration, err)
+		for i := len(c.hooks) - 1; i >= 0; i-- {
+			c.hooks[i].AfterCall(ctx, call, duration, err)
+		}
+	}
+}
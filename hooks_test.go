@@ -0,0 +1,62 @@
+package odoo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+func TestHooks(t *testing.T) {
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		if args[4] == "unlink" {
+			return &jsonError{Code: 200, Message: "Odoo Server Error"}
+		}
+		return 1
+	})
+
+	var order []string
+	var calls []Call
+	var errs []error
+	c, err := NewConnector(srv.URL, "admin", "key", "db",
+		WithProtocol(JSONRPC),
+		WithHook(HookFuncs{
+			Before: func(ctx context.Context, call Call) context.Context {
+				order = append(order, "before 1")
+				return context.WithValue(ctx, spanKey{}, call.Method)
+			},
+			After: func(ctx context.Context, call Call, duration time.Duration, err error) {
+				order = append(order, "after 1")
+				if span := ctx.Value(spanKey{}); span != call.Method {
+					t.Errorf("AfterCall got span %v for %s", span, call.Method)
+				}
+				calls = append(calls, call)
+				errs = append(errs, err)
+			},
+		}),
+		WithHook(HookFuncs{
+			After: func(context.Context, Call, time.Duration, error) { order = append(order, "after 2") },
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.CreateRecord("res.partner", map[string]interface{}{"name": "Azure"})
+	c.DeleteRecord("res.partner", 1)
+
+	want := []Call{{Model: "res.partner", Method: "create"}, {Model: "res.partner", Method: "unlink"}}
+	if len(calls) != 2 || calls[0] != want[0] || calls[1] != want[1] {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	if errs[0] != nil || errs[1] == nil {
+		t.Errorf("errors = %v", errs)
+	}
+	if order[0] != "before 1" || order[1] != "after 2" || order[2] != "after 1" {
+		t.Errorf("hooks ran in order %v", order)
+	}
+}
@@ -0,0 +1,517 @@
+package odoo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lookup modes of relational columns
+const (
+	// LookupName resolves values by exact display name (name_search)
+	LookupName = "name"
+	// LookupXMLID resolves values as external identifiers (module.name)
+	LookupXMLID = "xmlid"
+)
+
+// ImportMapping maps the columns of a CSV file to the fields of a model
+type ImportMapping struct {
+	Model   string          `json:"model"`
+	Columns []ColumnMapping `json:"columns"`
+}
+
+// ColumnMapping maps a CSV column to a field.
+//
+// Converters are applied in order to the cell before it is validated:
+//
+//	trim, upper, lower     whitespace and case
+//	bool                   yes/no, y/n, 1/0, true/false, x/empty to true/false
+//	decimal_comma          "1.234,5" to "1234.5"
+//	date:<layout>          a Go time layout, reformatted to YYYY-MM-DD
+//	datetime:<layout>      a Go time layout, reformatted to YYYY-MM-DD HH:MM:SS
+//
+// Relational fields take database IDs unless Lookup is LookupName or
+// LookupXMLID. Many2many cells may hold several comma-separated values.
+type ColumnMapping struct {
+	Column     string   `json:"column"`
+	Field      string   `json:"field"`
+	Converters []string `json:"converters,omitempty"`
+	Lookup     string   `json:"lookup,omitempty"`
+}
+
+// LoadImportMapping loads an import mapping from a JSON file
+func LoadImportMapping(path string) (*ImportMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var mapping ImportMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+	if mapping.Model == "" {
+		return nil, fmt.Errorf("model is required in mapping")
+	}
+	if len(mapping.Columns) == 0 {
+		return nil, fmt.Errorf("columns are required in mapping")
+	}
+	return &mapping, nil
+}
+
+// ImportOptions configures ImportCSV
+type ImportOptions struct {
+	// DryRun only validates the file
+	DryRun bool
+	// BatchSize is the number of rows per load call (default 1000). Each
+	// batch is loaded in its own transaction.
+	BatchSize int
+	// Context is passed to load, e.g. {"tracking_disable": true}
+	Context map[string]interface{}
+}
+
+// ImportRowError is a problem with a row of the file. Row is the 1-based
+// data row, not counting the header; it is 0 for errors not tied to a row.
+type ImportRowError struct {
+	Row     int
+	Column  string
+	Field   string
+	Message string
+}
+
+func (e ImportRowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("row %d, column %s: %s", e.Row, e.Column, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// ImportReport is the outcome of ImportCSV
+type ImportReport struct {
+	Rows   int
+	IDs    []int64
+	Errors []ImportRowError
+}
+
+// OK reports whether every row was valid and, unless dry-running, imported
+func (r *ImportReport) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// ImportCSV imports the CSV file read from r into mapping.Model. All rows are
+// first validated locally: converters run, values are checked against the
+// field types and selections and relational lookups are resolved. Only when
+// no row has an error (and opts.DryRun is false) are the rows loaded through
+// the model's load method, whose messages are added to the report.
+//
+// The returned error is reserved for failures of the import itself, such as
+// an unreadable file or unknown fields; problems with rows are in the report.
+func (c *Connector) ImportCSV(ctx context.Context, r io.Reader, mapping ImportMapping, opts ImportOptions) (*ImportReport, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("import failed for model %s: %w", mapping.Model, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("import failed for model %s: missing header row", mapping.Model)
+	}
+	header, rows := records[0], records[1:]
+
+	im, err := c.newImporter(ctx, mapping, header)
+	if err != nil {
+		return nil, fmt.Errorf("import failed for model %s: %w", mapping.Model, err)
+	}
+
+	report := &ImportReport{Rows: len(rows)}
+	data := make([][]string, len(rows))
+	for i, row := range rows {
+		values, rowErrs, err := im.convertRow(ctx, i+1, row)
+		if err != nil {
+			return nil, fmt.Errorf("import failed for model %s: %w", mapping.Model, err)
+		}
+		data[i] = values
+		report.Errors = append(report.Errors, rowErrs...)
+	}
+	if opts.DryRun || !report.OK() {
+		return report, nil
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	for start := 0; start < len(data); start += batchSize {
+		end := start + batchSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := im.load(ctx, data[start:end], start, opts.Context, report); err != nil {
+			return report, fmt.Errorf("import failed for model %s: %w", mapping.Model, err)
+		}
+	}
+	return report, nil
+}
+
+// importColumn is a mapped column with the metadata of its field
+type importColumn struct {
+	ColumnMapping
+	index     int
+	fieldType string
+	relation  string
+	required  bool
+	selection map[string]bool
+}
+
+type importer struct {
+	c       *Connector
+	model   string
+	columns []importColumn
+	// lookups caches resolved relational values per relation and lookup
+	lookups map[string]map[string]int64
+}
+
+func (c *Connector) newImporter(ctx context.Context, mapping ImportMapping, header []string) (*importer, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.TrimSpace(name)] = i
+	}
+
+	fields := make([]string, len(mapping.Columns))
+	for i, column := range mapping.Columns {
+		fields[i] = column.Field
+	}
+	var defs map[string]map[string]interface{}
+	err := c.execute(ctx, mapping.Model, "fields_get", []interface{}{fields}, map[string]interface{}{
+		"attributes": []string{"type", "relation", "required", "selection"},
+	}, &defs)
+	if err != nil {
+		return nil, err
+	}
+
+	im := &importer{c: c, model: mapping.Model, lookups: make(map[string]map[string]int64)}
+	for _, column := range mapping.Columns {
+		index, ok := positions[column.Column]
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in file", column.Column)
+		}
+		def, ok := defs[column.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s", column.Field)
+		}
+		for _, converter := range column.Converters {
+			if !validConverter(converter) {
+				return nil, fmt.Errorf("unknown converter %q for column %s", converter, column.Column)
+			}
+		}
+
+		ic := importColumn{ColumnMapping: column, index: index}
+		ic.fieldType, _ = def["type"].(string)
+		ic.relation, _ = def["relation"].(string)
+		ic.required, _ = def["required"].(bool)
+		if ic.fieldType == "one2many" {
+			return nil, fmt.Errorf("one2many field %s cannot be imported from a column", column.Field)
+		}
+		if column.Lookup != "" && column.Lookup != LookupName && column.Lookup != LookupXMLID {
+			return nil, fmt.Errorf("unknown lookup %q for column %s", column.Lookup, column.Column)
+		}
+		if column.Lookup != "" && ic.relation == "" {
+			return nil, fmt.Errorf("lookup on column %s requires a relational field", column.Column)
+		}
+		if options, ok := def["selection"].([]interface{}); ok && ic.fieldType == "selection" {
+			ic.selection = make(map[string]bool, len(options))
+			for _, option := range options {
+				if pair, ok := option.([]interface{}); ok && len(pair) > 0 {
+					ic.selection[fmt.Sprint(pair[0])] = true
+				}
+			}
+		}
+		im.columns = append(im.columns, ic)
+	}
+	return im, nil
+}
+
+// loadFields returns the field paths passed to load; resolved lookups are
+// sent as database IDs
+func (im *importer) loadFields() []string {
+	fields := make([]string, len(im.columns))
+	for i, column := range im.columns {
+		fields[i] = column.Field
+		if column.relation != "" {
+			fields[i] += "/.id"
+		}
+	}
+	return fields
+}
+
+// convertRow validates a row and returns the cells to load. The error is
+// only set when a lookup could not be run.
+func (im *importer) convertRow(ctx context.Context, rowNum int, row []string) ([]string, []ImportRowError, error) {
+	values := make([]string, len(im.columns))
+	var rowErrs []ImportRowError
+	fail := func(column importColumn, format string, args ...interface{}) {
+		rowErrs = append(rowErrs, ImportRowError{
+			Row:     rowNum,
+			Column:  column.Column,
+			Field:   column.Field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, column := range im.columns {
+		var cell string
+		if column.index < len(row) {
+			cell = row[column.index]
+		}
+		var err error
+		for _, converter := range column.Converters {
+			if cell, err = convertCell(converter, cell); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			fail(column, "%v", err)
+			continue
+		}
+
+		if cell == "" {
+			if column.required {
+				fail(column, "value is required")
+			}
+			continue
+		}
+
+		switch {
+		case column.Lookup != "":
+			ids, missing, err := im.resolve(ctx, column, cell)
+			if err != nil {
+				return nil, nil, err
+			}
+			if missing != "" {
+				fail(column, "%s", missing)
+				continue
+			}
+			cell = ids
+		case column.relation != "":
+			for _, id := range splitList(cell) {
+				if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+					fail(column, "invalid ID %q", id)
+				}
+			}
+		default:
+			if msg := checkCell(column, cell); msg != "" {
+				fail(column, "%s", msg)
+			}
+		}
+		values[i] = cell
+	}
+	return values, rowErrs, nil
+}
+
+// checkCell validates a non-relational cell against its field type
+func checkCell(column importColumn, cell string) string {
+	switch column.fieldType {
+	case "integer":
+		if _, err := strconv.ParseInt(cell, 10, 64); err != nil {
+			return fmt.Sprintf("invalid integer %q", cell)
+		}
+	case "float", "monetary":
+		if _, err := strconv.ParseFloat(cell, 64); err != nil {
+			return fmt.Sprintf("invalid number %q", cell)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(cell); err != nil {
+			return fmt.Sprintf("invalid boolean %q", cell)
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, cell); err != nil {
+			return fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", cell)
+		}
+	case "datetime":
+		if _, err := time.Parse(time.DateTime, cell); err != nil {
+			return fmt.Sprintf("invalid datetime %q, expected YYYY-MM-DD HH:MM:SS", cell)
+		}
+	case "selection":
+		if column.selection != nil && !column.selection[cell] {
+			return fmt.Sprintf("%q is not an allowed value", cell)
+		}
+	}
+	return ""
+}
+
+// resolve looks up the comma-separated values of a relational cell and
+// returns their IDs, or a message naming a value that could not be resolved
+func (im *importer) resolve(ctx context.Context, column importColumn, cell string) (string, string, error) {
+	values := []string{cell}
+	if column.fieldType == "many2many" {
+		values = splitList(cell)
+	}
+
+	cacheKey := column.relation + "|" + column.Lookup
+	cache := im.lookups[cacheKey]
+	if cache == nil {
+		cache = make(map[string]int64)
+		im.lookups[cacheKey] = cache
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		id, ok := cache[value]
+		if !ok {
+			var err error
+			if id, err = im.lookup(ctx, column, value); err != nil {
+				return "", "", err
+			}
+			cache[value] = id
+		}
+		switch id {
+		case 0:
+			return "", fmt.Sprintf("%s %q not found", column.relation, value), nil
+		case -1:
+			return "", fmt.Sprintf("%s %q is ambiguous", column.relation, value), nil
+		}
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(ids, ","), "", nil
+}
+
+// lookup resolves a single value; 0 means not found and -1 ambiguous
+func (im *importer) lookup(ctx context.Context, column importColumn, value string) (int64, error) {
+	if column.Lookup == LookupXMLID {
+		id, _, err := im.c.resolveXMLID(ctx, column.relation, value)
+		return id, err
+	}
+
+	var matches []interface{}
+	err := im.c.execute(ctx, column.relation, "name_search", []interface{}{value, []interface{}{}, "=", 2}, nil, &matches)
+	if err != nil {
+		return 0, err
+	}
+	switch len(matches) {
+	case 0:
+		return 0, nil
+	case 1:
+		id, _ := many2oneID(matches[0])
+		return id, nil
+	}
+	return -1, nil
+}
+
+// load sends a batch of converted rows to the model's load method. offset is
+// the index of the batch's first row in the file.
+func (im *importer) load(ctx context.Context, rows [][]string, offset int, loadContext map[string]interface{}, report *ImportReport) error {
+	var result map[string]interface{}
+	err := im.c.execute(ctx, im.model, "load", []interface{}{im.loadFields(), rows}, map[string]interface{}{
+		"context": contextOrEmpty(loadContext),
+	}, &result)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	messages, _ := result["messages"].([]interface{})
+	for _, m := range messages {
+		message, _ := m.(map[string]interface{})
+		if message["type"] != "error" {
+			continue
+		}
+		failed = true
+		rowErr := ImportRowError{Message: fmt.Sprint(message["message"])}
+		if record, ok := toInt64(message["record"]); ok {
+			rowErr.Row = offset + int(record) + 1
+		} else if span, ok := message["rows"].(map[string]interface{}); ok {
+			from, _ := toInt64(span["from"])
+			rowErr.Row = offset + int(from) + 1
+		}
+		if field, ok := message["field"].(string); ok {
+			rowErr.Field = field
+			for _, column := range im.columns {
+				if column.Field == field {
+					rowErr.Column = column.Column
+				}
+			}
+		}
+		report.Errors = append(report.Errors, rowErr)
+	}
+
+	ids, ok := result["ids"].([]interface{})
+	if !ok {
+		// ids is false when the batch was rolled back
+		if !failed {
+			for i := range rows {
+				report.Errors = append(report.Errors, ImportRowError{Row: offset + i + 1, Message: "batch was not imported"})
+			}
+		}
+		return nil
+	}
+	for _, id := range ids {
+		if id, ok := toInt64(id); ok {
+			report.IDs = append(report.IDs, id)
+		}
+	}
+	return nil
+}
+
+// convertCell applies a named converter to a cell
+func convertCell(converter, cell string) (string, error) {
+	name, layout, _ := strings.Cut(converter, ":")
+	switch name {
+	case "trim":
+		return strings.TrimSpace(cell), nil
+	case "upper":
+		return strings.ToUpper(cell), nil
+	case "lower":
+		return strings.ToLower(cell), nil
+	case "bool":
+		switch strings.ToLower(strings.TrimSpace(cell)) {
+		case "1", "y", "yes", "true", "x":
+			return "true", nil
+		case "", "0", "n", "no", "false":
+			return "false", nil
+		}
+		return "", fmt.Errorf("invalid boolean %q", cell)
+	case "decimal_comma":
+		return strings.ReplaceAll(strings.ReplaceAll(cell, ".", ""), ",", "."), nil
+	case "date", "datetime":
+		if cell == "" {
+			return "", nil
+		}
+		t, err := time.Parse(layout, cell)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s %q, expected layout %s", name, cell, layout)
+		}
+		if name == "date" {
+			return t.Format(time.DateOnly), nil
+		}
+		return t.UTC().Format(time.DateTime), nil
+	}
+	return "", fmt.Errorf("unknown converter %q", converter)
+}
+
+// validConverter reports whether converter names a known converter
+func validConverter(converter string) bool {
+	name, layout, hasLayout := strings.Cut(converter, ":")
+	switch name {
+	case "trim", "upper", "lower", "bool", "decimal_comma":
+		return !hasLayout
+	case "date", "datetime":
+		return layout != ""
+	}
+	return false
+}
+
+// splitList splits a comma-separated cell into trimmed values
+func splitList(cell string) []string {
+	parts := strings.Split(cell, ",")
+	values := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,31 @@
+package odoo
+
+import "testing"
+
+func TestConvertCell(t *testing.T) {
+	tests := []struct {
+		converter, cell, want string
+		wantErr               bool
+	}{
+		{converter: "trim", cell: "  Azure  ", want: "Azure"},
+		{converter: "upper", cell: "be", want: "BE"},
+		{converter: "bool", cell: "Yes", want: "true"},
+		{converter: "bool", cell: "", want: "false"},
+		{converter: "bool", cell: "maybe", wantErr: true},
+		{converter: "decimal_comma", cell: "1.234,5", want: "1234.5"},
+		{converter: "date:02.01.2006", cell: "31.12.2024", want: "2024-12-31"},
+		{converter: "date:02.01.2006", cell: "2024-12-31", wantErr: true},
+		{converter: "datetime:2006-01-02T15:04:05Z07:00", cell: "2024-12-31T10:00:00+02:00", want: "2024-12-31 08:00:00"},
+		{converter: "reverse", cell: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := convertCell(tt.converter, tt.cell)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("convertCell(%q, %q) error = %v, wantErr %v", tt.converter, tt.cell, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("convertCell(%q, %q) = %q, want %q", tt.converter, tt.cell, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package odoo
+
+import "context"
+
+// The interfaces below split the Connector API by concern, so applications
+// can depend on exactly the methods they use and fake them in tests:
+//
+//	type InvoiceService struct {
+//		odoo odoo.ReadWriter
+//	}
+//
+// *Connector implements all of them.
+
+// Reader searches and reads records
+type Reader interface {
+	SearchReadRecords(model string, opts SearchReadOptions) ([]map[string]interface{}, error)
+	SearchReadRecordsContext(ctx context.Context, model string, opts SearchReadOptions) ([]map[string]interface{}, error)
+	SearchReadStream(ctx context.Context, model string, opts SearchReadOptions, fn func(record map[string]interface{}) error) error
+}
+
+// Writer creates, updates and deletes records
+type Writer interface {
+	CreateRecord(model string, values map[string]interface{}) (int64, error)
+	CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error)
+	UpdateRecord(model string, id int64, values map[string]interface{}) error
+	UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error
+	DeleteRecord(model string, id int64) error
+	DeleteRecordContext(ctx context.Context, model string, id int64) error
+}
+
+// ReadWriter reads and writes records
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+// Executor calls model methods
+type Executor interface {
+	ExecuteMethod(model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error)
+	ExecuteMethodContext(ctx context.Context, model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error)
+}
+
+// Introspector describes the server: the fields of models and the session
+type Introspector interface {
+	FieldsGet(ctx context.Context, model string, attributes ...string) (map[string]map[string]interface{}, error)
+	SessionInfo(ctx context.Context) (*SessionInfo, error)
+	VerifySchema(ctx context.Context, models ...interface{}) error
+}
+
+var (
+	_ ReadWriter   = (*Connector)(nil)
+	_ Executor     = (*Connector)(nil)
+	_ Introspector = (*Connector)(nil)
+)
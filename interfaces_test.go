@@ -0,0 +1,116 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestInterfacesCallConnector(t *testing.T) {
+	var calls []string
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		model, method := args[3].(string), args[4].(string)
+		calls = append(calls, model+"."+method)
+		switch method {
+		case "search_read":
+			return []map[string]interface{}{{"id": 1, "name": "Azure"}}
+		case "create":
+			return 7
+		case "fields_get":
+			return map[string]interface{}{"name": map[string]interface{}{"type": "char"}}
+		case "name_search":
+			return []interface{}{[]interface{}{1, "Azure"}}
+		}
+		return true
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	var rw ReadWriter = c
+	records, err := rw.SearchReadRecordsContext(ctx, "res.partner", SearchReadOptions{Fields: []string{"name"}})
+	if err != nil || len(records) != 1 || records[0]["name"] != "Azure" {
+		t.Fatalf("SearchReadRecordsContext: %v, %v", records, err)
+	}
+	var streamed int
+	err = rw.SearchReadStream(ctx, "res.partner", SearchReadOptions{}, func(map[string]interface{}) error {
+		streamed++
+		return nil
+	})
+	if err != nil || streamed != 1 {
+		t.Fatalf("SearchReadStream: %d records, %v", streamed, err)
+	}
+	id, err := rw.CreateRecordContext(ctx, "res.partner", map[string]interface{}{"name": "Deco"})
+	if err != nil || id != 7 {
+		t.Fatalf("CreateRecordContext: %d, %v", id, err)
+	}
+	if err := rw.UpdateRecordContext(ctx, "res.partner", id, map[string]interface{}{"name": "Deco Addict"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rw.DeleteRecordContext(ctx, "res.partner", id); err != nil {
+		t.Fatal(err)
+	}
+
+	var executor Executor = c
+	result, err := executor.ExecuteMethodContext(ctx, "res.partner", "name_search", []interface{}{"Azure"}, nil)
+	if err != nil || !reflect.DeepEqual(result, []interface{}{[]interface{}{int64(1), "Azure"}}) {
+		t.Fatalf("ExecuteMethodContext: %v, %v", result, err)
+	}
+
+	var introspector Introspector = c
+	fields, err := introspector.FieldsGet(ctx, "res.partner", "type")
+	if err != nil || fields["name"]["type"] != "char" {
+		t.Fatalf("FieldsGet: %v, %v", fields, err)
+	}
+
+	want := []string{
+		"res.partner.search_read",
+		"res.partner.search_read",
+		"res.partner.create",
+		"res.partner.write",
+		"res.partner.unlink",
+		"res.partner.name_search",
+		"res.partner.fields_get",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+}
+
+// partnerNames depends on Reader only, the way applications are meant to
+func partnerNames(r Reader) ([]string, error) {
+	records, err := r.SearchReadRecords("res.partner", SearchReadOptions{Fields: []string{"name"}})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, record := range records {
+		name, _ := record["name"].(string)
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+type fakeReader struct {
+	Reader
+	records []map[string]interface{}
+}
+
+func (f fakeReader) SearchReadRecords(model string, opts SearchReadOptions) ([]map[string]interface{}, error) {
+	return f.records, nil
+}
+
+func TestReaderCanBeFaked(t *testing.T) {
+	names, err := partnerNames(fakeReader{records: []map[string]interface{}{{"name": "Azure"}, {"name": "Deco"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"Azure", "Deco"}) {
+		t.Errorf("got %v", names)
+	}
+}
@@ -0,0 +1,77 @@
+// Package errkind sorts the errors of the connector into the kinds of
+// failure the REST and gRPC gateways answer with, so both report a failure
+// the same way.
+package errkind
+
+import (
+	"context"
+	"errors"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// Kind is a kind of failure
+type Kind int
+
+const (
+	// Unknown is any other failure of the server or of the connection
+	Unknown Kind = iota
+	// Canceled is a call cancelled by its context
+	Canceled
+	// DeadlineExceeded is a call that ran past the deadline of its context
+	DeadlineExceeded
+	// Invalid is a payload rejected by client-side validation
+	Invalid
+	// PermissionDenied is a rejected login or a missing access right
+	PermissionDenied
+	// NotFound is a record that does not exist or has been deleted
+	NotFound
+	// Rejected is an error meant for the user, such as a violated
+	// constraint, or an invalid argument of the call
+	Rejected
+	// RateLimited is a call rejected by a rate limit
+	RateLimited
+	// Unavailable is a server in maintenance, a failing reverse proxy or a
+	// network failure
+	Unavailable
+	// Conflict is a concurrent update of the same records
+	Conflict
+	// Unauthenticated is a rejected request without a server fault, e.g.
+	// by a reverse proxy
+	Unauthenticated
+)
+
+// Of returns the kind of err
+func Of(err error) Kind {
+	var validation *odoo.ValidationError
+	var fault *odoo.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	case errors.As(err, &validation):
+		return Invalid
+	case odoo.IsAccessError(err), odoo.IsAccessDenied(err):
+		return PermissionDenied
+	case odoo.IsMissingRecord(err):
+		return NotFound
+	case odoo.IsUserError(err):
+		return Rejected
+	case errors.As(err, &fault) && fault.ExceptionType == "ValueError":
+		// Odoo raises ValueError for invalid domains and field names
+		return Rejected
+	}
+
+	switch odoo.ClassifyError(err) {
+	case odoo.ErrorClassRateLimited:
+		return RateLimited
+	case odoo.ErrorClassUnavailable, odoo.ErrorClassGateway, odoo.ErrorClassNetwork:
+		return Unavailable
+	case odoo.ErrorClassSerialization:
+		return Conflict
+	case odoo.ErrorClassAuthentication:
+		return Unauthenticated
+	}
+	return Unknown
+}
@@ -0,0 +1,42 @@
+package errkind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+func TestOf(t *testing.T) {
+	fault := func(code int, typ, message string) error {
+		return fmt.Errorf("update failed for model res.partner with id 5: %w", &odoo.Error{FaultCode: code, ExceptionType: typ, Message: message})
+	}
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"canceled", fmt.Errorf("search_read failed: %w", context.Canceled), Canceled},
+		{"deadline", context.DeadlineExceeded, DeadlineExceeded},
+		{"client-side validation", &odoo.ValidationError{Model: "res.partner", Method: "create"}, Invalid},
+		{"access error", fault(4, "odoo.exceptions.AccessError", "not allowed"), PermissionDenied},
+		{"access denied", fault(3, "odoo.exceptions.AccessDenied", "Access Denied"), PermissionDenied},
+		{"missing record", fault(2, "odoo.exceptions.MissingError", "Record does not exist or has been deleted."), NotFound},
+		{"user error", fault(2, "odoo.exceptions.UserError", "You cannot delete a posted journal entry."), Rejected},
+		{"value error", fault(1, "ValueError", "Invalid field 'nam' on model 'res.partner'"), Rejected},
+		{"server error", fault(1, "KeyError", "'name'"), Unknown},
+		{"other error", errors.New("unexpected response"), Unknown},
+
+		// The text of records and messages does not decide the kind
+		{"user error mentioning access", fault(2, "odoo.exceptions.UserError", "Partner 'AccessError Ltd' is archived"), Rejected},
+		{"server error mentioning a missing record", fault(1, "KeyError", "no record updated"), Unknown},
+		{"plain error mentioning a user error", errors.New("ValidationError: UserError"), Unknown},
+	}
+	for _, tt := range tests {
+		if got := Of(tt.err); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
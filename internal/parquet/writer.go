@@ -0,0 +1,381 @@
+// Package parquet is a minimal Apache Parquet writer: flat schemas of
+// optional columns, PLAIN encoding, one data page per column chunk and
+// optional GZIP compression. It covers what the exporters need without
+// pulling in a full Parquet implementation.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Kind is the type of a column
+type Kind int
+
+const (
+	Boolean Kind = iota
+	Int64
+	Double
+	String
+	// Date holds days since the Unix epoch (int32).
+	Date
+	// Timestamp holds milliseconds since the Unix epoch in UTC (int64).
+	Timestamp
+)
+
+// Column describes a column of the file
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Codec is a page compression codec
+type Codec int32
+
+const (
+	Uncompressed Codec = 0
+	Gzip         Codec = 2
+)
+
+// Parquet physical types, converted types and other enums used in metadata
+const (
+	typeBoolean   = 0
+	typeInt32     = 1
+	typeInt64     = 2
+	typeDouble    = 5
+	typeByteArray = 6
+
+	convertedUTF8            = 0
+	convertedDate            = 6
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageData = 0
+)
+
+const magic = "PAR1"
+
+// Writer writes rows to a Parquet file. Rows are buffered and written as a
+// row group every RowGroupSize rows and on Close.
+type Writer struct {
+	w       io.Writer
+	offset  int64
+	columns []Column
+	codec   Codec
+
+	rowGroupSize int
+	rows         [][]interface{}
+	rowGroups    []rowGroup
+	numRows      int64
+	err          error
+}
+
+type rowGroup struct {
+	numRows   int64
+	totalSize int64
+	chunks    []columnChunk
+}
+
+type columnChunk struct {
+	offset           int64
+	numValues        int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// NewWriter writes the file header to w and returns a writer for the columns
+func NewWriter(w io.Writer, columns []Column, codec Codec, rowGroupSize int) (*Writer, error) {
+	if rowGroupSize <= 0 {
+		rowGroupSize = 10000
+	}
+	pw := &Writer{w: w, columns: columns, codec: codec, rowGroupSize: rowGroupSize}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write adds a row. Values are matched to the columns by position; nil is a
+// null value. Accepted Go types: bool for Boolean, int64 for Int64 and
+// Timestamp, int32 for Date, float64 for Double and string for String.
+func (pw *Writer) Write(row []interface{}) error {
+	if pw.err != nil {
+		return pw.err
+	}
+	if len(row) != len(pw.columns) {
+		return fmt.Errorf("parquet: row has %d values, want %d", len(row), len(pw.columns))
+	}
+	pw.rows = append(pw.rows, row)
+	if len(pw.rows) >= pw.rowGroupSize {
+		return pw.flush()
+	}
+	return nil
+}
+
+// Close flushes the buffered rows and writes the footer. It does not close
+// the underlying writer.
+func (pw *Writer) Close() error {
+	if err := pw.flush(); err != nil {
+		return err
+	}
+
+	footer := pw.footer()
+	if err := pw.write(footer); err != nil {
+		return err
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if err := pw.write(length[:]); err != nil {
+		return err
+	}
+	return pw.write([]byte(magic))
+}
+
+func (pw *Writer) write(p []byte) error {
+	if pw.err != nil {
+		return pw.err
+	}
+	n, err := pw.w.Write(p)
+	pw.offset += int64(n)
+	pw.err = err
+	return err
+}
+
+// flush writes the buffered rows as a row group
+func (pw *Writer) flush() error {
+	if len(pw.rows) == 0 {
+		return pw.err
+	}
+
+	group := rowGroup{numRows: int64(len(pw.rows))}
+	for i, column := range pw.columns {
+		chunk, err := pw.writeColumn(i, column)
+		if err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.totalSize += chunk.uncompressedSize
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.numRows += group.numRows
+	pw.rows = pw.rows[:0]
+	return nil
+}
+
+func (pw *Writer) writeColumn(index int, column Column) (columnChunk, error) {
+	var defLevels bytes.Buffer
+	var values bytes.Buffer
+	levels := make([]bool, len(pw.rows))
+	var bits []bool
+
+	for r, row := range pw.rows {
+		v := row[index]
+		if v == nil {
+			continue
+		}
+		levels[r] = true
+		if err := plainEncode(&values, column.Kind, v, &bits); err != nil {
+			return columnChunk{}, fmt.Errorf("parquet: column %s: %w", column.Name, err)
+		}
+	}
+	if column.Kind == Boolean {
+		values.Write(packBits(bits))
+	}
+
+	encodeLevels(&defLevels, levels)
+
+	var page bytes.Buffer
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(defLevels.Len()))
+	page.Write(length[:])
+	page.Write(defLevels.Bytes())
+	page.Write(values.Bytes())
+
+	uncompressedSize := page.Len()
+	data := page.Bytes()
+	if pw.codec == Gzip {
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		if _, err := zw.Write(data); err != nil {
+			return columnChunk{}, err
+		}
+		if err := zw.Close(); err != nil {
+			return columnChunk{}, err
+		}
+		data = compressed.Bytes()
+	}
+
+	var header thriftWriter
+	header.structBegin()
+	header.i32(1, pageData)
+	header.i32(2, int32(uncompressedSize))
+	header.i32(3, int32(len(data)))
+	header.structField(5)
+	header.i32(1, int32(len(pw.rows)))
+	header.i32(2, encodingPlain)
+	header.i32(3, encodingRLE)
+	header.i32(4, encodingRLE)
+	header.structEnd()
+	header.structEnd()
+
+	chunk := columnChunk{
+		offset:           pw.offset,
+		numValues:        int64(len(pw.rows)),
+		uncompressedSize: int64(header.buf.Len() + uncompressedSize),
+		compressedSize:   int64(header.buf.Len() + len(data)),
+	}
+	if err := pw.write(header.buf.Bytes()); err != nil {
+		return columnChunk{}, err
+	}
+	if err := pw.write(data); err != nil {
+		return columnChunk{}, err
+	}
+	return chunk, nil
+}
+
+func plainEncode(buf *bytes.Buffer, kind Kind, v interface{}, bits *[]bool) error {
+	var scratch [8]byte
+	switch kind {
+	case Boolean:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		*bits = append(*bits, b)
+	case Int64, Timestamp:
+		i, ok := v.(int64)
+		if !ok {
+			return fmt.Errorf("expected int64, got %T", v)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], uint64(i))
+		buf.Write(scratch[:8])
+	case Date:
+		i, ok := v.(int32)
+		if !ok {
+			return fmt.Errorf("expected int32, got %T", v)
+		}
+		binary.LittleEndian.PutUint32(scratch[:], uint32(i))
+		buf.Write(scratch[:4])
+	case Double:
+		f, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("expected float64, got %T", v)
+		}
+		binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(f))
+		buf.Write(scratch[:8])
+	case String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", v)
+		}
+		binary.LittleEndian.PutUint32(scratch[:], uint32(len(s)))
+		buf.Write(scratch[:4])
+		buf.WriteString(s)
+	}
+	return nil
+}
+
+// packBits packs booleans LSB first, as used by PLAIN booleans and
+// bit-packed runs
+func packBits(bits []bool) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return packed
+}
+
+// encodeLevels writes definition levels of bit width 1 as a single
+// bit-packed run of the RLE/bit-packing hybrid encoding
+func encodeLevels(buf *bytes.Buffer, levels []bool) {
+	groups := (len(levels) + 7) / 8
+	buf.Write(binary.AppendUvarint(nil, uint64(groups)<<1|1))
+	buf.Write(packBits(levels))
+}
+
+func (pw *Writer) footer() []byte {
+	var t thriftWriter
+	t.structBegin()
+	t.i32(1, 1)
+
+	t.listHeader(2, thriftStruct, len(pw.columns)+1)
+	t.structBegin()
+	t.i32(3, repetitionRequired)
+	t.str(4, "schema")
+	t.i32(5, int32(len(pw.columns)))
+	t.structEnd()
+	for _, column := range pw.columns {
+		physical, converted := column.types()
+		t.structBegin()
+		t.i32(1, physical)
+		t.i32(3, repetitionOptional)
+		t.str(4, column.Name)
+		if converted >= 0 {
+			t.i32(6, converted)
+		}
+		t.structEnd()
+	}
+
+	t.i64(3, pw.numRows)
+
+	t.listHeader(4, thriftStruct, len(pw.rowGroups))
+	for _, group := range pw.rowGroups {
+		t.structBegin()
+		t.listHeader(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			physical, _ := pw.columns[i].types()
+			t.structBegin()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, physical)
+			t.i32List(2, []int32{encodingPlain, encodingRLE})
+			t.strList(3, []string{pw.columns[i].Name})
+			t.i32(4, int32(pw.codec))
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.uncompressedSize)
+			t.i64(7, chunk.compressedSize)
+			t.i64(9, chunk.offset)
+			t.structEnd()
+			t.structEnd()
+		}
+		t.i64(2, group.totalSize)
+		t.i64(3, group.numRows)
+		t.structEnd()
+	}
+
+	t.str(6, "go-odoo-connector")
+	t.structEnd()
+	return t.buf.Bytes()
+}
+
+// types returns the physical and converted type of the column; -1 means no
+// converted type
+func (c Column) types() (int32, int32) {
+	switch c.Kind {
+	case Boolean:
+		return typeBoolean, -1
+	case Int64:
+		return typeInt64, -1
+	case Double:
+		return typeDouble, -1
+	case String:
+		return typeByteArray, convertedUTF8
+	case Date:
+		return typeInt32, convertedDate
+	case Timestamp:
+		return typeInt64, convertedTimestampMillis
+	}
+	return typeByteArray, -1
+}
@@ -0,0 +1,146 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// thriftReader decodes Thrift compact structs into maps keyed by field id,
+// enough to check the metadata written by Writer
+type thriftReader struct {
+	b []byte
+	t *testing.T
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.t.Fatal("invalid varint")
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *thriftReader) varint() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftBoolTrue:
+		return true
+	case thriftBoolFalse:
+		return false
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := r.uvarint()
+		s := string(r.b[:n])
+		r.b = r.b[n:]
+		return s
+	case thriftList:
+		header := r.b[0]
+		r.b = r.b[1:]
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0F)
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	}
+	r.t.Fatalf("unsupported thrift type %d", typ)
+	return nil
+}
+
+func (r *thriftReader) structValue() map[int64]interface{} {
+	fields := make(map[int64]interface{})
+	var id int64
+	for {
+		header := r.b[0]
+		r.b = r.b[1:]
+		if header == 0 {
+			return fields
+		}
+		if delta := int64(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = r.varint()
+		}
+		fields[id] = r.value(header & 0x0F)
+	}
+}
+
+func TestWriterMetadata(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Kind: Int64},
+		{Name: "name", Kind: String},
+		{Name: "active", Kind: Boolean},
+		{Name: "date", Kind: Date},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns, Uncompressed, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]interface{}{
+		{int64(1), "a", true, int32(19000)},
+		{int64(2), nil, false, nil},
+		{int64(3), "c", true, int32(19002)},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	if string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		t.Fatal("missing magic bytes")
+	}
+	length := binary.LittleEndian.Uint32(data[len(data)-8:])
+	footer := data[len(data)-8-int(length) : len(data)-8]
+	meta := (&thriftReader{b: footer, t: t}).structValue()
+
+	if meta[3] != int64(3) {
+		t.Errorf("num_rows = %v, want 3", meta[3])
+	}
+	schema := meta[2].([]interface{})
+	if len(schema) != len(columns)+1 {
+		t.Fatalf("schema has %d elements, want %d", len(schema), len(columns)+1)
+	}
+	for i, column := range columns {
+		if name := schema[i+1].(map[int64]interface{})[4]; name != column.Name {
+			t.Errorf("schema element %d = %v, want %s", i+1, name, column.Name)
+		}
+	}
+
+	groups := meta[4].([]interface{})
+	if len(groups) != 2 {
+		t.Fatalf("got %d row groups, want 2", len(groups))
+	}
+
+	// The name column of the first row group holds "a" and a null
+	chunk := groups[0].(map[int64]interface{})[1].([]interface{})[1].(map[int64]interface{})
+	offset := chunk[2].(int64)
+	r := &thriftReader{b: data[offset:], t: t}
+	header := r.structValue()
+	page := r.b[:header[3].(int64)]
+	levelsLen := binary.LittleEndian.Uint32(page)
+	levels := page[4 : 4+levelsLen]
+	if !bytes.Equal(levels, []byte{0x03, 0x01}) {
+		t.Errorf("definition levels = %x, want 0301", levels)
+	}
+	if values := page[4+levelsLen:]; !bytes.Equal(values, []byte{1, 0, 0, 0, 'a'}) {
+		t.Errorf("values = %x", values)
+	}
+}
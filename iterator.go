@@ -0,0 +1,246 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultBatchSize is the number of records per call of the iterator and the
+// batched methods when no batch size is given
+const defaultBatchSize = 500
+
+// RecordIterator pages through the results of a search, one search_read per
+// batch. Only the current batch is held in memory.
+//
+//	it := c.SearchReadIterator("res.partner", odoo.SearchReadOptions{Fields: fields}, 1000)
+//	for it.Next() {
+//		record := it.Record()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type RecordIterator struct {
+	c         *Connector
+	ctx       context.Context
+	model     string
+	opts      SearchReadOptions
+	batchSize int
+
+	// byID pages by the last ID instead of offsets when no order is given,
+	// so records created or deleted meanwhile do not shift the pages
+	byID   bool
+	lastID int64
+	read   int
+
+	page   []map[string]interface{}
+	pos    int
+	record map[string]interface{}
+	done   bool
+	err    error
+}
+
+// SearchReadIterator returns an iterator over the records matching opts,
+// read batchSize (default 500) at a time. opts.Offset and opts.Limit apply to
+// the whole result. Without opts.Order records are returned by ID.
+func (c *Connector) SearchReadIterator(model string, opts SearchReadOptions, batchSize int) *RecordIterator {
+	return c.SearchReadIteratorContext(context.Background(), model, opts, batchSize)
+}
+
+// SearchReadIteratorContext is SearchReadIterator with a context for the
+// calls of the iterator
+func (c *Connector) SearchReadIteratorContext(ctx context.Context, model string, opts SearchReadOptions, batchSize int) *RecordIterator {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &RecordIterator{
+		c:         c,
+		ctx:       ctx,
+		model:     model,
+		opts:      opts,
+		batchSize: batchSize,
+		byID:      opts.Order == "",
+	}
+}
+
+// Next advances to the next record and reports whether there is one. It
+// returns false at the end of the results or after an error.
+func (it *RecordIterator) Next() bool {
+	if it.pos >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.page, it.pos = nil, 0
+		if it.err = it.fetch(); it.err != nil || len(it.page) == 0 {
+			it.record = nil
+			return false
+		}
+	}
+	it.record = it.page[it.pos]
+	it.pos++
+	return true
+}
+
+// Record returns the current record
+func (it *RecordIterator) Record() map[string]interface{} {
+	return it.record
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *RecordIterator) Err() error {
+	return it.err
+}
+
+// fetch reads the next batch
+func (it *RecordIterator) fetch() error {
+	limit := it.batchSize
+	if it.opts.Limit > 0 && it.opts.Limit-it.read < limit {
+		limit = it.opts.Limit - it.read
+	}
+	if limit <= 0 {
+		it.done = true
+		return nil
+	}
+
+	opts := it.opts
+	opts.Limit = limit
+	if it.byID {
+		opts.Order = "id"
+		if it.read > 0 {
+			opts.Domain = Domain(it.opts.Domain).And(Gt("id", it.lastID))
+			opts.Offset = 0
+		}
+	} else {
+		opts.Offset = it.opts.Offset + it.read
+	}
+
+	page, err := it.c.SearchReadRecordsContext(it.ctx, it.model, opts)
+	if err != nil {
+		return err
+	}
+	it.page = page
+	it.read += len(page)
+	if len(page) < limit {
+		it.done = true
+	}
+	if len(page) > 0 {
+		it.lastID, _ = toInt64(page[len(page)-1]["id"])
+	}
+	return nil
+}
+
+// SearchReadAll reads all records matching opts in batches of batchSize
+// (default 500) calls, for results too large for a single response
+func (c *Connector) SearchReadAll(model string, opts SearchReadOptions, batchSize int) ([]map[string]interface{}, error) {
+	return c.SearchReadAllContext(context.Background(), model, opts, batchSize)
+}
+
+// SearchReadAllContext is SearchReadAll with a context
+func (c *Connector) SearchReadAllContext(ctx context.Context, model string, opts SearchReadOptions, batchSize int) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	it := c.SearchReadIteratorContext(ctx, model, opts, batchSize)
+	for it.Next() {
+		records = append(records, it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// CreateRecords creates a record for each of values, batchSize (default 500)
+// records per create call, and returns their IDs in order. After a failure
+// the IDs of the records created by the earlier calls are returned with the
+// error.
+func (c *Connector) CreateRecords(model string, values []map[string]interface{}, batchSize int) ([]int64, error) {
+	return c.CreateRecordsContext(context.Background(), model, values, batchSize)
+}
+
+// CreateRecordsContext is CreateRecords with a context
+func (c *Connector) CreateRecordsContext(ctx context.Context, model string, values []map[string]interface{}, batchSize int) ([]int64, error) {
+	aliases := c.aliasesOf(model)
+	ids := make([]int64, 0, len(values))
+	err := batches(len(values), batchSize, func(start, end int) error {
+		batch := make([]interface{}, 0, end-start)
+		for _, v := range values[start:end] {
+			batch = append(batch, aliases.values(v))
+		}
+		var created []int64
+		if err := c.execute(ctx, model, "create", []interface{}{batch}, nil, &created); err != nil {
+			return err
+		}
+		ids = append(ids, created...)
+		return nil
+	})
+	if err != nil {
+		return ids, fmt.Errorf("create failed for model %s: %w", model, err)
+	}
+	return ids, nil
+}
+
+// UpdateRecords writes values to the records with the given ids, batchSize
+// (default 500) records per write call
+func (c *Connector) UpdateRecords(model string, ids []int64, values map[string]interface{}, batchSize int) error {
+	return c.UpdateRecordsContext(context.Background(), model, ids, values, batchSize)
+}
+
+// UpdateRecordsContext is UpdateRecords with a context
+func (c *Connector) UpdateRecordsContext(ctx context.Context, model string, ids []int64, values map[string]interface{}, batchSize int) error {
+	values = c.aliasesOf(model).values(values)
+	err := batches(len(ids), batchSize, func(start, end int) error {
+		var result bool
+		if err := c.execute(ctx, model, "write", []interface{}{ids[start:end], values}, nil, &result); err != nil {
+			return err
+		}
+		if !result {
+			return fmt.Errorf("records %d to %d not updated", start, end-1)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("update failed for model %s: %w", model, err)
+	}
+	return nil
+}
+
+// DeleteRecords deletes the records with the given ids, batchSize (default
+// 500) records per unlink call
+func (c *Connector) DeleteRecords(model string, ids []int64, batchSize int) error {
+	return c.DeleteRecordsContext(context.Background(), model, ids, batchSize)
+}
+
+// DeleteRecordsContext is DeleteRecords with a context
+func (c *Connector) DeleteRecordsContext(ctx context.Context, model string, ids []int64, batchSize int) error {
+	err := batches(len(ids), batchSize, func(start, end int) error {
+		var result bool
+		if err := c.execute(ctx, model, "unlink", []interface{}{ids[start:end]}, nil, &result); err != nil {
+			return err
+		}
+		if !result {
+			return fmt.Errorf("records %d to %d not deleted", start, end-1)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete failed for model %s: %w", model, err)
+	}
+	return nil
+}
+
+// batches calls fn with the bounds of consecutive batches of n items,
+// stopping at the first error
+func batches(n, batchSize int, fn func(start, end int) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	for start := 0; start < n; start += batchSize {
+		end := start + batchSize
+		if end > n {
+			end = n
+		}
+		if err := fn(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
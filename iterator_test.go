@@ -0,0 +1,93 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchReadIterator(t *testing.T) {
+	var limits []int64
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		domain := args[5].([]interface{})[0].([]interface{})
+		kwargs := args[6].(map[string]interface{})
+		var after int64
+		if len(domain) > 0 {
+			after, _ = toInt64(normalizeJSONValue(domain[0].([]interface{})[2]))
+		}
+		limit, _ := toInt64(normalizeJSONValue(kwargs["limit"]))
+		limits = append(limits, limit)
+
+		var records []map[string]interface{}
+		for id := after + 1; id <= 7 && int64(len(records)) < limit; id++ {
+			records = append(records, map[string]interface{}{"id": id})
+		}
+		return records
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	it := c.SearchReadIterator("res.partner", SearchReadOptions{}, 3)
+	for it.Next() {
+		id, _ := toInt64(it.Record()["id"])
+		ids = append(ids, id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{1, 2, 3, 4, 5, 6, 7}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+
+	limits = nil
+	records, err := c.SearchReadAll("res.partner", SearchReadOptions{Limit: 5}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 {
+		t.Errorf("read %d records, want 5", len(records))
+	}
+	if want := []int64{3, 2}; !reflect.DeepEqual(limits, want) {
+		t.Errorf("limits = %v, want %v", limits, want)
+	}
+}
+
+func TestCreateRecordsBatches(t *testing.T) {
+	var sizes []int
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		batch := args[5].([]interface{})[0].([]interface{})
+		sizes = append(sizes, len(batch))
+		ids := make([]int, len(batch))
+		for i := range ids {
+			ids[i] = 10 + i
+		}
+		return ids
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]map[string]interface{}, 5)
+	for i := range values {
+		values[i] = map[string]interface{}{"name": "Partner"}
+	}
+	ids, err := c.CreateRecords("res.partner", values, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 2, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("batch sizes = %v, want %v", sizes, want)
+	}
+	if want := []int64{10, 11, 10, 11, 10}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
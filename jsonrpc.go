@@ -0,0 +1,458 @@
+package odoo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// jsonCodec speaks Odoo's JSON-RPC dialect: every service is called through
+// /jsonrpc with the service, method and arguments as params of a "call"
+type jsonCodec struct{}
+
+// jsonRequestID numbers requests, which makes them easier to follow in
+// proxy logs
+var jsonRequestID atomic.Int64
+
+func (jsonCodec) endpoint(baseURL, service string) string {
+	return baseURL + "/jsonrpc"
+}
+
+func (jsonCodec) contentType() string { return "application/json" }
+
+func (jsonCodec) encodeCall(buf encodeBuffer, service, method string, args []interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+	buf.WriteString(`{"jsonrpc":"2.0","method":"call","params":{"service":`)
+	if err := encodeJSON(buf, service); err != nil {
+		return err
+	}
+	buf.WriteString(`,"method":`)
+	if err := encodeJSON(buf, method); err != nil {
+		return err
+	}
+	buf.WriteString(`,"args":`)
+	if err := encodeJSON(buf, args); err != nil {
+		return err
+	}
+	buf.WriteString(`},"id":`)
+	buf.Write(strconv.AppendInt(buf.AvailableBuffer(), jsonRequestID.Add(1), 10))
+	buf.WriteString("}\n")
+	return nil
+}
+
+// encodeJSON writes v to buf. Lists and dictionaries are walked so binary
+// values are streamed into buf instead of buffered by encoding/json, and
+// times are sent as UTC datetime strings, which XML-RPC does with its
+// dateTime type; other values are marshaled by encoding/json.
+func encodeJSON(buf encodeBuffer, v interface{}) error {
+	switch t := v.(type) {
+	case *binaryValue:
+		return t.encodeJSON(buf)
+	case time.Time:
+		return encodeJSON(buf, t.UTC().Format(time.DateTime))
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeJSON(buf, t[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// jsonEnvelope is a JSON-RPC response
+type jsonEnvelope struct {
+	Result json.RawMessage `json:"result"`
+	Error  *jsonError      `json:"error"`
+}
+
+// jsonError is the error of a JSON-RPC response. Data carries the Odoo
+// exception.
+type jsonError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+		Debug   string `json:"debug"`
+	} `json:"data"`
+}
+
+func (e *jsonError) err() error {
+	if e.Data.Name == "" {
+		return &Error{FaultCode: e.Code, Message: e.Message}
+	}
+	return &Error{
+		FaultCode:       e.Code,
+		ExceptionType:   e.Data.Name,
+		Message:         e.Data.Message,
+		ServerTraceback: e.Data.Debug,
+	}
+}
+
+func (jsonCodec) parse(data []byte) (response, error) {
+	var envelope jsonEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid JSON-RPC response: %w", err)
+	}
+	if envelope.Error != nil {
+		return nil, envelope.Error.err()
+	}
+	return jsonResponse(envelope.Result), nil
+}
+
+// jsonResponse is the raw result of a JSON-RPC response
+type jsonResponse []byte
+
+// Unmarshal decodes numbers like the XML-RPC decoder does, so shared helpers
+// work on either result
+func (r jsonResponse) Unmarshal(v interface{}) error {
+	if u, ok := v.(json.Unmarshaler); ok {
+		return u.UnmarshalJSON(r)
+	}
+	dec := json.NewDecoder(bytes.NewReader(r))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	normalizeJSONNumbers(v)
+	return nil
+}
+
+func (jsonCodec) decodeArray(r io.Reader, fn func(interface{}) error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return decodeJSONResult(dec, func() error {
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			if err := fn(normalizeJSONValue(v)); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	})
+}
+
+func (jsonCodec) decodeStructArray(r io.Reader, record *structRecord, fn func() error) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return decodeJSONResult(dec, func() error {
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			record.value.Set(reflect.Zero(record.value.Type()))
+			if err := decodeJSONStruct(dec, record); err != nil {
+				return err
+			}
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	})
+}
+
+// decodeBinaryMember scans the records of a read response for field. Unlike
+// the XML-RPC decoder it holds the encoded value in memory while decoding it.
+func (jsonCodec) decodeBinaryMember(r io.Reader, field string, w io.Writer) (int64, error) {
+	dec := json.NewDecoder(r)
+	var n int64
+	err := decodeJSONResult(dec, func() error {
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			if err := expectJSONDelim(dec, '{'); err != nil {
+				return err
+			}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if key != field {
+					var skip json.RawMessage
+					if err := dec.Decode(&skip); err != nil {
+						return err
+					}
+					continue
+				}
+				value, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				// Odoo sends false when the field is empty
+				s, ok := value.(string)
+				if !ok {
+					return nil
+				}
+				out := &base64Writer{w: w}
+				if _, err := io.WriteString(out, s); err != nil {
+					n = out.n
+					return err
+				}
+				err = out.Close()
+				n = out.n
+				return err
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("field %s not found in response", field)
+	})
+	return n, err
+}
+
+// decodeJSONResult reads a JSON-RPC response up to its result and calls
+// result to decode it from dec. An error response is returned like the
+// buffered path does.
+func decodeJSONResult(dec *json.Decoder, result func() error) error {
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "result":
+			return result()
+		case "error":
+			var e jsonError
+			if err := dec.Decode(&e); err != nil {
+				return err
+			}
+			return e.err()
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	return errors.New("invalid JSON-RPC response: no result")
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("invalid JSON-RPC response: expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
+// structRecords decodes a JSON-RPC search_read result directly into a slice
+// of structs or struct pointers, like DecodeRecord does from record maps
+type structRecords struct {
+	slice   reflect.Value
+	aliases *aliasMap
+}
+
+func (r *structRecords) UnmarshalJSON(data []byte) error {
+	elemType := r.slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	record := &structRecord{fields: structFieldsByName(structType), aliases: r.aliases}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(r.slice.Type(), 0, 0)
+	for dec.More() {
+		item := reflect.New(structType)
+		record.value = item.Elem()
+		if err := decodeJSONStruct(dec, record); err != nil {
+			return err
+		}
+		if elemType.Kind() == reflect.Ptr {
+			result = reflect.Append(result, item)
+		} else {
+			result = reflect.Append(result, item.Elem())
+		}
+	}
+	r.slice.Set(result)
+	return nil
+}
+
+// decodeJSONStruct decodes the next object of dec into record, skipping the
+// members without a mapped field
+func decodeJSONStruct(dec *json.Decoder, record *structRecord) error {
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := key.(string)
+		dst, f, ok := record.field(name)
+		if !ok {
+			if _, err := readJSONValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decodeJSONField(dec, dst); err != nil {
+			return fmt.Errorf("decoding field %s: %w", f.name, err)
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// decodeJSONField decodes the next value of dec into dst. Values that
+// encoding/json decodes the way decodeValue does are decoded directly;
+// false, many2one pairs, dates and the like take the generic path.
+func decodeJSONField(dec *json.Decoder, dst reflect.Value) error {
+	if next := peekJSON(dec); next != 'f' && next != 'n' && decodesAsJSON(dst.Type(), next) {
+		return dec.Decode(dst.Addr().Interface())
+	}
+	value, err := readJSONValue(dec)
+	if err != nil {
+		return err
+	}
+	return decodeValue(dst, value)
+}
+
+// peekJSON returns the first byte of the next value of dec, or 0 if it is
+// not buffered yet
+func peekJSON(dec *json.Decoder) byte {
+	var b [1]byte
+	buffered := dec.Buffered()
+	for {
+		if _, err := buffered.Read(b[:]); err != nil {
+			return 0
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n', ':':
+			continue
+		}
+		return b[0]
+	}
+}
+
+// decodesAsJSON reports whether encoding/json decodes a value starting with
+// the byte next into a t the same way decodeValue would
+func decodesAsJSON(t reflect.Type, next byte) bool {
+	if next == 0 || t == timeType || t == many2oneType {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.String:
+		// A many2one pair decodes into its ID or display name
+		return next != '['
+	case reflect.Float32, reflect.Float64, reflect.Bool:
+		return true
+	case reflect.Slice:
+		// x2many IDs and base64 binary values
+		switch t.Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint8, reflect.String:
+			return true
+		}
+	}
+	return false
+}
+
+// readJSONValue reads the next value from dec token by token, with numbers
+// normalized like jsonResponse does
+func readJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Number:
+		return normalizeJSONValue(t), nil
+	case json.Delim:
+		if t == '[' {
+			items := []interface{}{}
+			for dec.More() {
+				item, err := readJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			_, err := dec.Token()
+			return items, err
+		}
+		members := make(map[string]interface{})
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			name, _ := key.(string)
+			members[name] = value
+		}
+		_, err := dec.Token()
+		return members, err
+	}
+	return tok, nil
+}
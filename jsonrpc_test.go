@@ -0,0 +1,196 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// jsonRPCServer answers /jsonrpc calls with the result of handle
+func jsonRPCServer(t *testing.T, handle func(service, method string, args []interface{}) interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(jsonRPCHandler(t, handle))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// jsonRPCHandler is the handler of jsonRPCServer, for tests that inspect the
+// HTTP requests too
+func jsonRPCHandler(t *testing.T, handle func(service, method string, args []interface{}) interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jsonrpc" {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+		var req struct {
+			ID     int64 `json:"id"`
+			Params struct {
+				Service string        `json:"service"`
+				Method  string        `json:"method"`
+				Args    []interface{} `json:"args"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		result := handle(req.Params.Service, req.Params.Method, req.Params.Args)
+		if code, ok := result.(httpStatus); ok {
+			w.WriteHeader(int(code))
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result}
+		if e, ok := result.(*jsonError); ok {
+			resp = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": e}
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func TestJSONRPC(t *testing.T) {
+	var written map[string]interface{}
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if service == serviceCommon && method == "authenticate" {
+			return 2
+		}
+		switch args[4] {
+		case "search_read":
+			return []map[string]interface{}{
+				{"id": 1, "name": "Azure Interior", "partner_id": []interface{}{3, "Deco Addict"}, "amount": 12.5},
+				{"id": 2, "name": "Gemini Furniture", "partner_id": false, "amount": 0},
+			}
+		case "write":
+			written = args[5].([]interface{})[1].(map[string]interface{})
+			return true
+		case "read":
+			return []map[string]interface{}{{"id": 1, "datas": "aGVsbG8="}}
+		}
+		e := &jsonError{Code: 200, Message: "Odoo Server Error"}
+		e.Data.Name = "odoo.exceptions.AccessError"
+		e.Data.Message = "You are not allowed to access this document."
+		return e
+	})
+
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.UID != 2 {
+		t.Errorf("UID = %d, want 2", c.UID)
+	}
+
+	records, err := c.SearchReadRecords("res.partner", SearchReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]interface{}{
+		{"id": int64(1), "name": "Azure Interior", "partner_id": []interface{}{int64(3), "Deco Addict"}, "amount": 12.5},
+		{"id": int64(2), "name": "Gemini Furniture", "partner_id": false, "amount": int64(0)},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %v, want %v", records, want)
+	}
+
+	var streamed []map[string]interface{}
+	err = c.SearchReadStream(context.Background(), "res.partner", SearchReadOptions{}, func(record map[string]interface{}) error {
+		streamed = append(streamed, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(streamed, want) {
+		t.Errorf("streamed records = %v, want %v", streamed, want)
+	}
+
+	if err := c.UpdateRecord("res.partner", 1, map[string]interface{}{"name": "Azure"}); err != nil {
+		t.Fatal(err)
+	}
+	if written["name"] != "Azure" {
+		t.Errorf("written values = %v", written)
+	}
+
+	var content bytes.Buffer
+	if _, err := c.ReadBinaryField(context.Background(), "ir.attachment", 1, "datas", &content); err != nil {
+		t.Fatal(err)
+	}
+	if content.String() != "hello" {
+		t.Errorf("binary content = %q, want hello", content.String())
+	}
+
+	err = c.DeleteRecord("res.partner", 1)
+	if err == nil || !strings.Contains(err.Error(), "odoo.exceptions.AccessError: You are not allowed") {
+		t.Errorf("err = %v, want the access error", err)
+	}
+}
+
+// typedRecordsJSON is a search_read result with values of every kind
+// DecodeRecord supports
+const typedRecordsJSON = `[
+	{"id": 7, "name": "Office chairs", "email_from": "info@azure.example", "partner_id": [3, "Azure Interior"],
+	 "user_id": [2, "Mitchell Admin"], "stage_id": [1, "New"], "expected_revenue": 1200, "active": true,
+	 "date_deadline": "2024-03-31", "create_date": "2024-03-01 08:15:00", "tag_ids": [4, 5], "color": 3},
+	{"id": 8, "name": "Desks", "email_from": false, "partner_id": false, "user_id": null, "stage_id": false,
+	 "expected_revenue": 99.5, "active": false, "date_deadline": false, "create_date": "2024-03-02 09:00:00", "tag_ids": []}
+]`
+
+func TestStructRecordsMatchDecodeRecord(t *testing.T) {
+	var got []*mappedLead
+	target := &structRecords{slice: reflect.ValueOf(&got).Elem()}
+	if err := jsonResponse(typedRecordsJSON).Unmarshal(target); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []map[string]interface{}
+	if err := jsonResponse(typedRecordsJSON).Unmarshal(&records); err != nil {
+		t.Fatal(err)
+	}
+	want := make([]*mappedLead, len(records))
+	for i, record := range records {
+		want[i] = new(mappedLead)
+		if err := DecodeRecord(record, want[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	var invalid []struct {
+		Name int64 `odoo:"name"`
+	}
+	target = &structRecords{slice: reflect.ValueOf(&invalid).Elem()}
+	if err := jsonResponse(typedRecordsJSON).Unmarshal(target); err == nil {
+		t.Error("decoding a string into an integer did not fail")
+	}
+}
+
+// benchmarkRecordsJSON repeats the records of typedRecordsJSON 100 times
+var benchmarkRecordsJSON = jsonResponse("[" + strings.Repeat(strings.Trim(typedRecordsJSON, "[]\n")+",", 99) + strings.Trim(typedRecordsJSON, "[]\n") + "]")
+
+func BenchmarkSearchReadIntoJSONMaps(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var records []map[string]interface{}
+		if err := benchmarkRecordsJSON.Unmarshal(&records); err != nil {
+			b.Fatal(err)
+		}
+		leads := make([]mappedLead, len(records))
+		for i, record := range records {
+			if err := DecodeRecord(record, &leads[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkSearchReadIntoJSONTyped(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var leads []mappedLead
+		if err := benchmarkRecordsJSON.Unmarshal(&structRecords{slice: reflect.ValueOf(&leads).Elem()}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Language holds the formatting settings of a res.lang record, so dates and
+// numbers can be formatted the way the Odoo UI shows them in that language
+type Language struct {
+	Code string
+	Name string
+	// DateFormat and TimeFormat are strftime formats, e.g. "%d/%m/%Y"
+	DateFormat string
+	TimeFormat string
+	// DecimalPoint and ThousandsSep separate the digits of numbers
+	DecimalPoint string
+	ThousandsSep string
+	// Grouping lists the sizes of the digit groups from the right, as in
+	// Odoo: a 0 repeats the previous size and -1 stops grouping, so [3, 0]
+	// groups by thousands and [3, 2, 0] the Indian way
+	Grouping []int
+}
+
+// Language returns the formatting settings of the language with the given
+// code, e.g. "de_DE", or of the integration user's language if code is empty
+func (c *Connector) Language(ctx context.Context, code string) (*Language, error) {
+	if code == "" {
+		user, err := c.CurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("language lookup failed: %w", err)
+		}
+		code = user.Lang
+	}
+
+	var records []map[string]interface{}
+	err := c.execute(ctx, "res.lang", "search_read", []interface{}{[]interface{}{
+		[]interface{}{"code", "=", code},
+	}}, map[string]interface{}{
+		"fields":  []string{"code", "name", "date_format", "time_format", "decimal_point", "thousands_sep", "grouping"},
+		"limit":   1,
+		"context": map[string]interface{}{"active_test": false},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("language lookup failed for %s: %w", code, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("language lookup failed for %s: language not found", code)
+	}
+
+	record := records[0]
+	lang := &Language{}
+	lang.Code, _ = record["code"].(string)
+	lang.Name, _ = record["name"].(string)
+	lang.DateFormat, _ = record["date_format"].(string)
+	lang.TimeFormat, _ = record["time_format"].(string)
+	lang.DecimalPoint, _ = record["decimal_point"].(string)
+	lang.ThousandsSep, _ = record["thousands_sep"].(string)
+	if grouping, ok := record["grouping"].(string); ok {
+		if err := json.Unmarshal([]byte(grouping), &lang.Grouping); err != nil {
+			return nil, fmt.Errorf("language lookup failed for %s: invalid grouping %q", code, grouping)
+		}
+	}
+	return lang, nil
+}
+
+// DateLayout returns DateFormat as a Go time layout
+func (l *Language) DateLayout() string {
+	return strftimeLayout(l.DateFormat)
+}
+
+// DatetimeLayout returns DateFormat and TimeFormat as a Go time layout
+func (l *Language) DatetimeLayout() string {
+	return strftimeLayout(l.DateFormat + " " + l.TimeFormat)
+}
+
+// FormatDate formats t as a date in the language
+func (l *Language) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout())
+}
+
+// FormatDatetime formats t as a date and time in the language
+func (l *Language) FormatDatetime(t time.Time) string {
+	return t.Format(l.DatetimeLayout())
+}
+
+// FormatNumber formats value with places decimals and the separators and
+// digit grouping of the language
+func (l *Language) FormatNumber(value float64, places int) string {
+	return formatNumber(value, places, l.DecimalPoint, l.ThousandsSep, l.Grouping)
+}
+
+// TemplateOptions returns TemplateFuncs options formatting dates and amounts
+// in the language, with datetimes shown in loc
+func (l *Language) TemplateOptions(loc *time.Location, currencies map[string]Currency) TemplateOptions {
+	return TemplateOptions{
+		Location:           loc,
+		DateLayout:         l.DateLayout(),
+		DatetimeLayout:     l.DatetimeLayout(),
+		DecimalSeparator:   l.DecimalPoint,
+		ThousandsSeparator: l.ThousandsSep,
+		Grouping:           l.Grouping,
+		Currencies:         currencies,
+	}
+}
+
+// strftimeVerbs maps the strftime directives Odoo languages use to Go layout
+// elements
+var strftimeVerbs = map[byte]string{
+	'a': "Mon", 'A': "Monday", 'b': "Jan", 'B': "January", 'h': "Jan",
+	'd': "02", 'e': "_2", 'm': "01", 'y': "06", 'Y': "2006", 'j': "002",
+	'H': "15", 'I': "03", 'M': "04", 'S': "05", 'p': "PM",
+	'z': "-0700", 'Z': "MST", '%': "%",
+}
+
+// strftimeLayout converts a strftime format to a Go time layout. Unknown
+// directives are kept literally.
+func strftimeLayout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		if verb, ok := strftimeVerbs[format[i]]; ok {
+			b.WriteString(verb)
+		} else {
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+// formatNumber rounds value to places decimals and inserts the separators,
+// grouping the integer digits like Odoo's intersperse
+func formatNumber(value float64, places int, decimalSep, thousandsSep string, grouping []int) string {
+	s := strconv.FormatFloat(math.Abs(value), 'f', places, 64)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	groups := digitGroups(integer, grouping)
+	for i := len(groups) - 1; i >= 0; i-- {
+		b.WriteString(groups[i])
+		if i > 0 {
+			b.WriteString(thousandsSep)
+		}
+	}
+	if fraction != "" {
+		b.WriteString(decimalSep)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// digitGroups splits digits into groups from the right, last group first
+func digitGroups(digits string, grouping []int) []string {
+	var groups []string
+	saved := len(digits)
+	for _, size := range grouping {
+		if digits == "" || size == -1 {
+			break
+		}
+		if size == 0 {
+			for digits != "" {
+				n := saved
+				if n > len(digits) {
+					n = len(digits)
+				}
+				groups = append(groups, digits[len(digits)-n:])
+				digits = digits[:len(digits)-n]
+			}
+			break
+		}
+		if size > len(digits) {
+			size = len(digits)
+		}
+		groups = append(groups, digits[len(digits)-size:])
+		digits = digits[:len(digits)-size]
+		saved = size
+	}
+	if digits != "" {
+		groups = append(groups, digits)
+	}
+	return groups
+}
@@ -0,0 +1,29 @@
+package odoo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLanguageFormatting(t *testing.T) {
+	de := &Language{DateFormat: "%d.%m.%Y", TimeFormat: "%H:%M:%S", DecimalPoint: ",", ThousandsSep: ".", Grouping: []int{3, 0}}
+	in := &Language{DateFormat: "%A %e %B %Y", TimeFormat: "%I:%M %p", DecimalPoint: ".", ThousandsSep: ",", Grouping: []int{3, 2, 0}}
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{de.FormatDatetime(at), "05.03.2024 14:07:09"},
+		{de.FormatNumber(-1234567.891, 2), "-1.234.567,89"},
+		{in.FormatDatetime(at), "Tuesday  5 March 2024 02:07 PM"},
+		{in.FormatNumber(123456789, 0), "12,34,56,789"},
+		{formatNumber(1234567, 0, ".", ",", []int{3, -1}), "1234,567"},
+		{formatNumber(1234567, 0, ".", ",", []int{3}), "1234,567"},
+		{formatNumber(1234567, 0, ".", ",", nil), "1234567"},
+		{strftimeLayout("%Y-%m-%d %% %Q"), "2006-01-02 % %Q"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+}
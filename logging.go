@@ -0,0 +1,32 @@
+package odoo
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger makes the connector log through logger. Every RPC call is logged
+// at debug level with its model, method and duration; failed calls are logged
+// at warn level. Without this option the connector does not log at all.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Connector) {
+		c.logger = logger
+	}
+}
+
+// log returns the configured logger or one discarding all records
+func (c *Connector) log() *slog.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,77 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestLogger(t *testing.T) {
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		if args[4] == "unlink" {
+			return xmlrpc.FaultError{Code: 1, String: "Odoo Server Error"}
+		}
+		return []map[string]interface{}{{"id": 1}}
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SearchReadRecords("res.partner", SearchReadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteRecord("res.partner", 1); err == nil {
+		t.Fatal("unlink did not fail")
+	}
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3:\n%s", len(entries), buf.String())
+	}
+	for i, want := range []struct {
+		level, msg, method string
+	}{
+		{"INFO", "odoo connector initialized", ""},
+		{"DEBUG", "odoo call", "search_read"},
+		{"WARN", "odoo call failed", "unlink"},
+	} {
+		entry := entries[i]
+		if entry["level"] != want.level || entry["msg"] != want.msg {
+			t.Errorf("entry %d: %v", i, entry)
+		}
+		if want.method == "" {
+			continue
+		}
+		if entry["model"] != "res.partner" || entry["method"] != want.method || entry["duration"] == nil {
+			t.Errorf("entry %d: %v", i, entry)
+		}
+	}
+	if entries[2]["error"] == nil {
+		t.Errorf("failed call logged without its error: %v", entries[2])
+	}
+}
+
+func TestDiscardLogger(t *testing.T) {
+	c := &Connector{}
+	if c.log() != discardLogger || c.log().Enabled(context.Background(), slog.LevelError) {
+		t.Error("connector without logger does not discard")
+	}
+}
@@ -0,0 +1,391 @@
+package odoo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Many2one holds a many2one value, which Odoo reads as [id, display name].
+// The zero value is the empty many2one (false).
+type Many2one struct {
+	ID   int64
+	Name string
+}
+
+var many2oneType = reflect.TypeOf(Many2one{})
+
+// DecodeRecord stores the fields of record in the struct pointed to by v,
+// mapped through `odoo` tags (see FieldsOf):
+//
+//   - Odoo's false for empty values becomes the zero value, or nil for
+//     pointer fields
+//   - many2one values decode into Many2one, into an integer field as the ID
+//     or into a string field as the display name
+//   - date and datetime strings decode into time.Time (UTC)
+//   - x2many values decode into integer slices and binary values into
+//     []byte
+//
+// Fields missing from record are left unchanged.
+func DecodeRecord(record map[string]interface{}, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decoding record: %T is not a pointer to a struct", v)
+	}
+	rv = rv.Elem()
+	for _, f := range structFieldsOf(rv.Type()) {
+		value, ok := record[f.name]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(rv.FieldByIndex(f.index), value); err != nil {
+			return fmt.Errorf("decoding field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// decodeValue stores the Odoo value v in dst. nil, which JSON-RPC returns
+// for unset values, decodes into the zero value of any type.
+func decodeValue(dst reflect.Value, v interface{}) error {
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	empty := v == false
+	if dst.Kind() == reflect.Ptr {
+		if empty {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		elem := reflect.New(dst.Type().Elem())
+		if err := decodeValue(elem.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	if dst.Kind() == reflect.Interface {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if empty && dst.Kind() != reflect.Bool {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch dst.Type() {
+	case timeType:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("cannot decode %v (%T) into time.Time", v, v)
+		}
+		t, err := parseOdooTime(s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	case many2oneType:
+		id, ok := many2oneID(v)
+		if !ok {
+			return fmt.Errorf("cannot decode %v (%T) into Many2one", v, v)
+		}
+		m := Many2one{ID: id}
+		if pair, ok := v.([]interface{}); ok && len(pair) == 2 {
+			m.Name, _ = pair[1].(string)
+		}
+		dst.Set(reflect.ValueOf(m))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := many2oneID(v); ok {
+			dst.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := many2oneID(v); ok && n >= 0 {
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch n := v.(type) {
+		case float64:
+			dst.SetFloat(n)
+			return nil
+		case int64:
+			dst.SetFloat(float64(n))
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := v.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.String:
+		switch s := v.(type) {
+		case string:
+			dst.SetString(s)
+			return nil
+		case []interface{}:
+			// The display name of a many2one value
+			if len(s) == 2 {
+				if name, ok := s[1].(string); ok {
+					dst.SetString(name)
+					return nil
+				}
+			}
+		}
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			// Binary fields are read as base64
+			if s, ok := v.(string); ok {
+				data, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return err
+				}
+				dst.SetBytes(data)
+				return nil
+			}
+			break
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Type().AssignableTo(dst.Type()) {
+		dst.Set(value)
+		return nil
+	}
+	return fmt.Errorf("cannot decode %v (%T) into %s", v, v, dst.Type())
+}
+
+// parseOdooTime parses an Odoo date or datetime value, which are in UTC
+func parseOdooTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateTime, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an Odoo date or datetime", s)
+	}
+	return t, nil
+}
+
+// EncodeRecord returns the field values of the struct v (or pointer to one)
+// for create and write, the reverse of DecodeRecord. The id field and fields
+// tagged "readonly" are skipped, as are zero values of fields tagged
+// "omitempty". Zero times, nil pointers and empty Many2one values are written
+// as false; times are written as datetimes, or as dates for fields tagged
+// "date"; integer slices replace x2many fields.
+//
+//	type Lead struct {
+//		ID       int64     `odoo:"id"`
+//		Name     string    `odoo:"name"`
+//		Partner  Many2one  `odoo:"partner_id,omitempty"`
+//		Deadline time.Time `odoo:"date_deadline,date"`
+//		Stage    string    `odoo:"stage_id,readonly"`
+//	}
+func EncodeRecord(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("encoding record: %T is not a struct", v)
+	}
+
+	values := make(map[string]interface{})
+	for _, f := range structFieldsOf(rv.Type()) {
+		opts := tagOptions(f.opts)
+		if f.name == "id" || opts["readonly"] {
+			continue
+		}
+		field := rv.FieldByIndex(f.index)
+		if opts["omitempty"] && field.IsZero() {
+			continue
+		}
+		value, err := encodeFieldValue(field, opts["date"])
+		if err != nil {
+			return nil, fmt.Errorf("encoding field %s: %w", f.name, err)
+		}
+		values[f.name] = value
+	}
+	return values, nil
+}
+
+// encodeFieldValue converts a struct field value for create and write
+func encodeFieldValue(v reflect.Value, date bool) (interface{}, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false, nil
+		}
+		return encodeFieldValue(v.Elem(), date)
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		switch {
+		case t.IsZero():
+			return false, nil
+		case date:
+			return t.Format(time.DateOnly), nil
+		}
+		return t.UTC().Format(time.DateTime), nil
+	case many2oneType:
+		if id := v.Interface().(Many2one).ID; id != 0 {
+			return id, nil
+		}
+		return false, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		ids := make([]int64, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			switch item.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				ids = append(ids, item.Int())
+			default:
+				return nil, fmt.Errorf("cannot encode %s as x2many IDs", v.Type())
+			}
+		}
+		return Commands(CommandSet(ids)), nil
+	case reflect.Map:
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot encode %s", v.Type())
+}
+
+// tagOptions parses the options after the field name of an `odoo` tag
+func tagOptions(opts string) map[string]bool {
+	set := make(map[string]bool)
+	for _, opt := range strings.Split(opts, ",") {
+		if opt != "" {
+			set[opt] = true
+		}
+	}
+	return set
+}
+
+// SearchReadInto searches records like SearchReadRecords and decodes them
+// with DecodeRecord into dest, a pointer to a slice of structs or of struct
+// pointers. Without opts.Fields only the fields tagged on the struct are read
+// (see FieldsOf), unless opts.AllFields is set.
+// The call is retried and hedged like SearchReadRecords. Over JSON-RPC the
+// records are decoded from the response directly into dest.
+//
+//	var leads []Lead
+//	err := c.SearchReadInto("crm.lead", odoo.SearchReadOptions{Limit: 10}, &leads)
+func (c *Connector) SearchReadInto(model string, opts SearchReadOptions, dest interface{}) error {
+	return c.SearchReadIntoContext(context.Background(), model, opts, dest)
+}
+
+// SearchReadIntoContext is SearchReadInto with a context
+func (c *Connector) SearchReadIntoContext(ctx context.Context, model string, opts SearchReadOptions, dest interface{}) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.IsNil() || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("search_read failed for model %s: %T is not a pointer to a slice", model, dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("search_read failed for model %s: %s is not a struct", model, elemType)
+	}
+	if len(opts.Fields) == 0 && !opts.AllFields {
+		opts.Fields = FieldsOf(dest)
+	}
+
+	if c.protocol == JSONRPC {
+		// Decoded straight from the response, without the record maps
+		err := c.searchRead(ctx, model, opts, &structRecords{slice: slice, aliases: c.aliasesOf(model)})
+		if err != nil {
+			return fmt.Errorf("search_read failed for model %s: %w", model, err)
+		}
+		return nil
+	}
+
+	records, err := c.SearchReadRecordsContext(ctx, model, opts)
+	if err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(slice.Type(), 0, len(records))
+	for _, record := range records {
+		item := reflect.New(structType)
+		if err := DecodeRecord(record, item.Interface()); err != nil {
+			return fmt.Errorf("search_read failed for model %s: %w", model, err)
+		}
+		if elemType.Kind() == reflect.Ptr {
+			result = reflect.Append(result, item)
+		} else {
+			result = reflect.Append(result, item.Elem())
+		}
+	}
+	slice.Set(result)
+	return nil
+}
+
+// CreateFromStruct creates a record from the values of the struct v, encoded
+// with EncodeRecord
+func (c *Connector) CreateFromStruct(model string, v interface{}) (int64, error) {
+	return c.CreateFromStructContext(context.Background(), model, v)
+}
+
+// CreateFromStructContext is CreateFromStruct with a context
+func (c *Connector) CreateFromStructContext(ctx context.Context, model string, v interface{}) (int64, error) {
+	values, err := EncodeRecord(v)
+	if err != nil {
+		return 0, fmt.Errorf("create failed for model %s: %w", model, err)
+	}
+	return c.CreateRecordContext(ctx, model, values)
+}
+
+// UpdateFromStruct writes the values of the struct v, encoded with
+// EncodeRecord, to the record with the given id
+func (c *Connector) UpdateFromStruct(model string, id int64, v interface{}) error {
+	return c.UpdateFromStructContext(context.Background(), model, id, v)
+}
+
+// UpdateFromStructContext is UpdateFromStruct with a context
+func (c *Connector) UpdateFromStructContext(ctx context.Context, model string, id int64, v interface{}) error {
+	values, err := EncodeRecord(v)
+	if err != nil {
+		return fmt.Errorf("update failed for model %s with id %d: %w", model, id, err)
+	}
+	return c.UpdateRecordContext(ctx, model, id, values)
+}
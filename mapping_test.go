@@ -0,0 +1,203 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mappedLead struct {
+	ID       int64     `odoo:"id"`
+	Name     string    `odoo:"name"`
+	Email    *string   `odoo:"email_from"`
+	Partner  Many2one  `odoo:"partner_id,omitempty"`
+	UserID   int64     `odoo:"user_id,omitempty"`
+	Stage    string    `odoo:"stage_id,readonly"`
+	Revenue  float64   `odoo:"expected_revenue"`
+	Active   bool      `odoo:"active"`
+	Deadline time.Time `odoo:"date_deadline,date"`
+	Created  time.Time `odoo:"create_date,readonly"`
+	TagIDs   []int64   `odoo:"tag_ids"`
+	Ignored  string
+}
+
+func TestDecodeRecord(t *testing.T) {
+	var lead mappedLead
+	err := DecodeRecord(map[string]interface{}{
+		"id":               int64(7),
+		"name":             "Office chairs",
+		"email_from":       false,
+		"partner_id":       []interface{}{int64(3), "Azure Interior"},
+		"user_id":          []interface{}{int64(2), "Mitchell Admin"},
+		"stage_id":         []interface{}{int64(1), "New"},
+		"expected_revenue": int64(1200),
+		"active":           true,
+		"date_deadline":    "2024-03-31",
+		"create_date":      "2024-03-01 08:15:00",
+		"tag_ids":          []interface{}{int64(4), int64(5)},
+	}, &lead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mappedLead{
+		ID:       7,
+		Name:     "Office chairs",
+		Partner:  Many2one{ID: 3, Name: "Azure Interior"},
+		UserID:   2,
+		Stage:    "New",
+		Revenue:  1200,
+		Active:   true,
+		Deadline: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		Created:  time.Date(2024, 3, 1, 8, 15, 0, 0, time.UTC),
+		TagIDs:   []int64{4, 5},
+	}
+	if !reflect.DeepEqual(lead, want) {
+		t.Errorf("got  %+v\nwant %+v", lead, want)
+	}
+
+	if err := DecodeRecord(map[string]interface{}{"name": int64(1)}, &lead); err == nil {
+		t.Error("decoding an integer into a string did not fail")
+	}
+	if err := DecodeRecord(map[string]interface{}{}, lead); err == nil {
+		t.Error("decoding into a non-pointer did not fail")
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	values, err := EncodeRecord(&mappedLead{
+		ID:       7,
+		Name:     "Office chairs",
+		Stage:    "New",
+		Deadline: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		TagIDs:   []int64{4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":             "Office chairs",
+		"email_from":       false,
+		"expected_revenue": 0.0,
+		"active":           false,
+		"date_deadline":    "2024-03-31",
+		"tag_ids":          []interface{}{[]interface{}{6, 0, []int64{4}}},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("got  %v\nwant %v", values, want)
+	}
+}
+
+func TestDecodeRecordNil(t *testing.T) {
+	name := "set"
+	// Each destination starts from a non-zero value that nil must reset
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"int", 1},
+		{"int8", int8(1)},
+		{"int32", int32(1)},
+		{"int64", int64(1)},
+		{"uint", uint(1)},
+		{"uint64", uint64(1)},
+		{"float32", float32(1)},
+		{"float64", 1.5},
+		{"bool", true},
+		{"string", name},
+		{"bytes", []byte("x")},
+		{"ids", []int64{1}},
+		{"time", time.Now()},
+		{"many2one", Many2one{ID: 1, Name: name}},
+		{"pointer", &name},
+		{"map", map[string]interface{}{"a": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := reflect.New(reflect.TypeOf(tt.value)).Elem()
+			dst.Set(reflect.ValueOf(tt.value))
+			if err := decodeValue(dst, nil); err != nil {
+				t.Fatal(err)
+			}
+			if !dst.IsZero() {
+				t.Errorf("got %v, want the zero value", dst.Interface())
+			}
+		})
+	}
+
+	var value interface{} = name
+	if err := decodeValue(reflect.ValueOf(&value).Elem(), nil); err != nil || value != nil {
+		t.Errorf("got %v, %v for an interface, want nil", value, err)
+	}
+
+	lead := mappedLead{Name: name, Active: true, Partner: Many2one{ID: 1}}
+	if err := DecodeRecord(map[string]interface{}{"active": nil, "name": nil, "partner_id": nil}, &lead); err != nil {
+		t.Fatal(err)
+	}
+	if lead.Name != "" || lead.Active || lead.Partner.ID != 0 {
+		t.Errorf("got %+v, want the fields reset", lead)
+	}
+}
+
+func TestSearchReadIntoRetries(t *testing.T) {
+	calls := 0
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls++
+		if calls == 1 {
+			return httpStatus(503)
+		}
+		return []map[string]interface{}{
+			{"id": 7, "name": "Office chairs", "partner_id": []interface{}{3, "Azure Interior"}, "active": nil},
+		}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithRetry(RetryPolicy{MaxAttempts: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var leads []mappedLead
+	if err := c.SearchReadInto("crm.lead", SearchReadOptions{}, &leads); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("search_read was called %d times, want 2", calls)
+	}
+	want := []mappedLead{{ID: 7, Name: "Office chairs", Partner: Many2one{ID: 3, Name: "Azure Interior"}}}
+	if !reflect.DeepEqual(leads, want) {
+		t.Errorf("got  %+v\nwant %+v", leads, want)
+	}
+}
+
+func TestSearchReadIntoFields(t *testing.T) {
+	var fields interface{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		fields = args[6].(map[string]interface{})["fields"]
+		return []interface{}{}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var leads []struct {
+		ID   int64  `odoo:"id"`
+		Name string `odoo:"name"`
+	}
+	if err := c.SearchReadInto("crm.lead", SearchReadOptions{}, &leads); err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{"id", "name"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if err := c.SearchReadInto("crm.lead", SearchReadOptions{AllFields: true}, &leads); err != nil {
+		t.Fatal(err)
+	}
+	if fields, _ := fields.([]interface{}); len(fields) != 0 {
+		t.Errorf("fields = %v, want all fields", fields)
+	}
+}
@@ -0,0 +1,51 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// SearchReadMerged runs several searches against the same model, e.g. the
+// domains of multiple saved filters, and combines their results. Records
+// matched by more than one query are returned once, holding the union of the
+// fields read by those queries. Records keep the order in which they were
+// first seen.
+func (c *Connector) SearchReadMerged(ctx context.Context, model string, queries ...SearchReadOptions) ([]map[string]interface{}, error) {
+	var merged []map[string]interface{}
+	byID := make(map[int64]map[string]interface{})
+
+	for i, opts := range queries {
+		if opts.Domain == nil {
+			opts.Domain = []interface{}{}
+		}
+		var records []map[string]interface{}
+		err := c.execute(ctx, model, "search_read", []interface{}{opts.Domain}, map[string]interface{}{
+			"fields": opts.Fields,
+			"offset": opts.Offset,
+			"limit":  opts.Limit,
+			"order":  opts.Order,
+		}, &records)
+		if err != nil {
+			return nil, fmt.Errorf("search_read failed for model %s in query %d: %w", model, i, err)
+		}
+
+		for _, record := range records {
+			id, ok := toInt64(record["id"])
+			if !ok {
+				return nil, fmt.Errorf("search_read failed for model %s in query %d: record without id", model, i)
+			}
+			existing, seen := byID[id]
+			if !seen {
+				byID[id] = record
+				merged = append(merged, record)
+				continue
+			}
+			for field, value := range record {
+				if _, ok := existing[field]; !ok {
+					existing[field] = value
+				}
+			}
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,71 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+func TestSearchReadMerged(t *testing.T) {
+	var domains []interface{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		domain := args[5].([]interface{})[0].([]interface{})
+		domains = append(domains, domain)
+		if len(domain) == 0 {
+			return []map[string]interface{}{{"id": 1, "name": "Azure"}, {"id": 2, "name": "Gemini"}}
+		}
+		switch domain[0].([]interface{})[0] {
+		case "email":
+			return []map[string]interface{}{{"id": 3, "email": "deco@example.com"}, {"id": 2, "email": "gemini@example.com", "name": "ignored"}}
+		case "broken":
+			return []map[string]interface{}{{"name": "no id"}}
+		}
+		return xmlrpc.FaultError{Code: 1, String: "Odoo Server Error"}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	records, err := c.SearchReadMerged(ctx, "res.partner",
+		SearchReadOptions{Fields: []string{"name"}},
+		SearchReadOptions{Fields: []string{"email"}, Domain: []interface{}{[]interface{}{"email", "!=", false}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Records keep their first position and the values read first
+	want := []map[string]interface{}{
+		{"id": int64(1), "name": "Azure"},
+		{"id": int64(2), "name": "Gemini", "email": "gemini@example.com"},
+		{"id": int64(3), "email": "deco@example.com"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %v, want %v", records, want)
+	}
+	if len(domains) != 2 || domains[0] == nil {
+		t.Errorf("domains %v", domains)
+	}
+
+	for _, tc := range []struct {
+		field, err string
+	}{
+		{"broken", "query 1: record without id"},
+		{"failing", "query 1: "},
+	} {
+		_, err := c.SearchReadMerged(ctx, "res.partner",
+			SearchReadOptions{},
+			SearchReadOptions{Domain: []interface{}{[]interface{}{tc.field, "=", true}}},
+		)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: got %v, want an error containing %q", tc.field, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package odoo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// MirrorModel selects a model to mirror
+type MirrorModel struct {
+	Model string
+	// Fields to mirror; all stored, non-binary fields when empty
+	Fields []string
+	// Domain restricts the mirrored records
+	Domain []interface{}
+}
+
+// Mirror materializes models into a local SQLite database so reporting tools
+// can query them with SQL. Each model is stored in a table named after it
+// (res.partner becomes res_partner) with columns derived from fields_get.
+// Refresh applies the changes since the previous refresh, tracked in the
+// _odoo_sync table.
+//
+// The database is opened by the caller, who also imports the SQLite driver.
+// Upserts require SQLite 3.24 or later.
+//
+// Mirror is also a Sink, so Export and SyncTo can write into it directly.
+type Mirror struct {
+	*sqlReplica
+}
+
+// NewMirror returns a mirror of models into db
+func (c *Connector) NewMirror(db *sql.DB, models ...MirrorModel) *Mirror {
+	return &Mirror{&sqlReplica{c: c, db: db, dialect: sqliteDialect{}, models: models}}
+}
+
+// Refresh brings every mirrored model up to date
+func (m *Mirror) Refresh(ctx context.Context) error {
+	if err := m.refresh(ctx); err != nil {
+		return fmt.Errorf("mirror refresh failed: %w", err)
+	}
+	return nil
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) placeholder(int) string { return "?" }
+
+func (sqliteDialect) columnType(fieldType string) string {
+	switch fieldType {
+	case "integer", "many2one", "many2one_reference", "boolean":
+		return "INTEGER"
+	case "float", "monetary":
+		return "REAL"
+	}
+	return "TEXT"
+}
+
+func (sqliteDialect) table(name string) string { return quoteIdent(name) }
+
+func (sqliteDialect) prepare(context.Context, *sql.DB) error { return nil }
+
+func (sqliteDialect) columns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`PRAGMA table_info(%s)`, quoteIdent(table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    bool
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultVal, &pk); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
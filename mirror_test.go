@@ -0,0 +1,345 @@
+package odoo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSQL is an in-memory database understanding just the statements the
+// SQL replicas issue. Writes of a transaction are applied on commit.
+type fakeSQL struct {
+	mu         sync.Mutex
+	statements []string
+	columns    map[string][]string
+	rows       map[string]map[int64]map[string]driver.Value
+	states     map[string]string
+	// failOn makes statements containing it fail
+	failOn string
+}
+
+func newFakeSQL() *fakeSQL {
+	return &fakeSQL{columns: map[string][]string{}, rows: map[string]map[int64]map[string]driver.Value{}, states: map[string]string{}}
+}
+
+func (db *fakeSQL) open() *sql.DB { return sql.OpenDB(db) }
+
+// Connect implements driver.Connector
+func (db *fakeSQL) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+
+// Driver implements driver.Connector
+func (db *fakeSQL) Driver() driver.Driver { return nil }
+
+// row returns the row of table with the given id, or nil
+func (db *fakeSQL) row(table string, id int64) map[string]driver.Value {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.rows[table][id]
+}
+
+// ids returns the ids of the rows of table
+func (db *fakeSQL) ids(table string) []int64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	ids := []int64{}
+	for id := range db.rows[table] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// executed returns the statements matching prefix
+func (db *fakeSQL) executed(prefix string) []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var statements []string
+	for _, s := range db.statements {
+		if strings.HasPrefix(s, prefix) {
+			statements = append(statements, s)
+		}
+	}
+	return statements
+}
+
+var (
+	createTableRe = regexp.MustCompile(`^CREATE TABLE (?:IF NOT EXISTS )?(\S+) \((.*)\)$`)
+	alterTableRe  = regexp.MustCompile(`^ALTER TABLE (\S+) ADD COLUMN "([^"]+)"`)
+	insertRe      = regexp.MustCompile(`^INSERT INTO (\S+) \(([^)]*)\)`)
+	deleteRe      = regexp.MustCompile(`^DELETE FROM (\S+) WHERE id`)
+	softDeleteRe  = regexp.MustCompile(`^UPDATE (\S+) SET _deleted_at = CURRENT_TIMESTAMP WHERE id`)
+	pragmaRe      = regexp.MustCompile(`^PRAGMA table_info\((\S+)\)$`)
+)
+
+// tableKey strips the quotes of a possibly qualified table name
+func tableKey(name string) string {
+	return strings.ReplaceAll(name, `"`, "")
+}
+
+// exec applies a write statement
+func (db *fakeSQL) exec(query string, args []driver.NamedValue) {
+	switch {
+	case createTableRe.MatchString(query):
+		m := createTableRe.FindStringSubmatch(query)
+		table := tableKey(m[1])
+		if _, ok := db.columns[table]; ok {
+			return
+		}
+		for _, column := range strings.Split(m[2], ", ") {
+			db.columns[table] = append(db.columns[table], tableKey(strings.Fields(column)[0]))
+		}
+		db.rows[table] = map[int64]map[string]driver.Value{}
+	case alterTableRe.MatchString(query):
+		m := alterTableRe.FindStringSubmatch(query)
+		db.columns[tableKey(m[1])] = append(db.columns[tableKey(m[1])], m[2])
+	case insertRe.MatchString(query):
+		m := insertRe.FindStringSubmatch(query)
+		table := tableKey(m[1])
+		row := map[string]driver.Value{}
+		for i, column := range strings.Split(m[2], ", ") {
+			row[tableKey(column)] = args[i].Value
+		}
+		if strings.HasSuffix(table, "_odoo_sync") {
+			db.states[row["model"].(string)] = row["state"].(string)
+			return
+		}
+		db.rows[table][row["id"].(int64)] = row
+	case deleteRe.MatchString(query):
+		delete(db.rows[tableKey(deleteRe.FindStringSubmatch(query)[1])], args[0].Value.(int64))
+	case softDeleteRe.MatchString(query):
+		if row := db.rows[tableKey(softDeleteRe.FindStringSubmatch(query)[1])][args[0].Value.(int64)]; row != nil {
+			row["_deleted_at"] = "now"
+		}
+	}
+}
+
+// query answers the metadata and checkpoint queries
+func (db *fakeSQL) query(query string, args []driver.NamedValue) *fakeRows {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	rows := &fakeRows{}
+	switch {
+	case pragmaRe.MatchString(query):
+		rows.columns = []string{"cid", "name", "type", "notnull", "dflt_value", "pk"}
+		for i, column := range db.columns[tableKey(pragmaRe.FindStringSubmatch(query)[1])] {
+			rows.values = append(rows.values, []driver.Value{int64(i), column, "TEXT", false, nil, int64(0)})
+		}
+	case strings.Contains(query, "information_schema.columns"):
+		rows.columns = []string{"column_name"}
+		for _, column := range db.columns[args[0].Value.(string)+"."+args[1].Value.(string)] {
+			rows.values = append(rows.values, []driver.Value{column})
+		}
+	case strings.HasPrefix(query, "SELECT state FROM"):
+		rows.columns = []string{"state"}
+		if state, ok := db.states[args[0].Value.(string)]; ok {
+			rows.values = append(rows.values, []driver.Value{state})
+		}
+	default:
+		panic("unexpected query " + query)
+	}
+	return rows
+}
+
+// state returns the stored sync state of model
+func (db *fakeSQL) state(model string) SyncState {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var state SyncState
+	json.Unmarshal([]byte(db.states[model]), &state)
+	return state
+}
+
+type fakeConn struct {
+	db      *fakeSQL
+	pending []func()
+	inTx    bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	for _, apply := range c.pending {
+		apply()
+	}
+	c.db.statements = append(c.db.statements, "COMMIT")
+	c.pending, c.inTx = nil, false
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.statements = append(c.db.statements, "ROLLBACK")
+	c.pending, c.inTx = nil, false
+	return nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.statements = append(c.db.statements, query)
+	if c.db.failOn != "" && strings.Contains(fmt.Sprint(query, args), c.db.failOn) {
+		return nil, fmt.Errorf("statement failed")
+	}
+	if c.inTx {
+		c.pending = append(c.pending, func() { c.db.exec(query, args) })
+	} else {
+		c.db.exec(query, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.db.query(query, args), nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	panic("ExecContext is used")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	panic("QueryContext is used")
+}
+
+func (s *fakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestMirror(t *testing.T) {
+	m := &syncModel{}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	m.set(2, "Gemini", "2024-01-01 11:00:00", "2024-01-01 11:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newFakeSQL()
+	mirror := c.NewMirror(db.open(), MirrorModel{Model: "res.partner"})
+	ctx := context.Background()
+
+	if err := mirror.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Binary and computed fields are left out
+	wantStatements := []string{
+		`CREATE TABLE "res_partner" ("id" INTEGER PRIMARY KEY, "create_date" TEXT, "name" TEXT, "write_date" TEXT)`,
+		`INSERT INTO "res_partner" ("id", "create_date", "name", "write_date") VALUES (?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET "create_date" = excluded."create_date", "name" = excluded."name", "write_date" = excluded."write_date"`,
+	}
+	for _, want := range wantStatements {
+		if got := db.executed(want); len(got) == 0 {
+			t.Errorf("missing statement %s in %q", want, db.statements)
+		}
+	}
+	if ids := db.ids("res_partner"); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("rows %v after the first refresh", ids)
+	}
+	want := SyncState{WriteDate: "2024-01-01 11:00:00", LastID: 2, IDs: []int64{1, 2}}
+	if state := db.state("res.partner"); !reflect.DeepEqual(state, want) {
+		t.Errorf("state = %+v, want %+v", state, want)
+	}
+
+	// The next refresh reads from the checkpoint, applies updates and
+	// removes deleted records
+	m.set(1, "Azure Interior", "2024-01-01 10:00:00", "2024-01-02 09:00:00")
+	m.remove(2)
+	if err := mirror.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if domain := fmt.Sprint(m.reads[len(m.reads)-1]); !strings.Contains(domain, "2024-01-01 11:00:00") {
+		t.Errorf("refresh did not resume from the checkpoint: %s", domain)
+	}
+	if ids := db.ids("res_partner"); !reflect.DeepEqual(ids, []int64{1}) {
+		t.Errorf("rows %v after the second refresh", ids)
+	}
+	if row := db.row("res_partner", 1); row["name"] != "Azure Interior" || row["write_date"] != "2024-01-02 09:00:00" {
+		t.Errorf("row 1 = %v", row)
+	}
+	if n := len(db.executed("CREATE TABLE \"res_partner\"")); n != 1 {
+		t.Errorf("table created %d times", n)
+	}
+}
+
+func TestMirrorResume(t *testing.T) {
+	m := &syncModel{}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newFakeSQL()
+	mirror := c.NewMirror(db.open(), MirrorModel{Model: "res.partner", Fields: []string{"name"}})
+	ctx := context.Background()
+	if err := mirror.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint := db.state("res.partner")
+
+	// A failing batch is rolled back and the checkpoint stays put
+	m.set(2, "Gemini", "2024-01-02 10:00:00", "2024-01-02 10:00:00")
+	m.set(3, "Deco", "2024-01-02 11:00:00", "2024-01-02 11:00:00")
+	db.failOn = "Deco"
+	if err := mirror.Refresh(ctx); err == nil {
+		t.Fatal("refresh did not fail")
+	}
+	if ids := db.ids("res_partner"); !reflect.DeepEqual(ids, []int64{1}) {
+		t.Errorf("rows %v after the failed refresh", ids)
+	}
+	if state := db.state("res.partner"); !reflect.DeepEqual(state, checkpoint) {
+		t.Errorf("state = %+v after the failed refresh, want %+v", state, checkpoint)
+	}
+
+	db.failOn = ""
+	if err := mirror.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ids := db.ids("res_partner"); !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+		t.Errorf("rows %v after resuming", ids)
+	}
+}
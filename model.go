@@ -0,0 +1,239 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Model gives access to the records of one model without repeating its
+// name on every call:
+//
+//	partners := c.Model("res.partner")
+//	n, err := partners.SearchCount(odoo.NewDomain(odoo.Eq("is_company", true)))
+//	records, err := partners.Browse(ids...).Read("name", "email")
+type Model struct {
+	c    *Connector
+	name string
+}
+
+// Model returns the model with the given technical name
+func (c *Connector) Model(name string) *Model {
+	return &Model{c: c, name: name}
+}
+
+// Name returns the technical name of the model
+func (m *Model) Name() string {
+	return m.name
+}
+
+// Search returns the IDs of the records matching opts; opts.Fields is
+// ignored
+func (m *Model) Search(opts SearchReadOptions) ([]int64, error) {
+	return m.SearchContext(context.Background(), opts)
+}
+
+// SearchContext is Search with a context
+func (m *Model) SearchContext(ctx context.Context, opts SearchReadOptions) ([]int64, error) {
+	aliases := m.c.aliasesOf(m.name)
+	domain := aliases.domain(opts.Domain)
+	if domain == nil {
+		domain = []interface{}{}
+	}
+	kwargs := map[string]interface{}{
+		"offset": opts.Offset,
+		"limit":  opts.Limit,
+		"order":  aliases.order(opts.Order),
+	}
+	var ids []int64
+	if err := m.c.execute(ctx, m.name, "search", []interface{}{domain}, kwargs, &ids); err != nil {
+		return nil, fmt.Errorf("search failed for model %s: %w", m.name, err)
+	}
+	return ids, nil
+}
+
+// SearchRead is SearchReadRecords on the model
+func (m *Model) SearchRead(opts SearchReadOptions) ([]map[string]interface{}, error) {
+	return m.c.SearchReadRecordsContext(context.Background(), m.name, opts)
+}
+
+// SearchReadContext is SearchRead with a context
+func (m *Model) SearchReadContext(ctx context.Context, opts SearchReadOptions) ([]map[string]interface{}, error) {
+	return m.c.SearchReadRecordsContext(ctx, m.name, opts)
+}
+
+// SearchCount returns the number of records matching domain
+func (m *Model) SearchCount(domain []interface{}) (int64, error) {
+	return m.SearchCountContext(context.Background(), domain)
+}
+
+// SearchCountContext is SearchCount with a context
+func (m *Model) SearchCountContext(ctx context.Context, domain []interface{}) (int64, error) {
+	domain = m.c.aliasesOf(m.name).domain(domain)
+	if domain == nil {
+		domain = []interface{}{}
+	}
+	var count int64
+	if err := m.c.execute(ctx, m.name, "search_count", []interface{}{domain}, nil, &count); err != nil {
+		return 0, fmt.Errorf("search_count failed for model %s: %w", m.name, err)
+	}
+	return count, nil
+}
+
+// Read reads fields (all fields when none are given) of the records with
+// the given ids, in the order of ids
+func (m *Model) Read(ids []int64, fields ...string) ([]map[string]interface{}, error) {
+	return m.ReadContext(context.Background(), ids, fields...)
+}
+
+// ReadContext is Read with a context
+func (m *Model) ReadContext(ctx context.Context, ids []int64, fields ...string) ([]map[string]interface{}, error) {
+	aliases := m.c.aliasesOf(m.name)
+	kwargs := map[string]interface{}{}
+	if len(fields) > 0 {
+		kwargs["fields"] = aliases.fields(fields)
+	}
+	var records []map[string]interface{}
+	if err := m.c.execute(ctx, m.name, "read", []interface{}{ids}, kwargs, &records); err != nil {
+		return nil, fmt.Errorf("read failed for model %s: %w", m.name, err)
+	}
+	for i, record := range records {
+		records[i] = aliases.record(record)
+	}
+	return records, nil
+}
+
+// NameSearch returns the records whose display name matches term, at most
+// limit (Odoo's default of 100 when zero), as ID and display name
+func (m *Model) NameSearch(term string, limit int) ([]Many2one, error) {
+	return m.NameSearchContext(context.Background(), term, limit)
+}
+
+// NameSearchContext is NameSearch with a context
+func (m *Model) NameSearchContext(ctx context.Context, term string, limit int) ([]Many2one, error) {
+	kwargs := map[string]interface{}{"name": term}
+	if limit > 0 {
+		kwargs["limit"] = limit
+	}
+	var pairs []interface{}
+	if err := m.c.execute(ctx, m.name, "name_search", []interface{}{}, kwargs, &pairs); err != nil {
+		return nil, fmt.Errorf("name_search failed for model %s: %w", m.name, err)
+	}
+	matches := make([]Many2one, 0, len(pairs))
+	for _, pair := range pairs {
+		id, ok := many2oneID(pair)
+		if !ok {
+			return nil, fmt.Errorf("name_search failed for model %s: unexpected result %v", m.name, pair)
+		}
+		match := Many2one{ID: id}
+		if p, ok := pair.([]interface{}); ok && len(p) == 2 {
+			match.Name, _ = p[1].(string)
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// FieldsGet returns the field definitions of the model, see
+// Connector.FieldsGet
+func (m *Model) FieldsGet(attributes ...string) (map[string]map[string]interface{}, error) {
+	return m.c.FieldsGet(context.Background(), m.name, attributes...)
+}
+
+// FieldsGetContext is FieldsGet with a context
+func (m *Model) FieldsGetContext(ctx context.Context, attributes ...string) (map[string]map[string]interface{}, error) {
+	return m.c.FieldsGet(ctx, m.name, attributes...)
+}
+
+// Create creates a record and returns it as a record set
+func (m *Model) Create(values map[string]interface{}) (*RecordSet, error) {
+	return m.CreateContext(context.Background(), values)
+}
+
+// CreateContext is Create with a context
+func (m *Model) CreateContext(ctx context.Context, values map[string]interface{}) (*RecordSet, error) {
+	id, err := m.c.CreateRecordContext(ctx, m.name, values)
+	if err != nil {
+		return nil, err
+	}
+	return m.Browse(id), nil
+}
+
+// Browse returns the record set of the given ids without reading them
+func (m *Model) Browse(ids ...int64) *RecordSet {
+	return &RecordSet{model: m, ids: ids}
+}
+
+// RecordSet is a set of records of one model, on which reads and writes
+// apply to all records at once
+type RecordSet struct {
+	model *Model
+	ids   []int64
+}
+
+// Model returns the model of the records
+func (rs *RecordSet) Model() *Model {
+	return rs.model
+}
+
+// IDs returns the IDs of the records
+func (rs *RecordSet) IDs() []int64 {
+	return rs.ids
+}
+
+// Len returns the number of records
+func (rs *RecordSet) Len() int {
+	return len(rs.ids)
+}
+
+// Read reads fields (all fields when none are given) of the records
+func (rs *RecordSet) Read(fields ...string) ([]map[string]interface{}, error) {
+	return rs.model.ReadContext(context.Background(), rs.ids, fields...)
+}
+
+// ReadContext is Read with a context
+func (rs *RecordSet) ReadContext(ctx context.Context, fields ...string) ([]map[string]interface{}, error) {
+	return rs.model.ReadContext(ctx, rs.ids, fields...)
+}
+
+// Write writes values to all records
+func (rs *RecordSet) Write(values map[string]interface{}) error {
+	return rs.WriteContext(context.Background(), values)
+}
+
+// WriteContext is Write with a context
+func (rs *RecordSet) WriteContext(ctx context.Context, values map[string]interface{}) error {
+	if len(rs.ids) == 0 {
+		return nil
+	}
+	m := rs.model
+	var result bool
+	err := m.c.execute(ctx, m.name, "write", []interface{}{rs.ids, m.c.aliasesOf(m.name).values(values)}, nil, &result)
+	if err != nil {
+		return fmt.Errorf("update failed for model %s with ids %v: %w", m.name, rs.ids, err)
+	}
+	if !result {
+		return fmt.Errorf("update failed for model %s with ids %v: %w", m.name, rs.ids, errNoRecordUpdated)
+	}
+	return nil
+}
+
+// Unlink deletes all records
+func (rs *RecordSet) Unlink() error {
+	return rs.UnlinkContext(context.Background())
+}
+
+// UnlinkContext is Unlink with a context
+func (rs *RecordSet) UnlinkContext(ctx context.Context) error {
+	if len(rs.ids) == 0 {
+		return nil
+	}
+	m := rs.model
+	var result bool
+	if err := m.c.execute(ctx, m.name, "unlink", []interface{}{rs.ids}, nil, &result); err != nil {
+		return fmt.Errorf("delete failed for model %s with ids %v: %w", m.name, rs.ids, err)
+	}
+	if !result {
+		return fmt.Errorf("delete failed for model %s with ids %v: %w", m.name, rs.ids, errNoRecordDeleted)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModel(t *testing.T) {
+	var calls [][]interface{}
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls = append(calls, args[3:])
+		switch args[4] {
+		case "search_count":
+			return 3
+		case "read":
+			return []map[string]interface{}{{"id": 1, "name": "Azure Interior"}, {"id": 2, "name": "Deco Addict"}}
+		case "name_search":
+			return [][]interface{}{{1, "Azure Interior"}}
+		}
+		return true
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	partners := c.Model("res.partner")
+
+	count, err := partners.SearchCount(NewDomain(Eq("is_company", true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+
+	records, err := partners.Browse(1, 2).Read("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1]["name"] != "Deco Addict" {
+		t.Errorf("records = %v", records)
+	}
+
+	matches, err := partners.NameSearch("azure", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Many2one{{ID: 1, Name: "Azure Interior"}}; !reflect.DeepEqual(matches, want) {
+		t.Errorf("matches = %v, want %v", matches, want)
+	}
+
+	err = partners.Browse(1).Write(map[string]interface{}{
+		"category_id": Commands(CommandSet([]int64{4, 5}), CommandLink(6)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := calls[len(calls)-1][2].([]interface{})[1].(map[string]interface{})
+	want := []interface{}{
+		[]interface{}{6.0, 0.0, []interface{}{4.0, 5.0}},
+		[]interface{}{4.0, 6.0, 0.0},
+	}
+	if !reflect.DeepEqual(written["category_id"], want) {
+		t.Errorf("category_id = %v, want %v", written["category_id"], want)
+	}
+
+	if err := partners.Browse().Unlink(); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls[len(calls)-1][1]; got != "write" {
+		t.Errorf("unlinking an empty record set called %v", got)
+	}
+}
@@ -0,0 +1,125 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ModuleGraph is the dependency graph of the installed modules
+type ModuleGraph struct {
+	// Dependencies maps every installed module to the modules it directly
+	// depends on.
+	Dependencies map[string][]string
+}
+
+// ModuleDependencyGraph builds the dependency graph of the installed modules
+// from ir.module.module.dependency, so deployment tooling can compute safe
+// install and upgrade orders.
+func (c *Connector) ModuleDependencyGraph(ctx context.Context) (*ModuleGraph, error) {
+	modules, err := c.ListModules(ctx, ModuleInstalled)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(modules))
+	ids := make([]int64, 0, len(modules))
+	graph := &ModuleGraph{Dependencies: make(map[string][]string, len(modules))}
+	for _, module := range modules {
+		names[module.ID] = module.Name
+		ids = append(ids, module.ID)
+		graph.Dependencies[module.Name] = nil
+	}
+
+	var records []map[string]interface{}
+	err = c.execute(ctx, "ir.module.module.dependency", "search_read", []interface{}{[]interface{}{
+		[]interface{}{"module_id", "in", ids},
+	}}, map[string]interface{}{
+		"fields": []string{"name", "module_id"},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("module dependency lookup failed: %w", err)
+	}
+
+	for _, record := range records {
+		moduleID, _ := many2oneID(record["module_id"])
+		dependency, _ := record["name"].(string)
+		module, ok := names[moduleID]
+		if !ok || dependency == "" {
+			continue
+		}
+		graph.Dependencies[module] = append(graph.Dependencies[module], dependency)
+	}
+	for _, deps := range graph.Dependencies {
+		sort.Strings(deps)
+	}
+	return graph, nil
+}
+
+// InstallOrder returns the given modules together with all their transitive
+// dependencies, ordered so that every module comes after its dependencies.
+// Modules unknown to the graph are returned as leaves.
+func (g *ModuleGraph) InstallOrder(names ...string) ([]string, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var order []string
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("module dependency cycle: %v", append(path, name))
+		}
+		state[name] = visiting
+		for _, dep := range g.Dependencies[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		order = append(order, name)
+		return nil
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Dependents returns the installed modules that directly or indirectly depend
+// on name, i.e. the modules affected when name is upgraded or uninstalled
+func (g *ModuleGraph) Dependents(name string) []string {
+	reverse := make(map[string][]string)
+	for module, deps := range g.Dependencies {
+		for _, dep := range deps {
+			reverse[dep] = append(reverse[dep], module)
+		}
+	}
+
+	seen := map[string]bool{name: true}
+	queue := []string{name}
+	var dependents []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, module := range reverse[current] {
+			if !seen[module] {
+				seen[module] = true
+				dependents = append(dependents, module)
+				queue = append(queue, module)
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
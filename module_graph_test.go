@@ -0,0 +1,75 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestModuleDependencyGraph(t *testing.T) {
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		switch args[3] {
+		case "ir.module.module":
+			return []map[string]interface{}{
+				{"id": 1, "name": "base", "state": "installed"},
+				{"id": 2, "name": "mail", "state": "installed"},
+				{"id": 3, "name": "sale", "state": "installed"},
+			}
+		case "ir.module.module.dependency":
+			return []map[string]interface{}{
+				{"name": "mail", "module_id": []interface{}{3, "sale"}},
+				{"name": "base", "module_id": []interface{}{2, "mail"}},
+				{"name": "base", "module_id": []interface{}{3, "sale"}},
+				// Dependencies of modules that are not installed are ignored
+				{"name": "base", "module_id": []interface{}{9, "crm"}},
+			}
+		}
+		return false
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graph, err := c.ModuleDependencyGraph(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"base": nil, "mail": {"base"}, "sale": {"base", "mail"}}
+	if !reflect.DeepEqual(graph.Dependencies, want) {
+		t.Errorf("got %v, want %v", graph.Dependencies, want)
+	}
+}
+
+func TestModuleGraph(t *testing.T) {
+	graph := &ModuleGraph{Dependencies: map[string][]string{
+		"base":    nil,
+		"mail":    {"base"},
+		"product": {"base"},
+		"sale":    {"mail", "product"},
+		"stock":   {"product"},
+	}}
+
+	order, err := graph.InstallOrder("stock", "sale", "custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"custom", "base", "mail", "product", "sale", "stock"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("install order %v, want %v", order, want)
+	}
+
+	if got, want := graph.Dependents("product"), []string{"sale", "stock"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dependents of product %v, want %v", got, want)
+	}
+	if got, want := graph.Dependents("base"), []string{"mail", "product", "sale", "stock"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dependents of base %v, want %v", got, want)
+	}
+
+	graph.Dependencies["base"] = []string{"sale"}
+	if _, err := graph.InstallOrder("sale"); err == nil {
+		t.Error("dependency cycle was not detected")
+	}
+}
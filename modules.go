@@ -0,0 +1,211 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Module states as stored on ir.module.module
+const (
+	ModuleInstalled     = "installed"
+	ModuleUninstalled   = "uninstalled"
+	ModuleUninstallable = "uninstallable"
+	ModuleToInstall     = "to install"
+	ModuleToUpgrade     = "to upgrade"
+	ModuleToRemove      = "to remove"
+)
+
+// Module is an ir.module.module record
+type Module struct {
+	ID               int64
+	Name             string
+	Title            string
+	State            string
+	InstalledVersion string
+	LatestVersion    string
+
+	// writeDate tells an upgraded module from one that was not upgraded yet
+	writeDate string
+}
+
+var moduleFields = []string{"name", "shortdesc", "state", "installed_version", "latest_version", "write_date"}
+
+// ListModules returns the modules known to the server, optionally limited to
+// the given states (e.g. ModuleInstalled)
+func (c *Connector) ListModules(ctx context.Context, states ...string) ([]Module, error) {
+	domain := []interface{}{}
+	if len(states) > 0 {
+		domain = append(domain, []interface{}{"state", "in", states})
+	}
+	return c.searchModules(ctx, domain)
+}
+
+func (c *Connector) searchModules(ctx context.Context, domain []interface{}) ([]Module, error) {
+	var records []map[string]interface{}
+	err := c.execute(ctx, "ir.module.module", "search_read", []interface{}{domain}, map[string]interface{}{
+		"fields": moduleFields,
+		"order":  "name",
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("module listing failed: %w", err)
+	}
+
+	modules := make([]Module, 0, len(records))
+	for _, record := range records {
+		module := Module{}
+		module.ID, _ = toInt64(record["id"])
+		module.Name, _ = record["name"].(string)
+		module.Title, _ = record["shortdesc"].(string)
+		module.State, _ = record["state"].(string)
+		module.InstalledVersion, _ = record["installed_version"].(string)
+		module.LatestVersion, _ = record["latest_version"].(string)
+		module.writeDate, _ = record["write_date"].(string)
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// InstallModules installs the named modules and their dependencies, then
+// waits until all of them are reported installed
+func (c *Connector) InstallModules(ctx context.Context, names ...string) error {
+	return c.moduleOperation(ctx, "install", "button_immediate_install", names, func(_, module Module) bool {
+		return module.State == ModuleInstalled
+	})
+}
+
+// UpgradeModules upgrades the named modules, then waits until all of them are
+// reported installed again. As they are installed before the upgrade too, a
+// module counts as upgraded once its record was written since the call.
+func (c *Connector) UpgradeModules(ctx context.Context, names ...string) error {
+	return c.moduleOperation(ctx, "upgrade", "button_immediate_upgrade", names, func(before, module Module) bool {
+		return module.State == ModuleInstalled && module.writeDate != before.writeDate
+	})
+}
+
+// UninstallModules uninstalls the named modules and the modules depending on
+// them, then waits until all of them are reported uninstalled
+func (c *Connector) UninstallModules(ctx context.Context, names ...string) error {
+	return c.moduleOperation(ctx, "uninstall", "button_immediate_uninstall", names, func(_, module Module) bool {
+		return module.State == ModuleUninstalled
+	})
+}
+
+// moduleOperation calls method on the named modules and waits until done
+// reports every module finished, given its state before the call
+func (c *Connector) moduleOperation(ctx context.Context, operation, method string, names []string, done func(before, module Module) bool) error {
+	modules, err := c.searchModules(ctx, []interface{}{[]interface{}{"name", "in", names}})
+	if err != nil {
+		return fmt.Errorf("module %s failed: %w", operation, err)
+	}
+	if len(modules) != len(names) {
+		return fmt.Errorf("module %s failed: %w", operation, missingModules(names, modules))
+	}
+
+	ids := make([]int64, 0, len(modules))
+	before := make(map[string]Module, len(modules))
+	for _, module := range modules {
+		ids = append(ids, module.ID)
+		before[module.Name] = module
+	}
+
+	ctx, cancel := withModuleTimeout(ctx)
+	defer cancel()
+
+	// The immediate buttons reload the registry within the request. A reverse
+	// proxy may time out before that finishes, so completion is verified by
+	// polling the module states rather than relying on the response. Faults
+	// raised by the server itself (e.g. access errors) are final.
+	var result interface{}
+	callErr := c.execute(ctx, "ir.module.module", method, []interface{}{ids}, nil, &result)
+	var fault *Error
+	if errors.As(callErr, &fault) {
+		return fmt.Errorf("module %s failed: %w", operation, callErr)
+	}
+	err = c.waitForModules(ctx, names, func(module Module) bool {
+		return done(before[module.Name], module)
+	})
+	if err != nil {
+		if callErr != nil {
+			return fmt.Errorf("module %s failed: %w", operation, callErr)
+		}
+		return fmt.Errorf("module %s failed: modules %v did not finish: %w", operation, names, err)
+	}
+	return nil
+}
+
+// modulePollInterval is the delay between two state checks of WaitForModules
+var modulePollInterval = 2 * time.Second
+
+// moduleTimeout bounds module operations and WaitForModules when the context
+// has no deadline of its own
+const moduleTimeout = 30 * time.Minute
+
+func withModuleTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, moduleTimeout)
+}
+
+// WaitForModules polls the named modules until all of them are in the given
+// state, or ctx is done. Without a deadline on ctx it gives up after 30
+// minutes.
+func (c *Connector) WaitForModules(ctx context.Context, state string, names ...string) error {
+	ctx, cancel := withModuleTimeout(ctx)
+	defer cancel()
+
+	err := c.waitForModules(ctx, names, func(module Module) bool {
+		return module.State == state
+	})
+	if err != nil {
+		return fmt.Errorf("modules %v did not reach state %q: %w", names, state, err)
+	}
+	return nil
+}
+
+// waitForModules polls the named modules until done reports all of them
+// finished, or ctx is done
+func (c *Connector) waitForModules(ctx context.Context, names []string, done func(Module) bool) error {
+	ticker := time.NewTicker(modulePollInterval)
+	defer ticker.Stop()
+
+	for {
+		modules, err := c.searchModules(ctx, []interface{}{[]interface{}{"name", "in", names}})
+		if err == nil {
+			finished := len(modules) == len(names)
+			for _, module := range modules {
+				if !done(module) {
+					finished = false
+				}
+			}
+			if finished {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func missingModules(names []string, modules []Module) error {
+	found := make(map[string]bool, len(modules))
+	for _, module := range modules {
+		found[module.Name] = true
+	}
+	var missing []string
+	for _, name := range names {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	return fmt.Errorf("unknown modules %v", missing)
+}
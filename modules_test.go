@@ -0,0 +1,111 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpgradeModules(t *testing.T) {
+	defer func(interval time.Duration) { modulePollInterval = interval }(modulePollInterval)
+	modulePollInterval = time.Millisecond
+
+	// The upgrade runs on after the proxy gave up on the request: the module
+	// stays installed at first, is marked to upgrade, then written installed
+	state, writeDate := ModuleInstalled, "2024-01-01 00:00:00"
+	polls := 0
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		switch args[4] {
+		case "button_immediate_upgrade":
+			return httpStatus(504)
+		case "search_read":
+			polls++
+			switch polls {
+			case 3:
+				state, writeDate = ModuleToUpgrade, "2024-05-01 10:00:00"
+			case 5:
+				state, writeDate = ModuleInstalled, "2024-05-01 10:00:07"
+			}
+			return []map[string]interface{}{{
+				"id": 4, "name": "sale", "shortdesc": "Sales", "state": state,
+				"installed_version": "17.0.1.2", "latest_version": "17.0.1.2", "write_date": writeDate,
+			}}
+		}
+		return nil
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.UpgradeModules(context.Background(), "sale"); err != nil {
+		t.Fatal(err)
+	}
+	if polls != 5 {
+		t.Errorf("upgrade finished after %d reads, want 5", polls)
+	}
+
+	// A proxy error is returned when the upgrade never shows up
+	polls = -100
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = c.UpgradeModules(ctx, "sale")
+	if err == nil || !strings.Contains(err.Error(), "504") {
+		t.Errorf("got %v, want the proxy error", err)
+	}
+}
+
+func TestInstallModules(t *testing.T) {
+	defer func(interval time.Duration) { modulePollInterval = interval }(modulePollInterval)
+	modulePollInterval = time.Millisecond
+
+	state := ModuleUninstalled
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		switch args[4] {
+		case "button_immediate_install":
+			if args[3] != "ir.module.module" {
+				t.Errorf("install called on %v", args[3])
+			}
+			state = ModuleInstalled
+			return true
+		case "search_read":
+			domain := args[5].([]interface{})[0].([]interface{})
+			names := domain[0].([]interface{})[2].([]interface{})
+			var records []map[string]interface{}
+			for _, name := range names {
+				if name == "crm" {
+					records = append(records, map[string]interface{}{"id": 7, "name": "crm", "state": state})
+				}
+			}
+			return records
+		}
+		return nil
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := c.InstallModules(ctx, "crm"); err != nil {
+		t.Fatal(err)
+	}
+	err = c.InstallModules(ctx, "crm", "helpdesk")
+	if err == nil || !strings.Contains(err.Error(), "unknown modules [helpdesk]") {
+		t.Errorf("got %v, want the unknown module", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := c.WaitForModules(ctx, ModuleUninstalled, "crm"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want the deadline", err)
+	}
+}
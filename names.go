@@ -0,0 +1,163 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// nameBatchSize bounds the number of IDs read per display_name call
+const nameBatchSize = 1000
+
+// ResolveNames replaces the relational values of records of model by
+// [id, display name] pairs, resolving the names of all records with one
+// call per related model instead of one per row. Many2one fields become a
+// pair and x2many fields a list of pairs, the way search_read returns
+// many2one values. Fields that already hold a pair keep it.
+//
+// Without fields all relational fields found in the records are resolved.
+// Values of related records that cannot be read are left as they are.
+func (c *Connector) ResolveNames(ctx context.Context, model string, records []map[string]interface{}, fields ...string) error {
+	defs, err := c.FieldsGet(ctx, model, "type", "relation")
+	if err != nil {
+		return fmt.Errorf("name resolution failed for model %s: %w", model, err)
+	}
+	if len(fields) == 0 {
+		for name, def := range defs {
+			if relation, _ := def["relation"].(string); relation != "" {
+				fields = append(fields, name)
+			}
+		}
+		sort.Strings(fields)
+	}
+
+	// Collect the IDs to resolve per related model
+	relations := make(map[string]string, len(fields))
+	ids := make(map[string]map[int64]bool)
+	for _, field := range fields {
+		relation, _ := defs[field]["relation"].(string)
+		if relation == "" {
+			return fmt.Errorf("name resolution failed for model %s: %s is not a relational field", model, field)
+		}
+		relations[field] = relation
+		if ids[relation] == nil {
+			ids[relation] = make(map[int64]bool)
+		}
+		for _, record := range records {
+			for _, id := range unresolvedIDs(record[field]) {
+				ids[relation][id] = true
+			}
+		}
+	}
+
+	names := make(map[string]map[int64]string, len(ids))
+	for relation, set := range ids {
+		if len(set) == 0 {
+			continue
+		}
+		resolved, err := c.displayNames(ctx, relation, set)
+		if err != nil {
+			return fmt.Errorf("name resolution failed for model %s: %w", model, err)
+		}
+		names[relation] = resolved
+	}
+
+	for _, record := range records {
+		for field, relation := range relations {
+			if value, ok := record[field]; ok {
+				record[field] = withNames(value, defs[field]["type"] == "many2one", names[relation])
+			}
+		}
+	}
+	return nil
+}
+
+// displayNames reads the display names of the records of model with the
+// given IDs. name_get was removed in Odoo 17; display_name is readable on
+// every version.
+func (c *Connector) displayNames(ctx context.Context, model string, set map[int64]bool) (map[int64]string, error) {
+	ids := make([]int64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	names := make(map[int64]string, len(ids))
+	for start := 0; start < len(ids); start += nameBatchSize {
+		end := start + nameBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		// Reading the IDs as a domain skips records deleted in the meantime
+		// instead of failing the whole batch
+		var records []map[string]interface{}
+		err := c.execute(ctx, model, "search_read", []interface{}{[]interface{}{
+			[]interface{}{"id", "in", ids[start:end]},
+		}}, map[string]interface{}{
+			"fields":  []string{"display_name"},
+			"context": map[string]interface{}{"active_test": false},
+		}, &records)
+		if err != nil {
+			return nil, fmt.Errorf("display_name read failed for model %s: %w", model, err)
+		}
+		for _, record := range records {
+			id, _ := toInt64(record["id"])
+			names[id], _ = record["display_name"].(string)
+		}
+	}
+	return names, nil
+}
+
+// unresolvedIDs returns the bare IDs of a relational value: a many2one ID
+// or the IDs of an x2many list
+func unresolvedIDs(v interface{}) []int64 {
+	if id, ok := toInt64(v); ok {
+		return []int64{id}
+	}
+	items, _ := v.([]interface{})
+	if isNamePair(items) {
+		return nil
+	}
+	var ids []int64
+	for _, item := range items {
+		if id, ok := toInt64(item); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// withNames replaces the bare IDs of a relational value by [id, name] pairs
+func withNames(v interface{}, many2one bool, names map[int64]string) interface{} {
+	pair := func(id int64) interface{} {
+		if name, ok := names[id]; ok {
+			return []interface{}{id, name}
+		}
+		return id
+	}
+	if id, ok := toInt64(v); ok {
+		return pair(id)
+	}
+	items, ok := v.([]interface{})
+	if !ok || (many2one && isNamePair(items)) {
+		return v
+	}
+	resolved := make([]interface{}, len(items))
+	for i, item := range items {
+		resolved[i] = item
+		if id, ok := toInt64(item); ok {
+			resolved[i] = pair(id)
+		}
+	}
+	return resolved
+}
+
+// isNamePair reports whether items is an [id, display name] pair
+func isNamePair(items []interface{}) bool {
+	if len(items) != 2 {
+		return false
+	}
+	_, isID := toInt64(items[0])
+	_, isName := items[1].(string)
+	return isID && isName
+}
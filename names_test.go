@@ -0,0 +1,120 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// nameServer serves the fields of sale.order and the display names of
+// partners and tags, recording the IDs read per model
+func nameServer(t *testing.T, reads map[string][][]int64) *Connector {
+	t.Helper()
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		model, method := args[3].(string), args[4].(string)
+		switch method {
+		case "fields_get":
+			return map[string]interface{}{
+				"name":       map[string]interface{}{"type": "char"},
+				"partner_id": map[string]interface{}{"type": "many2one", "relation": "res.partner"},
+				"tag_ids":    map[string]interface{}{"type": "many2many", "relation": "crm.tag"},
+			}
+		case "search_read":
+			kwargs := args[6].(map[string]interface{})
+			if kwargs["context"].(map[string]interface{})["active_test"] != false {
+				t.Errorf("archived records are not read: %v", kwargs)
+			}
+			domain := args[5].([]interface{})[0].([]interface{})
+			var ids []int64
+			var records []map[string]interface{}
+			for _, v := range domain[0].([]interface{})[2].([]interface{}) {
+				id, _ := toInt64(v)
+				ids = append(ids, id)
+				// Record 404 was deleted
+				if id != 404 {
+					records = append(records, map[string]interface{}{"id": id, "display_name": model + " " + string(rune('A'+id%26))})
+				}
+			}
+			reads[model] = append(reads[model], ids)
+			return records
+		}
+		return xmlrpc.FaultError{Code: 1, String: "Odoo Server Error"}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestResolveNames(t *testing.T) {
+	reads := make(map[string][][]int64)
+	c := nameServer(t, reads)
+
+	records := []map[string]interface{}{
+		{"id": int64(1), "name": "S1", "partner_id": int64(2), "tag_ids": []interface{}{int64(1), int64(3)}},
+		{"id": int64(2), "name": "S2", "partner_id": []interface{}{int64(3), "Kept"}, "tag_ids": []interface{}{int64(3), int64(404)}},
+		{"id": int64(3), "name": "S3", "partner_id": int64(404), "tag_ids": []interface{}{}},
+	}
+	if err := c.ResolveNames(context.Background(), "sale.order", records); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]interface{}{
+		{"id": int64(1), "name": "S1", "partner_id": []interface{}{int64(2), "res.partner C"}, "tag_ids": []interface{}{
+			[]interface{}{int64(1), "crm.tag B"}, []interface{}{int64(3), "crm.tag D"},
+		}},
+		{"id": int64(2), "name": "S2", "partner_id": []interface{}{int64(3), "Kept"}, "tag_ids": []interface{}{
+			[]interface{}{int64(3), "crm.tag D"}, int64(404),
+		}},
+		{"id": int64(3), "name": "S3", "partner_id": int64(404), "tag_ids": []interface{}{}},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %v, want %v", records, want)
+	}
+
+	// One read per related model, for the IDs that are not resolved yet
+	wantReads := map[string][][]int64{
+		"res.partner": {{2, 404}},
+		"crm.tag":     {{1, 3, 404}},
+	}
+	if !reflect.DeepEqual(reads, wantReads) {
+		t.Errorf("got reads %v, want %v", reads, wantReads)
+	}
+}
+
+func TestResolveNamesBatches(t *testing.T) {
+	reads := make(map[string][][]int64)
+	c := nameServer(t, reads)
+
+	var records []map[string]interface{}
+	for id := int64(1); id <= nameBatchSize+1; id++ {
+		records = append(records, map[string]interface{}{"partner_id": id})
+	}
+	if err := c.ResolveNames(context.Background(), "sale.order", records, "partner_id"); err != nil {
+		t.Fatal(err)
+	}
+	if batches := reads["res.partner"]; len(batches) != 2 || len(batches[0]) != nameBatchSize || len(batches[1]) != 1 {
+		t.Errorf("got %d batches", len(batches))
+	}
+	if _, ok := reads["crm.tag"]; ok {
+		t.Error("tags were read although only partner_id was requested")
+	}
+	if got := records[nameBatchSize]["partner_id"]; !reflect.DeepEqual(got, []interface{}{int64(nameBatchSize + 1), "res.partner N"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestResolveNamesRejectsPlainFields(t *testing.T) {
+	c := nameServer(t, make(map[string][][]int64))
+	err := c.ResolveNames(context.Background(), "sale.order", nil, "name")
+	if err == nil || !strings.Contains(err.Error(), "name is not a relational field") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/RolandZimmermann/go-odoo-connector/odoogrpc
+
+go 1.21
+
+require (
+	github.com/RolandZimmermann/go-odoo-connector v0.0.0-20261016175719-57a9483c6932
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/kolo/xmlrpc v0.0.0-20220921171641-a4b6fa1dd06b // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+)
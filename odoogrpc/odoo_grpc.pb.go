@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: odoo.proto
+
+package odoogrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OdooConnector_SearchRead_FullMethodName       = "/odoo.connector.v1.OdooConnector/SearchRead"
+	OdooConnector_StreamSearchRead_FullMethodName = "/odoo.connector.v1.OdooConnector/StreamSearchRead"
+	OdooConnector_Create_FullMethodName           = "/odoo.connector.v1.OdooConnector/Create"
+	OdooConnector_Update_FullMethodName           = "/odoo.connector.v1.OdooConnector/Update"
+	OdooConnector_Delete_FullMethodName           = "/odoo.connector.v1.OdooConnector/Delete"
+	OdooConnector_Execute_FullMethodName          = "/odoo.connector.v1.OdooConnector/Execute"
+)
+
+// OdooConnectorClient is the client API for OdooConnector service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OdooConnectorClient interface {
+	SearchRead(ctx context.Context, in *SearchReadRequest, opts ...grpc.CallOption) (*SearchReadResponse, error)
+	StreamSearchRead(ctx context.Context, in *SearchReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Record], error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+}
+
+type odooConnectorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOdooConnectorClient(cc grpc.ClientConnInterface) OdooConnectorClient {
+	return &odooConnectorClient{cc}
+}
+
+func (c *odooConnectorClient) SearchRead(ctx context.Context, in *SearchReadRequest, opts ...grpc.CallOption) (*SearchReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchReadResponse)
+	err := c.cc.Invoke(ctx, OdooConnector_SearchRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *odooConnectorClient) StreamSearchRead(ctx context.Context, in *SearchReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Record], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OdooConnector_ServiceDesc.Streams[0], OdooConnector_StreamSearchRead_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchReadRequest, Record]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OdooConnector_StreamSearchReadClient = grpc.ServerStreamingClient[Record]
+
+func (c *odooConnectorClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, OdooConnector_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *odooConnectorClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, OdooConnector_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *odooConnectorClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, OdooConnector_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *odooConnectorClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteResponse)
+	err := c.cc.Invoke(ctx, OdooConnector_Execute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OdooConnectorServer is the server API for OdooConnector service.
+// All implementations must embed UnimplementedOdooConnectorServer
+// for forward compatibility.
+type OdooConnectorServer interface {
+	SearchRead(context.Context, *SearchReadRequest) (*SearchReadResponse, error)
+	StreamSearchRead(*SearchReadRequest, grpc.ServerStreamingServer[Record]) error
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	mustEmbedUnimplementedOdooConnectorServer()
+}
+
+// UnimplementedOdooConnectorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOdooConnectorServer struct{}
+
+func (UnimplementedOdooConnectorServer) SearchRead(context.Context, *SearchReadRequest) (*SearchReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRead not implemented")
+}
+func (UnimplementedOdooConnectorServer) StreamSearchRead(*SearchReadRequest, grpc.ServerStreamingServer[Record]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSearchRead not implemented")
+}
+func (UnimplementedOdooConnectorServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedOdooConnectorServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedOdooConnectorServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedOdooConnectorServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedOdooConnectorServer) mustEmbedUnimplementedOdooConnectorServer() {}
+func (UnimplementedOdooConnectorServer) testEmbeddedByValue()                       {}
+
+// UnsafeOdooConnectorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OdooConnectorServer will
+// result in compilation errors.
+type UnsafeOdooConnectorServer interface {
+	mustEmbedUnimplementedOdooConnectorServer()
+}
+
+func RegisterOdooConnectorServer(s grpc.ServiceRegistrar, srv OdooConnectorServer) {
+	// If the following call pancis, it indicates UnimplementedOdooConnectorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OdooConnector_ServiceDesc, srv)
+}
+
+func _OdooConnector_SearchRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OdooConnectorServer).SearchRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OdooConnector_SearchRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OdooConnectorServer).SearchRead(ctx, req.(*SearchReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OdooConnector_StreamSearchRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OdooConnectorServer).StreamSearchRead(m, &grpc.GenericServerStream[SearchReadRequest, Record]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OdooConnector_StreamSearchReadServer = grpc.ServerStreamingServer[Record]
+
+func _OdooConnector_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OdooConnectorServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OdooConnector_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OdooConnectorServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OdooConnector_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OdooConnectorServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OdooConnector_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OdooConnectorServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OdooConnector_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OdooConnectorServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OdooConnector_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OdooConnectorServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OdooConnector_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OdooConnectorServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OdooConnector_Execute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OdooConnectorServer).Execute(ctx, req.(*ExecuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OdooConnector_ServiceDesc is the grpc.ServiceDesc for OdooConnector service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OdooConnector_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "odoo.connector.v1.OdooConnector",
+	HandlerType: (*OdooConnectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchRead",
+			Handler:    _OdooConnector_SearchRead_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _OdooConnector_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _OdooConnector_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OdooConnector_Delete_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _OdooConnector_Execute_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSearchRead",
+			Handler:       _OdooConnector_StreamSearchRead_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "odoo.proto",
+}
@@ -0,0 +1,277 @@
+// Package odoogrpc serves the connector over gRPC, so services written in
+// other languages can share one Odoo gateway with its retries, throttling
+// and validation. The API is defined in odoo.proto:
+//
+//	srv := grpc.NewServer()
+//	odoogrpc.RegisterOdooConnectorServer(srv, odoogrpc.NewServer(connector, odoogrpc.ServerOptions{
+//		Models:         []string{"res.partner", "sale.order"},
+//		ExecuteMethods: map[string][]string{"sale.order": {"action_confirm"}},
+//	}))
+//	srv.Serve(listener)
+//
+// Record values travel as google.protobuf.Struct; integral numbers are sent
+// to Odoo as integers.
+package odoogrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative odoo.proto
+
+import (
+	"context"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+	"github.com/RolandZimmermann/go-odoo-connector/internal/errkind"
+)
+
+// Client is the part of the connector the server uses; *odoo.Connector
+// implements it
+type Client interface {
+	SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(record map[string]interface{}) error) error
+	CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error)
+	UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error
+	DeleteRecordContext(ctx context.Context, model string, id int64) error
+	ExecuteMethodContext(ctx context.Context, model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error)
+}
+
+var _ Client = (*odoo.Connector)(nil)
+
+// ServerOptions configures the server
+type ServerOptions struct {
+	// Models are the models served; requests for other models fail with
+	// PermissionDenied
+	Models []string
+	// ExecuteMethods lists the methods Execute may call per model. No
+	// method is allowed by default.
+	ExecuteMethods map[string][]string
+	// MaxLimit caps the number of records SearchRead returns (default
+	// 1000). StreamSearchRead is not capped.
+	MaxLimit int
+}
+
+// Server implements OdooConnectorServer on top of a connector
+type Server struct {
+	UnimplementedOdooConnectorServer
+
+	client Client
+	opts   ServerOptions
+	models map[string]bool
+}
+
+// NewServer returns a server backed by client
+func NewServer(client Client, opts ServerOptions) *Server {
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = 1000
+	}
+	models := make(map[string]bool, len(opts.Models))
+	for _, model := range opts.Models {
+		models[model] = true
+	}
+	return &Server{client: client, opts: opts, models: models}
+}
+
+func (s *Server) checkModel(model string) error {
+	if !s.models[model] {
+		return status.Errorf(codes.PermissionDenied, "model %q is not served", model)
+	}
+	return nil
+}
+
+// SearchRead returns the records matching the request
+func (s *Server) SearchRead(ctx context.Context, req *SearchReadRequest) (*SearchReadResponse, error) {
+	opts, err := s.searchOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Limit == 0 || opts.Limit > s.opts.MaxLimit {
+		opts.Limit = s.opts.MaxLimit
+	}
+
+	resp := &SearchReadResponse{}
+	err = s.client.SearchReadStream(ctx, req.Model, opts, func(record map[string]interface{}) error {
+		r, err := toRecord(record)
+		if err != nil {
+			return err
+		}
+		resp.Records = append(resp.Records, r)
+		return nil
+	})
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return resp, nil
+}
+
+// StreamSearchRead sends the records matching the request as they are read
+func (s *Server) StreamSearchRead(req *SearchReadRequest, stream OdooConnector_StreamSearchReadServer) error {
+	opts, err := s.searchOptions(req)
+	if err != nil {
+		return err
+	}
+	err = s.client.SearchReadStream(stream.Context(), req.Model, opts, func(record map[string]interface{}) error {
+		r, err := toRecord(record)
+		if err != nil {
+			return err
+		}
+		return stream.Send(r)
+	})
+	if err != nil {
+		return statusOf(err)
+	}
+	return nil
+}
+
+func (s *Server) searchOptions(req *SearchReadRequest) (odoo.SearchReadOptions, error) {
+	if err := s.checkModel(req.Model); err != nil {
+		return odoo.SearchReadOptions{}, err
+	}
+	if req.Offset < 0 || req.Limit < 0 {
+		return odoo.SearchReadOptions{}, status.Error(codes.InvalidArgument, "offset and limit must not be negative")
+	}
+	opts := odoo.SearchReadOptions{
+		Fields: req.Fields,
+		Offset: int(req.Offset),
+		Limit:  int(req.Limit),
+		Order:  req.Order,
+	}
+	if req.Domain != nil {
+		opts.Domain = fromValue(req.Domain.AsSlice()).([]interface{})
+	}
+	return opts, nil
+}
+
+// Create creates a record
+func (s *Server) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	if err := s.checkModel(req.Model); err != nil {
+		return nil, err
+	}
+	id, err := s.client.CreateRecordContext(ctx, req.Model, fromStruct(req.Values))
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return &CreateResponse{Id: id}, nil
+}
+
+// Update writes values to a record
+func (s *Server) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
+	if err := s.checkModel(req.Model); err != nil {
+		return nil, err
+	}
+	if err := s.client.UpdateRecordContext(ctx, req.Model, req.Id, fromStruct(req.Values)); err != nil {
+		return nil, statusOf(err)
+	}
+	return &UpdateResponse{}, nil
+}
+
+// Delete deletes a record
+func (s *Server) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	if err := s.checkModel(req.Model); err != nil {
+		return nil, err
+	}
+	if err := s.client.DeleteRecordContext(ctx, req.Model, req.Id); err != nil {
+		return nil, statusOf(err)
+	}
+	return &DeleteResponse{}, nil
+}
+
+// Execute calls an allowed model method
+func (s *Server) Execute(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+	if err := s.checkModel(req.Model); err != nil {
+		return nil, err
+	}
+	allowed := false
+	for _, method := range s.opts.ExecuteMethods[req.Model] {
+		allowed = allowed || method == req.Method
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "method %q of model %q may not be executed", req.Method, req.Model)
+	}
+
+	args := []interface{}{}
+	if req.Args != nil {
+		args = fromValue(req.Args.AsSlice()).([]interface{})
+	}
+	var kwargs map[string]interface{}
+	if req.Kwargs != nil {
+		kwargs = fromStruct(req.Kwargs)
+	}
+	result, err := s.client.ExecuteMethodContext(ctx, req.Model, req.Method, args, kwargs)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	value, err := structpb.NewValue(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding result: %v", err)
+	}
+	return &ExecuteResponse{Result: value}, nil
+}
+
+func toRecord(record map[string]interface{}) (*Record, error) {
+	values, err := structpb.NewStruct(record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding record: %v", err)
+	}
+	id, _ := record["id"].(int64)
+	return &Record{Id: id, Values: values}, nil
+}
+
+func fromStruct(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return map[string]interface{}{}
+	}
+	return fromValue(s.AsMap()).(map[string]interface{})
+}
+
+// fromValue turns the float64 numbers of decoded protobuf values into int64
+// where they are integral, so that IDs reach Odoo as integers
+func fromValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+			return int64(value)
+		}
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = fromValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = fromValue(item)
+		}
+	}
+	return v
+}
+
+// statusOf converts an error of the connector to a gRPC status
+func statusOf(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Unknown
+	switch errkind.Of(err) {
+	case errkind.Canceled:
+		code = codes.Canceled
+	case errkind.DeadlineExceeded:
+		code = codes.DeadlineExceeded
+	case errkind.Invalid:
+		code = codes.InvalidArgument
+	case errkind.PermissionDenied:
+		code = codes.PermissionDenied
+	case errkind.NotFound:
+		code = codes.NotFound
+	case errkind.Rejected:
+		code = codes.FailedPrecondition
+	case errkind.RateLimited:
+		code = codes.ResourceExhausted
+	case errkind.Unavailable:
+		code = codes.Unavailable
+	case errkind.Conflict:
+		code = codes.Aborted
+	case errkind.Unauthenticated:
+		code = codes.Unauthenticated
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,146 @@
+package odoogrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+type fakeClient struct {
+	query   odoo.SearchReadOptions
+	created map[string]interface{}
+	args    []interface{}
+}
+
+func (f *fakeClient) SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(map[string]interface{}) error) error {
+	f.query = opts
+	for id := int64(1); id <= 3 && (opts.Limit == 0 || id <= int64(opts.Limit)); id++ {
+		err := fn(map[string]interface{}{"id": id, "name": "Partner", "parent_id": []interface{}{int64(9), "Azure"}, "email": false})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeClient) CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error) {
+	f.created = values
+	return 42, nil
+}
+
+func (f *fakeClient) UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error {
+	return fmt.Errorf("update failed: %w", &odoo.Error{FaultCode: 2, ExceptionType: "odoo.exceptions.MissingError", Message: "Record does not exist or has been deleted."})
+}
+
+func (f *fakeClient) DeleteRecordContext(ctx context.Context, model string, id int64) error {
+	return nil
+}
+
+func (f *fakeClient) ExecuteMethodContext(ctx context.Context, model, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	f.args = args
+	return true, nil
+}
+
+func dial(t *testing.T, fake *fakeClient) OdooConnectorClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterOdooConnectorServer(srv, NewServer(fake, ServerOptions{
+		Models:         []string{"res.partner"},
+		ExecuteMethods: map[string][]string{"res.partner": {"action_archive"}},
+		MaxLimit:       2,
+	}))
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewOdooConnectorClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeClient{}
+	client := dial(t, fake)
+
+	domain, _ := structpb.NewList([]interface{}{[]interface{}{"parent_id", "=", 9}})
+	resp, err := client.SearchRead(ctx, &SearchReadRequest{Model: "res.partner", Fields: []string{"name"}, Domain: domain, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.query.Limit != 2 || !reflect.DeepEqual(fake.query.Domain, []interface{}{[]interface{}{"parent_id", "=", int64(9)}}) {
+		t.Errorf("query = %+v", fake.query)
+	}
+	if len(resp.Records) != 2 || resp.Records[1].Id != 2 || resp.Records[0].Values.AsMap()["email"] != false {
+		t.Errorf("records = %v", resp.Records)
+	}
+
+	stream, err := client.StreamSearchRead(ctx, &SearchReadRequest{Model: "res.partner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streamed int
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		streamed++
+	}
+	if streamed != 3 {
+		t.Errorf("streamed %d records, want 3 (streams are not capped)", streamed)
+	}
+
+	values, _ := structpb.NewStruct(map[string]interface{}{"name": "Azure", "parent_id": 9, "credit_limit": 2.5})
+	created, err := client.Create(ctx, &CreateRequest{Model: "res.partner", Values: values})
+	if err != nil || created.Id != 42 {
+		t.Fatalf("create: %v, %v", created, err)
+	}
+	if want := map[string]interface{}{"name": "Azure", "parent_id": int64(9), "credit_limit": 2.5}; !reflect.DeepEqual(fake.created, want) {
+		t.Errorf("created %#v", fake.created)
+	}
+
+	args, _ := structpb.NewList([]interface{}{[]interface{}{1, 2}})
+	if _, err := client.Execute(ctx, &ExecuteRequest{Model: "res.partner", Method: "action_archive", Args: args}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{[]interface{}{int64(1), int64(2)}}; !reflect.DeepEqual(fake.args, want) {
+		t.Errorf("execute args %#v", fake.args)
+	}
+
+	for _, tt := range []struct {
+		call func() error
+		code codes.Code
+	}{
+		{func() error { _, err := client.SearchRead(ctx, &SearchReadRequest{Model: "res.users"}); return err }, codes.PermissionDenied},
+		{func() error {
+			_, err := client.Execute(ctx, &ExecuteRequest{Model: "res.partner", Method: "unlink"})
+			return err
+		}, codes.PermissionDenied},
+		{func() error { _, err := client.Update(ctx, &UpdateRequest{Model: "res.partner", Id: 5}); return err }, codes.NotFound},
+	} {
+		if got := status.Code(tt.call()); got != tt.code {
+			t.Errorf("got code %v, want %v", got, tt.code)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package odoorest
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// CacheOptions configures a CachingClient
+type CacheOptions struct {
+	// TTL is how long search results are served from the cache (default
+	// 30 seconds)
+	TTL time.Duration
+	// MaxEntries bounds the number of cached searches; the least recently
+	// used are evicted first (default 1000)
+	MaxEntries int
+	// Timeout bounds a search read through the cache (default 1 minute).
+	// The search is shared by all requests waiting for it, so it runs on
+	// when the request that started it is cancelled.
+	Timeout time.Duration
+	// Invalidates lists, per model, further models whose cached searches a
+	// write to it makes stale, e.g. "sale.order.line": {"sale.order"} since
+	// line changes recompute order totals
+	Invalidates map[string][]string
+}
+
+// CacheStats are the counters of a CachingClient
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	// Coalesced counts searches that waited for an identical search in
+	// flight instead of calling Odoo
+	Coalesced     int64
+	Invalidations int64
+	Evictions     int64
+	Entries       int
+}
+
+// CachingClient is a read-through cache in front of a Client: identical
+// searches within the TTL are answered from memory and concurrent identical
+// searches share one call, shielding Odoo from dashboards polling the same
+// queries. Writes through the client invalidate the cached searches of the
+// written model. Writes made elsewhere show after at most the TTL.
+//
+//	cached := odoorest.NewCachingClient(connector, odoorest.CacheOptions{TTL: time.Minute})
+//	handler := odoorest.NewHandler(cached, opts)
+type CachingClient struct {
+	client Client
+	opts   CacheOptions
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List // of *cacheEntry, most recently used first
+	inflight map[string]*inflightSearch
+	// generations counts the invalidations of each model, so searches
+	// that started before an invalidation are not stored
+	generations map[string]int64
+	stats       CacheStats
+}
+
+type cacheEntry struct {
+	key     string
+	model   string
+	records []map[string]interface{}
+	expires time.Time
+}
+
+type inflightSearch struct {
+	done    chan struct{}
+	records []map[string]interface{}
+	err     error
+}
+
+var _ Client = (*CachingClient)(nil)
+
+// NewCachingClient returns a caching client in front of client
+func NewCachingClient(client Client, opts CacheOptions) *CachingClient {
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1000
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Minute
+	}
+	return &CachingClient{
+		client:      client,
+		opts:        opts,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		inflight:    make(map[string]*inflightSearch),
+		generations: make(map[string]int64),
+	}
+}
+
+// SearchReadStream serves the search from the cache or reads it through
+func (c *CachingClient) SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(record map[string]interface{}) error) error {
+	key, err := cacheKey(model, opts)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if time.Now().Before(entry.expires) {
+			c.lru.MoveToFront(elem)
+			c.stats.Hits++
+			c.mu.Unlock()
+			return replay(entry.records, fn)
+		}
+		c.remove(elem)
+	}
+	c.stats.Misses++
+	search, ok := c.inflight[key]
+	if ok {
+		c.stats.Coalesced++
+	} else {
+		search = &inflightSearch{done: make(chan struct{})}
+		c.inflight[key] = search
+		go c.readThrough(ctx, key, model, opts, search, c.generations[model])
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-search.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if search.err != nil {
+		return search.err
+	}
+	return replay(search.records, fn)
+}
+
+// readThrough runs a search shared by all requests waiting for it and caches
+// the result unless the model was invalidated since generation. It is not
+// cancelled with ctx, the context of the request that started it.
+func (c *CachingClient) readThrough(ctx context.Context, key, model string, opts odoo.SearchReadOptions, search *inflightSearch, generation int64) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.opts.Timeout)
+	defer cancel()
+
+	var records []map[string]interface{}
+	search.err = c.client.SearchReadStream(ctx, model, opts, func(record map[string]interface{}) error {
+		records = append(records, record)
+		return nil
+	})
+	search.records = records
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if search.err == nil && c.generations[model] == generation {
+		c.store(&cacheEntry{key: key, model: model, records: records, expires: time.Now().Add(c.opts.TTL)})
+	}
+	c.mu.Unlock()
+	close(search.done)
+}
+
+// CreateRecordContext creates a record and invalidates the model
+func (c *CachingClient) CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error) {
+	defer c.Invalidate(model)
+	return c.client.CreateRecordContext(ctx, model, values)
+}
+
+// UpdateRecordContext updates a record and invalidates the model
+func (c *CachingClient) UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error {
+	defer c.Invalidate(model)
+	return c.client.UpdateRecordContext(ctx, model, id, values)
+}
+
+// DeleteRecordContext deletes a record and invalidates the model
+func (c *CachingClient) DeleteRecordContext(ctx context.Context, model string, id int64) error {
+	defer c.Invalidate(model)
+	return c.client.DeleteRecordContext(ctx, model, id)
+}
+
+// Invalidate drops the cached searches of model and of the models listed
+// for it in CacheOptions.Invalidates
+func (c *CachingClient) Invalidate(model string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Invalidations++
+	models := append([]string{model}, c.opts.Invalidates[model]...)
+	for _, m := range models {
+		c.generations[m]++
+	}
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*cacheEntry)
+		for _, m := range models {
+			if entry.model == m {
+				c.remove(elem)
+				break
+			}
+		}
+		elem = next
+	}
+}
+
+// Stats returns the current counters
+func (c *CachingClient) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// MetricsHandler serves the counters in the Prometheus text format
+func (c *CachingClient) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := c.Stats()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		for _, m := range []struct {
+			name, kind, help string
+			value            int64
+		}{
+			{"odoorest_cache_hits_total", "counter", "Searches answered from the cache.", stats.Hits},
+			{"odoorest_cache_misses_total", "counter", "Searches not found in the cache.", stats.Misses},
+			{"odoorest_cache_coalesced_total", "counter", "Searches that shared an identical search in flight.", stats.Coalesced},
+			{"odoorest_cache_invalidations_total", "counter", "Invalidations caused by writes.", stats.Invalidations},
+			{"odoorest_cache_evictions_total", "counter", "Entries evicted to make room.", stats.Evictions},
+			{"odoorest_cache_entries", "gauge", "Cached searches.", int64(stats.Entries)},
+		} {
+			fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", m.name, m.help, m.name, m.kind, m.name, m.value)
+		}
+	})
+}
+
+// store adds an entry, evicting the least recently used ones beyond
+// MaxEntries. c.mu must be held.
+func (c *CachingClient) store(entry *cacheEntry) {
+	if elem, ok := c.entries[entry.key]; ok {
+		c.remove(elem)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *CachingClient) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+func cacheKey(model string, opts odoo.SearchReadOptions) (string, error) {
+	key, err := json.Marshal([]interface{}{model, opts.Fields, opts.Domain, opts.Offset, opts.Limit, opts.Order})
+	if err != nil {
+		return "", fmt.Errorf("search of model %s cannot be cached: %w", model, err)
+	}
+	return string(key), nil
+}
+
+// replay passes cached records to fn as shallow copies, so callers modifying
+// them do not alter the cache
+func replay(records []map[string]interface{}, fn func(map[string]interface{}) error) error {
+	for _, record := range records {
+		copied := make(map[string]interface{}, len(record))
+		for k, v := range record {
+			copied[k] = v
+		}
+		if err := fn(copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
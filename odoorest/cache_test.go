@@ -0,0 +1,189 @@
+package odoorest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// countingClient counts searches and blocks them until release is closed
+type countingClient struct {
+	fakeClient
+	searches atomic.Int64
+	release  chan struct{}
+}
+
+func (c *countingClient) SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(map[string]interface{}) error) error {
+	c.searches.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	return fn(map[string]interface{}{"id": int64(1), "model": model})
+}
+
+func search(t *testing.T, c *CachingClient, model string, limit int) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	err := c.SearchReadStream(context.Background(), model, odoo.SearchReadOptions{Limit: limit}, func(r map[string]interface{}) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestCachingClient(t *testing.T) {
+	client := &countingClient{}
+	cache := NewCachingClient(client, CacheOptions{
+		MaxEntries:  2,
+		Invalidates: map[string][]string{"sale.order.line": {"sale.order"}},
+	})
+
+	search(t, cache, "sale.order", 10)
+	records := search(t, cache, "sale.order", 10)
+	if got := client.searches.Load(); got != 1 {
+		t.Fatalf("identical searches called Odoo %d times, want 1", got)
+	}
+	records[0]["id"] = int64(99)
+	if id := search(t, cache, "sale.order", 10)[0]["id"]; id != int64(1) {
+		t.Errorf("modifying a returned record changed the cache: id %v", id)
+	}
+
+	search(t, cache, "sale.order", 20)
+	if got := client.searches.Load(); got != 2 {
+		t.Errorf("different search was served from the cache")
+	}
+
+	if _, err := cache.CreateRecordContext(context.Background(), "sale.order.line", nil); err != nil {
+		t.Fatal(err)
+	}
+	search(t, cache, "sale.order", 10)
+	if got := client.searches.Load(); got != 3 {
+		t.Errorf("write to a dependent model did not invalidate sale.order")
+	}
+
+	search(t, cache, "res.partner", 10)
+	search(t, cache, "res.partner", 20)
+
+	stats := cache.Stats()
+	want := CacheStats{Hits: 2, Misses: 5, Invalidations: 1, Evictions: 1, Entries: 2}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestCachingClientExpiry(t *testing.T) {
+	client := &countingClient{}
+	cache := NewCachingClient(client, CacheOptions{TTL: time.Millisecond})
+	search(t, cache, "res.partner", 10)
+	time.Sleep(5 * time.Millisecond)
+	search(t, cache, "res.partner", 10)
+	if got := client.searches.Load(); got != 2 {
+		t.Errorf("expired search called Odoo %d times, want 2", got)
+	}
+}
+
+func TestCachingClientCoalesces(t *testing.T) {
+	client := &countingClient{release: make(chan struct{})}
+	cache := NewCachingClient(client, CacheOptions{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if records := search(t, cache, "res.partner", 10); len(records) != 1 {
+				t.Errorf("got %d records, want 1", len(records))
+			}
+		}()
+	}
+	for cache.Stats().Misses < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	close(client.release)
+	wg.Wait()
+
+	if got := client.searches.Load(); got != 1 {
+		t.Errorf("concurrent identical searches called Odoo %d times, want 1", got)
+	}
+	if got := cache.Stats().Coalesced; got != 4 {
+		t.Errorf("coalesced = %d, want 4", got)
+	}
+}
+
+func TestCachingClientMetrics(t *testing.T) {
+	cache := NewCachingClient(&countingClient{}, CacheOptions{})
+	search(t, cache, "res.partner", 10)
+	search(t, cache, "res.partner", 10)
+
+	w := serve(cache.MetricsHandler(), "GET", "/metrics", "")
+	for _, line := range []string{"odoorest_cache_hits_total 1", "odoorest_cache_misses_total 1", "odoorest_cache_entries 1"} {
+		if !strings.Contains(w.Body.String(), line+"\n") {
+			t.Errorf("metrics lack %q:\n%s", line, w.Body)
+		}
+	}
+}
+
+// blockingClient blocks searches until release is closed or their context
+// is done
+type blockingClient struct {
+	fakeClient
+	release chan struct{}
+}
+
+func (c *blockingClient) SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(map[string]interface{}) error) error {
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return fn(map[string]interface{}{"id": int64(1)})
+}
+
+func TestCachingClientOutlivesCancelledRequest(t *testing.T) {
+	client := &blockingClient{release: make(chan struct{})}
+	cache := NewCachingClient(client, CacheOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan error)
+	go func() {
+		started <- cache.SearchReadStream(ctx, "res.partner", odoo.SearchReadOptions{}, func(map[string]interface{}) error { return nil })
+	}()
+	for cache.Stats().Misses < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waited := make(chan []map[string]interface{})
+	go func() { waited <- search(t, cache, "res.partner", 0) }()
+	for cache.Stats().Coalesced < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The request that started the search goes away
+	cancel()
+	if err := <-started; err != context.Canceled {
+		t.Errorf("cancelled request: err = %v", err)
+	}
+	close(client.release)
+	if records := <-waited; len(records) != 1 {
+		t.Errorf("waiting request got %d records, want 1", len(records))
+	}
+	if stats := cache.Stats(); stats.Entries != 1 {
+		t.Errorf("entries = %d, want the shared search cached", stats.Entries)
+	}
+}
+
+func TestCachingClientTimeout(t *testing.T) {
+	cache := NewCachingClient(&blockingClient{release: make(chan struct{})}, CacheOptions{Timeout: time.Millisecond})
+	err := cache.SearchReadStream(context.Background(), "res.partner", odoo.SearchReadOptions{}, func(map[string]interface{}) error { return nil })
+	if err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want the search timeout", err)
+	}
+}
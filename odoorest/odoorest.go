@@ -0,0 +1,377 @@
+// Package odoorest exposes Odoo models as a JSON REST API, so internal tools
+// can read and write records without speaking XML-RPC:
+//
+//	GET    /models/{model}        search records: ?fields=&domain=&limit=&offset=&order=
+//	GET    /models/{model}/{id}   read a record: ?fields=
+//	POST   /models/{model}        create a record from a JSON object
+//	PUT    /models/{model}/{id}   update a record from a JSON object
+//	DELETE /models/{model}/{id}   delete a record
+//
+// Only the models and operations listed in Options.Models are served, and
+// every request passes the Authorize hook and the middleware:
+//
+//	handler := odoorest.NewHandler(connector, odoorest.Options{
+//		Models: map[string][]odoorest.Operation{
+//			"res.partner": odoorest.AllOperations,
+//			"sale.order":  odoorest.ReadOnly,
+//		},
+//		Middleware: []func(http.Handler) http.Handler{requireToken},
+//	})
+//	http.Handle("/api/", http.StripPrefix("/api", handler))
+package odoorest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+	"github.com/RolandZimmermann/go-odoo-connector/internal/errkind"
+)
+
+// Operation is a kind of access to a model
+type Operation string
+
+const (
+	OpRead   Operation = "read"
+	OpCreate Operation = "create"
+	OpUpdate Operation = "update"
+	OpDelete Operation = "delete"
+)
+
+var (
+	// ReadOnly allows searching and reading records
+	ReadOnly = []Operation{OpRead}
+	// AllOperations allows reading, creating, updating and deleting records
+	AllOperations = []Operation{OpRead, OpCreate, OpUpdate, OpDelete}
+)
+
+// Client is the part of the connector the handler uses; *odoo.Connector
+// implements it
+type Client interface {
+	SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(record map[string]interface{}) error) error
+	CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error)
+	UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error
+	DeleteRecordContext(ctx context.Context, model string, id int64) error
+}
+
+var _ Client = (*odoo.Connector)(nil)
+
+// Options configures the handler
+type Options struct {
+	// Models maps the served models to the operations allowed on them.
+	// Other models are answered with 404.
+	Models map[string][]Operation
+	// Authorize, if set, is called before every operation; an error denies
+	// the request with 403 and the error message. Errors implementing
+	// interface{ StatusCode() int } choose their own status, e.g. 401.
+	Authorize func(r *http.Request, model string, op Operation) error
+	// Middleware wraps the handler, first entry outermost, e.g. for
+	// authentication or logging
+	Middleware []func(http.Handler) http.Handler
+	// DefaultLimit and MaxLimit bound the number of records a search
+	// returns (defaults 80 and 1000)
+	DefaultLimit int
+	MaxLimit     int
+}
+
+type handler struct {
+	client Client
+	opts   Options
+}
+
+// NewHandler returns the REST handler. It serves paths starting with
+// /models/; mount it with http.StripPrefix to serve it below a prefix.
+func NewHandler(client Client, opts Options) http.Handler {
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = 80
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = 1000
+	}
+
+	var h http.Handler = &handler{client: client, opts: opts}
+	for i := len(opts.Middleware) - 1; i >= 0; i-- {
+		h = opts.Middleware[i](h)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rest, ok := strings.CutPrefix(r.URL.Path, "/models/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	model, idPart, _ := strings.Cut(rest, "/")
+	hasID := idPart != ""
+	if _, exposed := h.opts.Models[model]; !exposed || strings.Contains(idPart, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var id int64
+	if hasID {
+		var err error
+		id, err = strconv.ParseInt(idPart, 10, 64)
+		if err != nil || id <= 0 {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+	}
+
+	var op Operation
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		op = OpRead
+	case r.Method == http.MethodPost && !hasID:
+		op = OpCreate
+	case (r.Method == http.MethodPut || r.Method == http.MethodPatch) && hasID:
+		op = OpUpdate
+	case r.Method == http.MethodDelete && hasID:
+		op = OpDelete
+	default:
+		if hasID {
+			w.Header().Set("Allow", "GET, PUT, PATCH, DELETE")
+		} else {
+			w.Header().Set("Allow", "GET, POST")
+		}
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if !h.allowed(model, op) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("%s is not allowed on %s", op, model))
+		return
+	}
+	if h.opts.Authorize != nil {
+		if err := h.opts.Authorize(r, model, op); err != nil {
+			status := http.StatusForbidden
+			var coded interface{ StatusCode() int }
+			if errors.As(err, &coded) {
+				status = coded.StatusCode()
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+	}
+
+	switch op {
+	case OpRead:
+		if hasID {
+			h.read(w, r, model, id)
+		} else {
+			h.search(w, r, model)
+		}
+	case OpCreate:
+		h.create(w, r, model)
+	case OpUpdate:
+		h.update(w, r, model, id)
+	case OpDelete:
+		h.delete(w, r, model, id)
+	}
+}
+
+func (h *handler) allowed(model string, op Operation) bool {
+	for _, allowed := range h.opts.Models[model] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *handler) search(w http.ResponseWriter, r *http.Request, model string) {
+	query := r.URL.Query()
+	opts := odoo.SearchReadOptions{
+		Fields: splitFields(query.Get("fields")),
+		Order:  query.Get("order"),
+		Limit:  h.opts.DefaultLimit,
+	}
+
+	if s := query.Get("domain"); s != "" {
+		domain, err := parseDomain(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		opts.Domain = domain
+	}
+	for name, target := range map[string]*int{"limit": &opts.Limit, "offset": &opts.Offset} {
+		s := query.Get(name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s %q", name, s))
+			return
+		}
+		*target = n
+	}
+	if opts.Limit == 0 || opts.Limit > h.opts.MaxLimit {
+		opts.Limit = h.opts.MaxLimit
+	}
+
+	records := []map[string]interface{}{}
+	err := h.client.SearchReadStream(r.Context(), model, opts, func(record map[string]interface{}) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		writeOdooError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"records": records,
+		"offset":  opts.Offset,
+		"limit":   opts.Limit,
+	})
+}
+
+func (h *handler) read(w http.ResponseWriter, r *http.Request, model string, id int64) {
+	var record map[string]interface{}
+	err := h.client.SearchReadStream(r.Context(), model, odoo.SearchReadOptions{
+		Fields: splitFields(r.URL.Query().Get("fields")),
+		Domain: []interface{}{[]interface{}{"id", "=", id}},
+		Limit:  1,
+	}, func(r map[string]interface{}) error {
+		record = r
+		return nil
+	})
+	if err != nil {
+		writeOdooError(w, err)
+		return
+	}
+	if record == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", model, id))
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (h *handler) create(w http.ResponseWriter, r *http.Request, model string) {
+	values, ok := readValues(w, r)
+	if !ok {
+		return
+	}
+	id, err := h.client.CreateRecordContext(r.Context(), model, values)
+	if err != nil {
+		writeOdooError(w, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/%d", strings.TrimSuffix(r.URL.Path, "/"), id))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"id": id})
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request, model string, id int64) {
+	values, ok := readValues(w, r)
+	if !ok {
+		return
+	}
+	if err := h.client.UpdateRecordContext(r.Context(), model, id, values); err != nil {
+		writeOdooError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": id})
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request, model string, id int64) {
+	if err := h.client.DeleteRecordContext(r.Context(), model, id); err != nil {
+		writeOdooError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maxBodySize bounds the JSON body of create and update requests
+const maxBodySize = 10 << 20
+
+// readValues decodes the field values of a create or update request
+func readValues(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.UseNumber()
+	var values map[string]interface{}
+	if err := dec.Decode(&values); err != nil || values == nil {
+		writeError(w, http.StatusBadRequest, "request body must be a JSON object of field values")
+		return nil, false
+	}
+	return normalizeNumbers(values).(map[string]interface{}), true
+}
+
+// normalizeNumbers turns JSON numbers into int64 where they are integral, so
+// that IDs reach Odoo as integers rather than floats
+func normalizeNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeNumbers(item)
+		}
+	}
+	return v
+}
+
+// parseDomain accepts a domain as JSON or in Odoo's Python literal syntax
+func parseDomain(s string) ([]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var domain []interface{}
+	if err := dec.Decode(&domain); err == nil && !dec.More() {
+		return normalizeNumbers(domain).([]interface{}), nil
+	}
+	domain, err := odoo.ParseDomain(s)
+	if err != nil {
+		return nil, fmt.Errorf("domain: %w", err)
+	}
+	return domain, nil
+}
+
+func splitFields(s string) []string {
+	var fields []string
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// writeOdooError answers with the status matching an error of the connector
+func writeOdooError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	switch errkind.Of(err) {
+	case errkind.Invalid, errkind.Rejected:
+		status = http.StatusUnprocessableEntity
+	case errkind.Canceled, errkind.DeadlineExceeded:
+		status = http.StatusGatewayTimeout
+	case errkind.PermissionDenied:
+		status = http.StatusForbidden
+	case errkind.NotFound:
+		status = http.StatusNotFound
+	}
+	writeError(w, status, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
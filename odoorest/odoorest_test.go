@@ -0,0 +1,167 @@
+package odoorest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	odoo "github.com/RolandZimmermann/go-odoo-connector"
+)
+
+type fakeClient struct {
+	query   odoo.SearchReadOptions
+	records []map[string]interface{}
+	created map[string]interface{}
+	deleted int64
+	err     error
+}
+
+func (f *fakeClient) SearchReadStream(ctx context.Context, model string, opts odoo.SearchReadOptions, fn func(map[string]interface{}) error) error {
+	f.query = opts
+	for _, record := range f.records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func (f *fakeClient) CreateRecordContext(ctx context.Context, model string, values map[string]interface{}) (int64, error) {
+	f.created = values
+	return 42, f.err
+}
+
+func (f *fakeClient) UpdateRecordContext(ctx context.Context, model string, id int64, values map[string]interface{}) error {
+	return f.err
+}
+
+func (f *fakeClient) DeleteRecordContext(ctx context.Context, model string, id int64) error {
+	f.deleted = id
+	return f.err
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	client := &fakeClient{records: []map[string]interface{}{{"id": int64(1), "name": "Azure"}}}
+	h := NewHandler(client, Options{
+		Models: map[string][]Operation{
+			"res.partner": AllOperations,
+			"sale.order":  ReadOnly,
+		},
+		MaxLimit: 500,
+	})
+
+	w := serve(h, "GET", "/models/res.partner?fields=name&domain="+
+		"%5B%28%27is_company%27%2C+%27%3D%27%2C+True%29%5D&limit=9999&offset=20", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("search: %d %s", w.Code, w.Body)
+	}
+	wantQuery := odoo.SearchReadOptions{
+		Fields: []string{"name"},
+		Domain: []interface{}{[]interface{}{"is_company", "=", true}},
+		Offset: 20,
+		Limit:  500,
+	}
+	if !reflect.DeepEqual(client.query, wantQuery) {
+		t.Errorf("query = %+v, want %+v", client.query, wantQuery)
+	}
+	if !strings.Contains(w.Body.String(), `"records":[{"id":1,"name":"Azure"}]`) {
+		t.Errorf("search body = %s", w.Body)
+	}
+
+	// JSON domains are accepted as well
+	serve(h, "GET", `/models/res.partner?domain=[["id","in",[1,2]]]`, "")
+	if want := []interface{}{[]interface{}{"id", "in", []interface{}{int64(1), int64(2)}}}; !reflect.DeepEqual(client.query.Domain, want) {
+		t.Errorf("JSON domain = %#v", client.query.Domain)
+	}
+
+	w = serve(h, "POST", "/models/res.partner", `{"name": "Azure", "parent_id": 7, "credit_limit": 10.5}`)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/models/res.partner/42" {
+		t.Errorf("create: %d %v", w.Code, w.Header())
+	}
+	if want := map[string]interface{}{"name": "Azure", "parent_id": int64(7), "credit_limit": 10.5}; !reflect.DeepEqual(client.created, want) {
+		t.Errorf("created %#v", client.created)
+	}
+
+	if w = serve(h, "DELETE", "/models/res.partner/3", ""); w.Code != http.StatusNoContent || client.deleted != 3 {
+		t.Errorf("delete: %d, deleted %d", w.Code, client.deleted)
+	}
+
+	for _, tt := range []struct {
+		method, target, body string
+		status               int
+	}{
+		{"GET", "/models/res.users", "", http.StatusNotFound},
+		{"GET", "/models/res.partner/abc", "", http.StatusNotFound},
+		{"POST", "/models/sale.order", `{}`, http.StatusForbidden},
+		{"POST", "/models/res.partner/1", `{}`, http.StatusMethodNotAllowed},
+		{"PUT", "/models/res.partner/1", `[1]`, http.StatusBadRequest},
+		{"GET", "/models/res.partner?domain=[(", "", http.StatusBadRequest},
+	} {
+		if w := serve(h, tt.method, tt.target, tt.body); w.Code != tt.status {
+			t.Errorf("%s %s: status %d, want %d (%s)", tt.method, tt.target, w.Code, tt.status, w.Body)
+		}
+	}
+
+	client.records = nil
+	if w = serve(h, "GET", "/models/res.partner/5", ""); w.Code != http.StatusNotFound {
+		t.Errorf("read missing record: %d", w.Code)
+	}
+	client.err = fmt.Errorf("update failed: %w", &odoo.Error{FaultCode: 4, ExceptionType: "odoo.exceptions.AccessError", Message: "not allowed"})
+	if w = serve(h, "PUT", "/models/res.partner/5", `{"name": "x"}`); w.Code != http.StatusForbidden {
+		t.Errorf("access error: %d", w.Code)
+	}
+}
+
+type unauthorized struct{}
+
+func (unauthorized) Error() string   { return "missing token" }
+func (unauthorized) StatusCode() int { return http.StatusUnauthorized }
+
+func TestHandlerAuthorize(t *testing.T) {
+	var order []string
+	h := NewHandler(&fakeClient{}, Options{
+		Models: map[string][]Operation{"res.partner": ReadOnly},
+		Authorize: func(r *http.Request, model string, op Operation) error {
+			order = append(order, "authorize")
+			if r.Header.Get("Authorization") == "" {
+				return unauthorized{}
+			}
+			return nil
+		},
+		Middleware: []func(http.Handler) http.Handler{
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					order = append(order, "outer")
+					next.ServeHTTP(w, r)
+				})
+			},
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					order = append(order, "inner")
+					next.ServeHTTP(w, r)
+				})
+			},
+		},
+	})
+
+	w := serve(h, "GET", "/models/res.partner", "")
+	var body map[string]string
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if w.Code != http.StatusUnauthorized || body["error"] != "missing token" {
+		t.Errorf("unauthorized request: %d %v", w.Code, body)
+	}
+	if want := []string{"outer", "inner", "authorize"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("call order %v, want %v", order, want)
+	}
+}
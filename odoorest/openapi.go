@@ -0,0 +1,281 @@
+package odoorest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SchemaClient reads the field definitions of models; *odoo.Connector
+// implements it
+type SchemaClient interface {
+	ExecuteMethod(model string, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error)
+}
+
+// OpenAPIInfo describes the API in the generated document
+type OpenAPIInfo struct {
+	Title   string
+	Version string
+	// ServerURL is the URL the handler is mounted at, e.g.
+	// https://tools.example.com/api
+	ServerURL string
+}
+
+// OpenAPI generates an OpenAPI 3 document in JSON describing the API that
+// NewHandler serves with opts: the paths of the allowed operations of each
+// model and schemas derived from fields_get. Each model gets three schemas:
+// the record as read ("sale.order"), the writable values
+// ("sale.order.values") and the values of a create with the required fields
+// ("sale.order.create").
+func OpenAPI(client SchemaClient, opts Options, info OpenAPIInfo) ([]byte, error) {
+	if info.Title == "" {
+		info.Title = "Odoo REST API"
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = 80
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = 1000
+	}
+
+	models := make([]string, 0, len(opts.Models))
+	for model := range opts.Models {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	schemas := obj{"Error": obj{
+		"type":       "object",
+		"properties": obj{"error": obj{"type": "string"}},
+		"required":   []string{"error"},
+	}}
+	paths := obj{}
+	for _, model := range models {
+		result, err := client.ExecuteMethod(model, "fields_get", []interface{}{}, map[string]interface{}{
+			"attributes": []string{"string", "type", "help", "required", "readonly", "selection", "relation"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("OpenAPI generation failed for model %s: %w", model, err)
+		}
+		fields, _ := result.(map[string]interface{})
+		record, values, required := modelSchemas(fields)
+		schemas[model] = record
+		schemas[model+".values"] = values
+		schemas[model+".create"] = ref(model + ".values")
+		if len(required) > 0 {
+			schemas[model+".create"] = obj{
+				"allOf": []interface{}{ref(model + ".values"), obj{"required": required}},
+			}
+		}
+
+		collection, item := obj{}, obj{}
+		for _, op := range opts.Models[model] {
+			switch op {
+			case OpRead:
+				collection["get"] = searchOperation(model, opts)
+				item["get"] = operation("Read a "+model+" record", model+".read", nil, obj{
+					"200": jsonResponse("The record", ref(model)),
+				}, fieldsParameter)
+			case OpCreate:
+				collection["post"] = operation("Create a "+model+" record", model+".create", ref(model+".create"), obj{
+					"201": jsonResponse("The record was created", idSchema),
+				})
+			case OpUpdate:
+				item["put"] = operation("Update a "+model+" record", model+".update", ref(model+".values"), obj{
+					"200": jsonResponse("The record was updated", idSchema),
+				})
+			case OpDelete:
+				item["delete"] = operation("Delete a "+model+" record", model+".delete", nil, obj{
+					"204": obj{"description": "The record was deleted"},
+				})
+			}
+		}
+		if len(collection) > 0 {
+			paths["/models/"+model] = collection
+		}
+		if len(item) > 0 {
+			item["parameters"] = []interface{}{obj{
+				"name": "id", "in": "path", "required": true,
+				"schema": obj{"type": "integer", "format": "int64", "minimum": 1},
+			}}
+			paths["/models/"+model+"/{id}"] = item
+		}
+	}
+
+	doc := obj{
+		"openapi":    "3.0.3",
+		"info":       obj{"title": info.Title, "version": info.Version},
+		"paths":      paths,
+		"components": obj{"schemas": schemas},
+	}
+	if info.ServerURL != "" {
+		doc["servers"] = []interface{}{obj{"url": info.ServerURL}}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// obj is a JSON object of the generated document
+type obj = map[string]interface{}
+
+var idSchema = obj{
+	"type":       "object",
+	"properties": obj{"id": obj{"type": "integer", "format": "int64"}},
+}
+
+var fieldsParameter = obj{
+	"name": "fields", "in": "query",
+	"description": "Comma-separated fields to return; all fields when omitted",
+	"schema":      obj{"type": "string"},
+}
+
+func ref(name string) obj {
+	return obj{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonResponse(description string, schema obj) obj {
+	return obj{
+		"description": description,
+		"content":     obj{"application/json": obj{"schema": schema}},
+	}
+}
+
+// operation builds an operation with the error responses of the handler
+func operation(summary, id string, body obj, responses obj, parameters ...obj) obj {
+	for _, status := range []string{"400", "403", "404", "422", "502"} {
+		responses[status] = jsonResponse("Error", ref("Error"))
+	}
+	op := obj{"summary": summary, "operationId": id, "responses": responses}
+	if body != nil {
+		op["requestBody"] = obj{
+			"required": true,
+			"content":  obj{"application/json": obj{"schema": body}},
+		}
+	}
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+	return op
+}
+
+func searchOperation(model string, opts Options) obj {
+	query := func(name, description string, schema obj) obj {
+		return obj{"name": name, "in": "query", "description": description, "schema": schema}
+	}
+	return operation("Search "+model+" records", model+".search", nil, obj{
+		"200": jsonResponse("The matching records", obj{
+			"type": "object",
+			"properties": obj{
+				"records": obj{"type": "array", "items": ref(model)},
+				"offset":  obj{"type": "integer"},
+				"limit":   obj{"type": "integer"},
+			},
+		}),
+	},
+		fieldsParameter,
+		query("domain", "Domain as JSON or in Odoo's Python syntax, e.g. [('state', '=', 'sale')]", obj{"type": "string"}),
+		query("limit", "Maximum number of records", obj{"type": "integer", "minimum": 0, "maximum": opts.MaxLimit, "default": opts.DefaultLimit}),
+		query("offset", "Number of records to skip", obj{"type": "integer", "minimum": 0}),
+		query("order", "Sort order, e.g. \"date_order desc, id\"", obj{"type": "string"}),
+	)
+}
+
+// modelSchemas returns the read and write schemas of a model and its
+// required fields
+func modelSchemas(fields map[string]interface{}) (obj, obj, []string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	read, write := obj{}, obj{}
+	var required []string
+	for _, name := range names {
+		attrs, _ := fields[name].(map[string]interface{})
+		fieldType, _ := attrs["type"].(string)
+		description, _ := attrs["string"].(string)
+		if help, _ := attrs["help"].(string); help != "" {
+			description += ". " + help
+		}
+
+		readSchema, writeSchema := fieldSchemas(fieldType, attrs)
+		readSchema["description"] = description
+		if relation, _ := attrs["relation"].(string); relation != "" {
+			readSchema["x-odoo-relation"] = relation
+		}
+		read[name] = readSchema
+		if readonly, _ := attrs["readonly"].(bool); readonly || name == "id" {
+			continue
+		}
+		writeSchema["description"] = description
+		write[name] = writeSchema
+		if isRequired, _ := attrs["required"].(bool); isRequired {
+			required = append(required, name)
+		}
+	}
+	return obj{"type": "object", "properties": read}, obj{"type": "object", "properties": write}, required
+}
+
+// fieldSchemas returns the schemas of a field as read and as written. Odoo
+// returns false for empty values of every type, which the read schemas allow.
+func fieldSchemas(fieldType string, attrs map[string]interface{}) (obj, obj) {
+	var schema obj
+	switch fieldType {
+	case "boolean":
+		return obj{"type": "boolean"}, obj{"type": "boolean"}
+	case "integer":
+		schema = obj{"type": "integer"}
+	case "float", "monetary":
+		schema = obj{"type": "number"}
+	case "date":
+		schema = obj{"type": "string", "format": "date"}
+	case "datetime":
+		schema = obj{"type": "string", "example": "2024-01-31 13:45:00", "pattern": `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`}
+	case "binary":
+		schema = obj{"type": "string", "format": "byte"}
+	case "selection":
+		schema = obj{"type": "string"}
+		if options, ok := attrs["selection"].([]interface{}); ok {
+			var values []interface{}
+			for _, option := range options {
+				if pair, ok := option.([]interface{}); ok && len(pair) > 0 {
+					values = append(values, pair[0])
+				}
+			}
+			if len(values) > 0 {
+				schema["enum"] = values
+			}
+		}
+	case "many2one":
+		// Read as [id, display name], written as the ID
+		read := obj{
+			"type":     "array",
+			"items":    obj{"oneOf": []interface{}{obj{"type": "integer"}, obj{"type": "string"}}},
+			"minItems": 2, "maxItems": 2,
+		}
+		return orFalse(read), orFalse(obj{"type": "integer"})
+	case "one2many", "many2many":
+		// Read as IDs, written as IDs or Odoo's x2many commands
+		read := obj{"type": "array", "items": obj{"type": "integer"}}
+		write := obj{"type": "array", "items": obj{"oneOf": []interface{}{
+			obj{"type": "integer"},
+			obj{"type": "array", "description": "x2many command, e.g. [6, 0, [ids]]"},
+		}}}
+		return read, write
+	default:
+		schema = obj{"type": "string"}
+	}
+	write := obj{}
+	for k, v := range schema {
+		write[k] = v
+	}
+	return orFalse(schema), orFalse(write)
+}
+
+// orFalse allows false, Odoo's empty value, besides schema
+func orFalse(schema obj) obj {
+	return obj{"oneOf": []interface{}{schema, obj{"type": "boolean", "enum": []interface{}{false}}}}
+}
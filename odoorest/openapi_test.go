@@ -0,0 +1,79 @@
+package odoorest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type fakeSchemaClient struct{}
+
+func (fakeSchemaClient) ExecuteMethod(model, method string, args []interface{}, kwargs map[string]interface{}) (interface{}, error) {
+	return map[string]interface{}{
+		"id":         map[string]interface{}{"type": "integer", "string": "ID", "readonly": true},
+		"name":       map[string]interface{}{"type": "char", "string": "Name", "required": true},
+		"state":      map[string]interface{}{"type": "selection", "string": "Status", "selection": []interface{}{[]interface{}{"draft", "Draft"}, []interface{}{"sale", "Sales Order"}}},
+		"partner_id": map[string]interface{}{"type": "many2one", "string": "Customer", "relation": "res.partner", "required": true},
+	}, nil
+}
+
+func TestOpenAPI(t *testing.T) {
+	data, err := OpenAPI(fakeSchemaClient{}, Options{Models: map[string][]Operation{
+		"sale.order":  {OpRead, OpCreate},
+		"res.partner": ReadOnly,
+	}}, OpenAPIInfo{ServerURL: "https://tools.example.com/api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(v interface{}, path ...string) interface{} {
+		for _, key := range path {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				t.Fatalf("%v: not an object at %q", path, key)
+			}
+			v = m[key]
+		}
+		return v
+	}
+
+	paths := get(doc, "paths").(map[string]interface{})
+	var names []string
+	for name, item := range paths {
+		for method := range item.(map[string]interface{}) {
+			if method != "parameters" {
+				names = append(names, method+" "+name)
+			}
+		}
+	}
+	for _, want := range []string{"get /models/sale.order", "post /models/sale.order", "get /models/sale.order/{id}", "get /models/res.partner/{id}"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("missing %s in %v", want, names)
+		}
+	}
+	if _, ok := paths["/models/res.partner"].(map[string]interface{})["post"]; ok {
+		t.Error("read-only model has a create operation")
+	}
+
+	if got := get(doc, "components", "schemas", "sale.order.create", "allOf").([]interface{})[1]; !reflect.DeepEqual(got, map[string]interface{}{"required": []interface{}{"name", "partner_id"}}) {
+		t.Errorf("create requires %v", got)
+	}
+	if _, ok := get(doc, "components", "schemas", "sale.order.values", "properties").(map[string]interface{})["id"]; ok {
+		t.Error("read-only id is writable")
+	}
+	state := get(doc, "components", "schemas", "sale.order", "properties", "state", "oneOf").([]interface{})[0]
+	if got := get(state, "enum"); !reflect.DeepEqual(got, []interface{}{"draft", "sale"}) {
+		t.Errorf("state enum = %v", got)
+	}
+	if got := get(doc, "components", "schemas", "sale.order", "properties", "partner_id", "x-odoo-relation"); got != "res.partner" {
+		t.Errorf("partner_id relation = %v", got)
+	}
+}
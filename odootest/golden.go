@@ -0,0 +1,193 @@
+package odootest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Masked replaces the values of volatile fields in golden files
+const Masked = "<masked>"
+
+// DefaultVolatileFields change on every run and are always masked
+var DefaultVolatileFields = []string{"create_date", "write_date", "__last_update"}
+
+// GoldenOptions configures AssertGolden
+type GoldenOptions struct {
+	// Mask lists further fields whose values are masked
+	Mask []string
+	// MaskIDs masks record IDs, the IDs of many2one values and the IDs of
+	// x2many lists, which differ between scratch databases
+	MaskIDs bool
+	// SortBy orders the records by these fields; by default records are
+	// ordered by their normalized content
+	SortBy []string
+}
+
+// AssertGolden compares records, e.g. the result of search_read, with the
+// golden file at path. Records are normalized first: volatile fields are
+// masked and records get a stable order. On mismatch the test fails with a
+// line diff.
+//
+// Set ODOOTEST_UPDATE=1 to (re)write the golden files instead.
+func AssertGolden(t testing.TB, path string, records []map[string]interface{}, opts GoldenOptions) {
+	t.Helper()
+	got, err := NormalizeRecords(records, opts)
+	if err != nil {
+		t.Fatalf("odootest: normalizing records: %v", err)
+	}
+
+	if os.Getenv("ODOOTEST_UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("odootest: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("odootest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("odootest: %v (run with ODOOTEST_UPDATE=1 to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("odootest: records differ from %s (-want +got):\n%s", path, lineDiff(string(want), string(got)))
+	}
+}
+
+// NormalizeRecords returns records as indented JSON with volatile values
+// masked and a stable record order
+func NormalizeRecords(records []map[string]interface{}, opts GoldenOptions) ([]byte, error) {
+	masked := make(map[string]bool)
+	for _, field := range DefaultVolatileFields {
+		masked[field] = true
+	}
+	for _, field := range opts.Mask {
+		masked[field] = true
+	}
+
+	normalized := make([]map[string]interface{}, len(records))
+	sortKeys := make([]string, len(records))
+	for i, record := range records {
+		n := make(map[string]interface{}, len(record))
+		for field, value := range record {
+			switch {
+			case masked[field]:
+				n[field] = Masked
+			case opts.MaskIDs:
+				n[field] = maskIDs(field, value)
+			default:
+				n[field] = value
+			}
+		}
+		normalized[i] = n
+
+		key, err := sortKey(n, opts.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		sortKeys[i] = key
+	}
+
+	order := make([]int, len(records))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return sortKeys[order[a]] < sortKeys[order[b]]
+	})
+	sorted := make([]map[string]interface{}, len(records))
+	for i, index := range order {
+		sorted[i] = normalized[index]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sorted); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// maskIDs masks the database IDs in a field value
+func maskIDs(field string, value interface{}) interface{} {
+	if field == "id" {
+		return Masked
+	}
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return value
+	}
+	// many2one values are [id, display_name]
+	if len(list) == 2 {
+		if _, isName := list[1].(string); isName {
+			return []interface{}{Masked, list[1]}
+		}
+	}
+	// x2many values are lists of IDs; only their size is stable
+	return fmt.Sprintf("%s (%d records)", Masked, len(list))
+}
+
+func sortKey(record map[string]interface{}, fields []string) (string, error) {
+	var data []byte
+	var err error
+	if len(fields) == 0 {
+		data, err = json.Marshal(record)
+	} else {
+		values := make([]interface{}, len(fields))
+		for i, field := range fields {
+			values[i] = record[field]
+		}
+		data, err = json.Marshal(values)
+	}
+	return string(data), err
+}
+
+// lineDiff returns a minimal line diff of want and got, prefixing removed
+// lines with - and added lines with +
+func lineDiff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,47 @@
+package odootest
+
+import (
+	"testing"
+)
+
+func TestNormalizeRecords(t *testing.T) {
+	records := []map[string]interface{}{
+		{"id": int64(9), "name": "B", "write_date": "2024-05-01 10:00:00", "user_id": []interface{}{int64(2), "Admin"}, "tag_ids": []interface{}{int64(4), int64(5), int64(6)}},
+		{"id": int64(8), "name": "A", "write_date": "2024-05-01 10:00:01", "user_id": false, "tag_ids": []interface{}{}},
+	}
+	got, err := NormalizeRecords(records, GoldenOptions{MaskIDs: true, SortBy: []string{"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+  {
+    "id": "<masked>",
+    "name": "A",
+    "tag_ids": [],
+    "user_id": false,
+    "write_date": "<masked>"
+  },
+  {
+    "id": "<masked>",
+    "name": "B",
+    "tag_ids": "<masked> (3 records)",
+    "user_id": [
+      "<masked>",
+      "Admin"
+    ],
+    "write_date": "<masked>"
+  }
+]
+`
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := lineDiff("a\nb\nc", "a\nc\nd")
+	want := "  a\n- b\n  c\n+ d\n"
+	if got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
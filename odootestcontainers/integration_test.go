@@ -0,0 +1,62 @@
+//go:build odoointegration
+
+package odootestcontainers
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RolandZimmermann/go-odoo-connector"
+)
+
+// The smoke test needs a Docker daemon and pulls the images on first run:
+//
+//	go test -tags odoointegration ./odootestcontainers
+
+func TestSmoke(t *testing.T) {
+	env := New(t, Options{})
+
+	records, err := env.Connector.SearchReadRecords("res.users", odoo.SearchReadOptions{
+		Fields: []string{"login"},
+		Domain: []interface{}{[]interface{}{"login", "=", "admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Errorf("got %v, want the admin user", records)
+	}
+}
+
+func TestRemoveStale(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker is not available")
+	}
+	ctx := context.Background()
+
+	// A network left behind two hours ago and one of a run still going on
+	old := fmt.Sprintf("%s=%d", createdLabel, time.Now().Add(-2*time.Hour).Unix())
+	recent := fmt.Sprintf("%s=%d", createdLabel, time.Now().Unix())
+	suffix := randomSuffix()
+	for name, created := range map[string]string{"odootc-stale-" + suffix: old, "odootc-live-" + suffix: recent} {
+		if _, err := docker(ctx, "network", "create", "--label", label+"=true", "--label", created, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { docker(context.Background(), "network", "rm", "odootc-live-"+suffix) })
+
+	if err := removeStale(ctx, time.Now(), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	networks, err := docker(ctx, "network", "ls", "--filter", "label="+label, "--format", "{{.Name}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(networks, "odootc-stale-"+suffix) || !strings.Contains(networks, "odootc-live-"+suffix) {
+		t.Errorf("networks after the cleanup: %s", networks)
+	}
+}
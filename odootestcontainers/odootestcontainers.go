@@ -0,0 +1,270 @@
+// Package odootestcontainers starts throwaway Odoo and PostgreSQL containers
+// for hermetic integration tests. It drives the docker CLI, so the only
+// requirement in CI is a Docker daemon; no Odoo server has to be provided.
+//
+//	//go:build odoointegration
+//
+//	func TestFlow(t *testing.T) {
+//		env := odootestcontainers.New(t, odootestcontainers.Options{
+//			Image:   "odoo:17",
+//			Modules: []string{"sale"},
+//		})
+//		orders, err := env.Connector.SearchReadRecords("sale.order", odoo.SearchReadOptions{})
+//		...
+//	}
+//
+// Containers are started once per call; to share them across the tests of a
+// package, call Start from TestMain and odootest.New per test.
+//
+// Containers and networks are labelled "odootestcontainers". Start removes
+// the labelled ones older than Options.StaleAfter, which test binaries killed
+// before their cleanup ran leave behind.
+package odootestcontainers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RolandZimmermann/go-odoo-connector"
+	"github.com/RolandZimmermann/go-odoo-connector/odootest"
+)
+
+// Options configures the containers
+type Options struct {
+	// Image is the Odoo image (default odoo:17)
+	Image string
+	// PostgresImage is the database image (default postgres:16)
+	PostgresImage string
+	// Modules are installed into the test database
+	Modules []string
+	Demo    bool
+	// StartupTimeout bounds the time until Odoo answers (default 3 minutes)
+	StartupTimeout time.Duration
+	// StaleAfter is the age after which labelled containers of earlier runs
+	// are considered abandoned and removed by Start (default 1 hour)
+	StaleAfter time.Duration
+}
+
+// Labels of the containers and networks started by this package
+const (
+	label        = "odootestcontainers"
+	createdLabel = "odootestcontainers.created"
+)
+
+// masterPassword is the default admin_passwd of the official image
+const masterPassword = "admin"
+
+// Containers are running Odoo and PostgreSQL containers
+type Containers struct {
+	// URL of the Odoo server, published on a random local port
+	URL            string
+	MasterPassword string
+
+	network  string
+	postgres string
+	odoo     string
+}
+
+// Start starts PostgreSQL and Odoo on a private network and waits until Odoo
+// answers. Call Terminate to remove them.
+func Start(ctx context.Context, opts Options) (*Containers, error) {
+	if opts.Image == "" {
+		opts.Image = "odoo:17"
+	}
+	if opts.PostgresImage == "" {
+		opts.PostgresImage = "postgres:16"
+	}
+	if opts.StartupTimeout == 0 {
+		opts.StartupTimeout = 3 * time.Minute
+	}
+	if opts.StaleAfter == 0 {
+		opts.StaleAfter = time.Hour
+	}
+
+	if err := removeStale(ctx, time.Now(), opts.StaleAfter); err != nil {
+		return nil, fmt.Errorf("removing stale containers failed: %w", err)
+	}
+
+	labels := []string{"--label", label + "=true", "--label", fmt.Sprintf("%s=%d", createdLabel, time.Now().Unix())}
+	suffix := randomSuffix()
+	c := &Containers{MasterPassword: masterPassword, network: "odootc-" + suffix}
+	if _, err := docker(ctx, append(append([]string{"network", "create"}, labels...), c.network)...); err != nil {
+		return nil, err
+	}
+
+	run := append([]string{"run", "-d", "--network", c.network}, labels...)
+	run = run[:len(run):len(run)]
+	postgres, err := docker(ctx, append(run, "--name", "odootc-db-"+suffix,
+		"-e", "POSTGRES_USER=odoo", "-e", "POSTGRES_PASSWORD=odoo", "-e", "POSTGRES_DB=postgres",
+		opts.PostgresImage)...)
+	if err != nil {
+		c.Terminate(context.Background())
+		return nil, err
+	}
+	c.postgres = postgres
+
+	odooID, err := docker(ctx, append(run, "--name", "odootc-odoo-"+suffix,
+		"-p", "127.0.0.1::8069",
+		"-e", "HOST=odootc-db-"+suffix, "-e", "USER=odoo", "-e", "PASSWORD=odoo",
+		opts.Image)...)
+	if err != nil {
+		c.Terminate(context.Background())
+		return nil, err
+	}
+	c.odoo = odooID
+
+	address, err := docker(ctx, "port", c.odoo, "8069/tcp")
+	if err != nil {
+		c.Terminate(context.Background())
+		return nil, err
+	}
+	// docker port may list an address per IP family
+	address, _, _ = strings.Cut(address, "\n")
+	c.URL = "http://" + address
+
+	if err := c.waitReady(ctx, opts.StartupTimeout); err != nil {
+		logs, _ := docker(context.Background(), "logs", "--tail", "50", c.odoo)
+		c.Terminate(context.Background())
+		return nil, fmt.Errorf("%w\n%s", err, logs)
+	}
+	return c, nil
+}
+
+// waitReady polls the database service until Odoo answers
+func (c *Containers) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		callCtx, callCancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := odoo.ListDatabases(callCtx, c.URL)
+		callCancel()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("odoo did not start at %s: %w", c.URL, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Terminate removes the containers and their network
+func (c *Containers) Terminate(ctx context.Context) error {
+	var firstErr error
+	for _, id := range []string{c.odoo, c.postgres} {
+		if id == "" {
+			continue
+		}
+		if _, err := docker(ctx, "rm", "-f", "-v", id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if _, err := docker(ctx, "network", "rm", c.network); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// removeStale removes the labelled containers and networks created more than
+// maxAge before now
+func removeStale(ctx context.Context, now time.Time, maxAge time.Duration) error {
+	format := fmt.Sprintf("{{.ID}} {{.Label %q}}", createdLabel)
+	containers, err := docker(ctx, "ps", "-a", "--filter", "label="+label, "--format", format)
+	if err != nil {
+		return err
+	}
+	if ids := staleIDs(containers, now, maxAge); len(ids) > 0 {
+		if _, err := docker(ctx, append([]string{"rm", "-f", "-v"}, ids...)...); err != nil {
+			return err
+		}
+	}
+
+	networks, err := docker(ctx, "network", "ls", "--filter", "label="+label, "--format", format)
+	if err != nil {
+		return err
+	}
+	if ids := staleIDs(networks, now, maxAge); len(ids) > 0 {
+		if _, err := docker(ctx, append([]string{"network", "rm"}, ids...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// staleIDs returns the IDs of the "<id> <created>" lines listed by docker
+// whose creation time lies more than maxAge before now
+func staleIDs(listing string, now time.Time, maxAge time.Duration) []string {
+	var ids []string
+	for _, line := range strings.Split(listing, "\n") {
+		id, created, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		unix, err := strconv.ParseInt(created, 10, 64)
+		if err != nil {
+			continue
+		}
+		if now.Sub(time.Unix(unix, 0)) > maxAge {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// New starts containers for the test, creates a database with opts.Modules
+// and returns a connected environment. Everything is removed when the test
+// ends; failures abort the test.
+func New(t testing.TB, opts Options) *odootest.Env {
+	t.Helper()
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker is not available")
+	}
+
+	c, err := Start(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("odootestcontainers: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Terminate(context.Background()); err != nil {
+			t.Errorf("odootestcontainers: %v", err)
+		}
+	})
+
+	return odootest.New(t, odootest.Options{
+		URL:            c.URL,
+		MasterPassword: c.MasterPassword,
+		Modules:        opts.Modules,
+		Demo:           opts.Demo,
+	})
+}
+
+// docker runs a docker command and returns its trimmed output
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func randomSuffix() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,18 @@
+package odootestcontainers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStaleIDs(t *testing.T) {
+	now := time.Unix(10000, 0)
+	listing := "aaa 6000\nbbb 9000\nccc \nddd not-a-time\n\neee 6400"
+	if got, want := staleIDs(listing, now, time.Hour), []string{"aaa"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := staleIDs("", now, time.Hour); got != nil {
+		t.Errorf("empty listing: got %v", got)
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/RolandZimmermann/go-odoo-connector/odoozap
+
+go 1.21
+
+require go.uber.org/zap v1.27.0
+
+require go.uber.org/multierr v1.10.0 // indirect
@@ -0,0 +1,138 @@
+// Package odoozap adapts a zap logger to the *slog.Logger accepted by
+// odoo.WithLogger, so the connector logs in the same format as the rest of a
+// zap-based service. Attributes of groups are logged with dotted keys such
+// as "call.model", like odoozerolog does.
+//
+//	connector, err := odoo.NewConnector(url, username, apiKey, db,
+//		odoo.WithLogger(odoozap.New(zapLogger)))
+package odoozap
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// New returns a *slog.Logger writing to logger
+func New(logger *zap.Logger) *slog.Logger {
+	return slog.New(NewHandler(logger.Core()))
+}
+
+// Handler is a slog.Handler writing to a zap core
+type Handler struct {
+	core   zapcore.Core
+	fields []zap.Field
+	groups []string
+}
+
+// NewHandler returns a slog.Handler writing to core
+func NewHandler(core zapcore.Core) *Handler {
+	return &Handler{core: core}
+}
+
+// Enabled reports whether the core logs records of the given level
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(zapLevel(level))
+}
+
+// Handle writes the record to the core
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	entry := zapcore.Entry{
+		Level:   zapLevel(record.Level),
+		Time:    record.Time,
+		Message: record.Message,
+	}
+	checked := h.core.Check(entry, nil)
+	if checked == nil {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(h.fields)+record.NumAttrs())
+	fields = append(fields, h.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendFields(fields, h.groups, attr)
+		return true
+	})
+	checked.Write(fields...)
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.fields = append([]zap.Field(nil), h.fields...)
+	for _, attr := range attrs {
+		clone.fields = appendFields(clone.fields, h.groups, attr)
+	}
+	return &clone
+}
+
+// WithGroup returns a handler qualifying subsequent attributes with name
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(append([]string(nil), h.groups...), name)
+	return &clone
+}
+
+// appendFields appends attr to fields, a group as one field per member
+func appendFields(fields []zap.Field, groups []string, attr slog.Attr) []zap.Field {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+		for _, member := range value.Group() {
+			fields = appendFields(fields, groups, member)
+		}
+		return fields
+	}
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	key := qualify(groups, attr.Key)
+	switch value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(key, value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(key, value.Time()))
+	default:
+		return append(fields, zap.Any(key, value.Any()))
+	}
+}
+
+func qualify(groups []string, key string) string {
+	if len(groups) == 0 {
+		return key
+	}
+	return strings.Join(groups, ".") + "." + key
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
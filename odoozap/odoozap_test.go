@@ -0,0 +1,146 @@
+package odoozap
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandlerAttrs(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := slog.New(NewHandler(core))
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	logger.Info("call",
+		slog.String("model", "res.partner"),
+		slog.Int("id", 7),
+		slog.Uint64("size", 9),
+		slog.Float64("ratio", 0.5),
+		slog.Bool("retry", true),
+		slog.Duration("took", time.Second),
+		slog.Time("at", at),
+		slog.Any("ids", []int{1, 2}),
+	)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0].Message != "call" {
+		t.Fatalf("message %q, want call", entries[0].Message)
+	}
+	fields := entries[0].ContextMap()
+	want := map[string]interface{}{
+		"model": "res.partner",
+		"id":    int64(7),
+		"size":  uint64(9),
+		"ratio": 0.5,
+		"retry": true,
+		"took":  time.Second,
+		"at":    at,
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v (%T), want %v (%T)", key, fields[key], fields[key], value, value)
+		}
+	}
+	if ids, ok := fields["ids"].([]interface{}); !ok || len(ids) != 2 {
+		t.Errorf("ids = %v", fields["ids"])
+	}
+}
+
+func TestHandlerGroups(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := slog.New(NewHandler(core)).
+		With(slog.String("db", "prod")).
+		WithGroup("call").
+		With(slog.String("model", "res.partner"))
+
+	logger.Info("call",
+		slog.Group("args", slog.Int("limit", 10)),
+		slog.Group("", slog.Bool("retry", false)),
+		slog.Attr{},
+	)
+
+	fields := logs.All()[0].ContextMap()
+	want := map[string]interface{}{
+		"db":              "prod",
+		"call.model":      "res.partner",
+		"call.args.limit": int64(10),
+		"call.retry":      false,
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("fields %v, want %v", fields, want)
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+}
+
+func TestHandlerLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	handler := NewHandler(core)
+	logger := slog.New(handler)
+
+	if handler.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("debug enabled on an info core")
+	}
+	if !handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("info disabled on an info core")
+	}
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Log(context.Background(), slog.LevelError+4, "fatal")
+
+	want := []zapcore.Level{zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.ErrorLevel}
+	entries := logs.All()
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, entry := range entries {
+		if entry.Level != want[i] {
+			t.Errorf("%s logged at %s, want %s", entry.Message, entry.Level, want[i])
+		}
+	}
+}
+
+func TestHandlerConcurrentGroups(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	// Three nested groups leave spare capacity in the handler's group slice
+	logger := slog.New(NewHandler(core)).WithGroup("a").WithGroup("b").WithGroup("c")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		key := string(rune('p' + i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("call", slog.Group(key, slog.String("model", key)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			if !strings.HasPrefix(key, "a.b.c.") {
+				continue
+			}
+			if want := "a.b.c." + value.(string) + ".model"; key != want {
+				t.Fatalf("key %s, want %s", key, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/RolandZimmermann/go-odoo-connector/odoozerolog
+
+go 1.21
+
+require github.com/rs/zerolog v1.33.0
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
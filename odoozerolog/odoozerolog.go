@@ -0,0 +1,144 @@
+// Package odoozerolog adapts a zerolog logger to the *slog.Logger accepted by
+// odoo.WithLogger, so the connector logs in the same format as the rest of a
+// zerolog-based service. Attributes of groups are logged with dotted keys
+// such as "call.model", like odoozap does.
+//
+//	connector, err := odoo.NewConnector(url, username, apiKey, db,
+//		odoo.WithLogger(odoozerolog.New(zerologLogger)))
+package odoozerolog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// New returns a *slog.Logger writing to logger
+func New(logger zerolog.Logger) *slog.Logger {
+	return slog.New(NewHandler(logger))
+}
+
+// Handler is a slog.Handler writing to a zerolog logger
+type Handler struct {
+	logger zerolog.Logger
+	groups []string
+}
+
+// NewHandler returns a slog.Handler writing to logger
+func NewHandler(logger zerolog.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// Enabled reports whether the logger writes records of the given level
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return zerologLevel(level) >= h.logger.GetLevel() && zerologLevel(level) >= zerolog.GlobalLevel()
+}
+
+// Handle writes the record to the logger
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(zerologLevel(record.Level))
+	if event == nil {
+		return nil
+	}
+	if !record.Time.IsZero() {
+		event = event.Time(zerolog.TimestampFieldName, record.Time)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		event = h.appendAttr(event, h.groups, attr)
+		return true
+	})
+	event.Msg(record.Message)
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	ctx := h.logger.With()
+	for _, attr := range attrs {
+		ctx = appendContext(ctx, h.groups, attr)
+	}
+	return &Handler{logger: ctx.Logger(), groups: h.groups}
+}
+
+// WithGroup returns a handler qualifying subsequent attributes with name
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{logger: h.logger, groups: append(append([]string(nil), h.groups...), name)}
+}
+
+func (h *Handler) appendAttr(event *zerolog.Event, groups []string, attr slog.Attr) *zerolog.Event {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+		for _, member := range value.Group() {
+			event = h.appendAttr(event, groups, member)
+		}
+		return event
+	}
+	if attr.Equal(slog.Attr{}) {
+		return event
+	}
+
+	key := qualify(groups, attr.Key)
+	switch value.Kind() {
+	case slog.KindString:
+		return event.Str(key, value.String())
+	case slog.KindInt64:
+		return event.Int64(key, value.Int64())
+	case slog.KindUint64:
+		return event.Uint64(key, value.Uint64())
+	case slog.KindFloat64:
+		return event.Float64(key, value.Float64())
+	case slog.KindBool:
+		return event.Bool(key, value.Bool())
+	case slog.KindDuration:
+		return event.Dur(key, value.Duration())
+	case slog.KindTime:
+		return event.Time(key, value.Time())
+	default:
+		return event.Interface(key, value.Any())
+	}
+}
+
+func appendContext(ctx zerolog.Context, groups []string, attr slog.Attr) zerolog.Context {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], attr.Key)
+		}
+		for _, member := range value.Group() {
+			ctx = appendContext(ctx, groups, member)
+		}
+		return ctx
+	}
+	if attr.Equal(slog.Attr{}) {
+		return ctx
+	}
+	return ctx.Interface(qualify(groups, attr.Key), value.Any())
+}
+
+func qualify(groups []string, key string) string {
+	if len(groups) == 0 {
+		return key
+	}
+	return strings.Join(groups, ".") + "." + key
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	default:
+		return zerolog.DebugLevel
+	}
+}
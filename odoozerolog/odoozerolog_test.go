@@ -0,0 +1,91 @@
+package odoozerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// syncBuffer serializes the writes of concurrent log calls
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestHandlerConcurrentGroups(t *testing.T) {
+	var out syncBuffer
+	// Three nested groups leave spare capacity in the handler's group slice
+	logger := New(zerolog.New(&out)).WithGroup("a").WithGroup("b").WithGroup("c")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		key := string(rune('p' + i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("call", slog.Group(key, slog.String("model", key)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, line := range strings.Split(strings.TrimSpace(out.buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		for key, value := range entry {
+			if !strings.HasPrefix(key, "a.b.c.") {
+				continue
+			}
+			if want := "a.b.c." + value.(string) + ".model"; key != want {
+				t.Fatalf("key %s, want %s", key, want)
+			}
+		}
+	}
+}
+
+func TestHandlerGroups(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(zerolog.New(&out)).
+		With(slog.String("db", "prod")).
+		WithGroup("call").
+		With(slog.String("model", "res.partner"))
+
+	logger.Info("call",
+		slog.Group("args", slog.Int("limit", 10)),
+		slog.Group("", slog.Bool("retry", false)),
+		slog.Attr{},
+	)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"db":              "prod",
+		"call.model":      "res.partner",
+		"call.args.limit": float64(10),
+		"call.retry":      false,
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("%s = %v, want %v", key, entry[key], value)
+		}
+	}
+	if _, ok := entry[""]; ok {
+		t.Errorf("empty attribute logged: %v", entry)
+	}
+}
@@ -0,0 +1,14 @@
+package odoo
+
+// Option configures optional behaviour of a Connector
+type Option func(*Connector)
+
+// WithSessionAuth enables web session authentication next to the XML-RPC
+// API key, for the endpoints only reachable through a browser-like session
+// (see SessionInfo). Web logins do not accept API keys, so the user's
+// password has to be provided.
+func WithSessionAuth(password string) Option {
+	return func(c *Connector) {
+		c.sessionPassword = password
+	}
+}
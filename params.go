@@ -0,0 +1,172 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ParamCache is a cached view over ir.config_parameter. Values are served from
+// memory until they are older than the TTL, and callers can register
+// watchers that fire when a refresh observes a changed value.
+//
+//	params := c.NewParamCache(5 * time.Minute)
+//	params.Watch("web.base.url", func(key, oldValue, newValue string) {
+//		log.Printf("%s changed to %s", key, newValue)
+//	})
+//	go params.Run(ctx, time.Minute)
+//	baseURL, err := params.Get(ctx, "web.base.url")
+type ParamCache struct {
+	c   *Connector
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]paramEntry
+	watchers map[string][]ParamWatcher
+}
+
+// ParamWatcher is called with the previous and the new value of a changed
+// parameter. An empty value means the parameter does not exist.
+type ParamWatcher func(key, oldValue, newValue string)
+
+type paramEntry struct {
+	value   string
+	fetched time.Time
+}
+
+// NewParamCache returns an empty parameter cache whose entries expire after
+// ttl. A zero ttl keeps values until the next Refresh.
+func (c *Connector) NewParamCache(ttl time.Duration) *ParamCache {
+	return &ParamCache{
+		c:        c,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]paramEntry),
+		watchers: make(map[string][]ParamWatcher),
+	}
+}
+
+// Get returns the value of the system parameter key, fetching it from the
+// server when it is not cached or has expired
+func (p *ParamCache) Get(ctx context.Context, key string) (string, error) {
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	p.mu.Unlock()
+	if ok && (p.ttl == 0 || p.now().Sub(entry.fetched) < p.ttl) {
+		return entry.value, nil
+	}
+
+	values, err := p.fetch(ctx, []string{key})
+	if err != nil {
+		return "", err
+	}
+	p.store(values, []string{key})
+	return values[key], nil
+}
+
+// Watch registers fn to be called whenever a refresh observes a new value for
+// key. Watched keys are refreshed even if they were never read with Get.
+func (p *ParamCache) Watch(key string, fn ParamWatcher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.watchers[key] = append(p.watchers[key], fn)
+}
+
+// Invalidate drops key from the cache so the next Get fetches it again
+func (p *ParamCache) Invalidate(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, key)
+}
+
+// Refresh reloads all cached and watched parameters in a single call and
+// notifies the watchers of changed values
+func (p *ParamCache) Refresh(ctx context.Context) error {
+	p.mu.Lock()
+	keys := make([]string, 0, len(p.entries)+len(p.watchers))
+	for key := range p.entries {
+		keys = append(keys, key)
+	}
+	for key := range p.watchers {
+		if _, ok := p.entries[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	p.mu.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := p.fetch(ctx, keys)
+	if err != nil {
+		return err
+	}
+	p.store(values, keys)
+	return nil
+}
+
+// Run refreshes the cache every interval until ctx is done. Refresh errors
+// are skipped; the previous values stay in place until the next attempt.
+func (p *ParamCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = p.Refresh(ctx)
+		}
+	}
+}
+
+func (p *ParamCache) fetch(ctx context.Context, keys []string) (map[string]string, error) {
+	var records []map[string]interface{}
+	err := p.c.execute(ctx, "ir.config_parameter", "search_read", []interface{}{[]interface{}{
+		[]interface{}{"key", "in", keys},
+	}}, map[string]interface{}{
+		"fields": []string{"key", "value"},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("system parameter lookup failed for %v: %w", keys, err)
+	}
+
+	values := make(map[string]string, len(records))
+	for _, record := range records {
+		key, _ := record["key"].(string)
+		value, _ := record["value"].(string)
+		values[key] = value
+	}
+	return values, nil
+}
+
+// store caches the fetched values of keys and calls the watchers of the keys
+// whose value differs from the previously known one. Watchers are not called
+// for the first value seen of a key.
+func (p *ParamCache) store(values map[string]string, keys []string) {
+	type change struct {
+		key, oldValue, newValue string
+		watchers                []ParamWatcher
+	}
+	var changes []change
+
+	now := p.now()
+	p.mu.Lock()
+	for _, key := range keys {
+		previous, known := p.entries[key]
+		p.entries[key] = paramEntry{value: values[key], fetched: now}
+		if known && previous.value != values[key] && len(p.watchers[key]) > 0 {
+			changes = append(changes, change{key, previous.value, values[key], p.watchers[key]})
+		}
+	}
+	p.mu.Unlock()
+
+	for _, ch := range changes {
+		for _, fn := range ch.watchers {
+			fn(ch.key, ch.oldValue, ch.newValue)
+		}
+	}
+}
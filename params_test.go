@@ -0,0 +1,100 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParamCache(t *testing.T) {
+	values := map[string]string{"web.base.url": "http://localhost:8069", "database.uuid": "abc"}
+	var fetched [][]string
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		var keys []string
+		for _, key := range args[5].([]interface{})[0].([]interface{})[0].([]interface{})[2].([]interface{}) {
+			keys = append(keys, key.(string))
+		}
+		sort.Strings(keys)
+		fetched = append(fetched, keys)
+
+		records := []map[string]interface{}{}
+		for _, key := range keys {
+			if value, ok := values[key]; ok {
+				records = append(records, map[string]interface{}{"key": key, "value": value})
+			}
+		}
+		return records
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	clock := newFakeClock()
+	params := c.NewParamCache(time.Minute)
+	params.now = clock.now
+
+	get := func(key, want string) {
+		t.Helper()
+		value, err := params.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != want {
+			t.Errorf("Get(%s) = %q, want %q", key, value, want)
+		}
+	}
+
+	// Values are served from the cache until they expire
+	get("web.base.url", "http://localhost:8069")
+	values["web.base.url"] = "https://erp.example.com"
+	clock.advance(59 * time.Second)
+	get("web.base.url", "http://localhost:8069")
+	clock.advance(time.Second)
+	get("web.base.url", "https://erp.example.com")
+	get("missing", "")
+	if len(fetched) != 3 {
+		t.Errorf("%d fetches, want 3", len(fetched))
+	}
+
+	params.Invalidate("web.base.url")
+	get("web.base.url", "https://erp.example.com")
+	if len(fetched) != 4 {
+		t.Errorf("invalidated key was not fetched again")
+	}
+
+	// A refresh reloads cached and watched keys at once and reports changes
+	// of known values only
+	var changes []string
+	params.Watch("web.base.url", func(key, oldValue, newValue string) {
+		changes = append(changes, key+": "+oldValue+" -> "+newValue)
+	})
+	params.Watch("database.uuid", func(key, oldValue, newValue string) {
+		changes = append(changes, key+": "+oldValue+" -> "+newValue)
+	})
+	values["web.base.url"] = "https://odoo.example.com"
+	fetched = nil
+	if err := params.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"database.uuid", "missing", "web.base.url"}}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("refresh fetched %v, want %v", fetched, want)
+	}
+	if want := []string{"web.base.url: https://erp.example.com -> https://odoo.example.com"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes %v, want %v", changes, want)
+	}
+
+	changes = nil
+	values["database.uuid"] = "def"
+	if err := params.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"database.uuid: abc -> def"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes %v, want %v", changes, want)
+	}
+}
@@ -0,0 +1,271 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RolandZimmermann/go-odoo-connector/internal/parquet"
+)
+
+// ParquetOptions configures a Parquet export
+type ParquetOptions struct {
+	// Query selects the records to export. When Query.Fields is empty all
+	// stored, non-binary fields are exported.
+	Query SearchReadOptions
+	// Dir is the directory the files are written to
+	Dir string
+	// PartitionBy optionally names a field to partition the export by.
+	// Records are written to Hive-style directories (Dir/field=value/);
+	// date and datetime fields are partitioned by day. The partition field
+	// is not repeated inside the files.
+	PartitionBy string
+	// RowGroupSize is the number of rows per row group (default 10000)
+	RowGroupSize int
+	// Compress enables GZIP compression of the data pages
+	Compress bool
+}
+
+// ParquetExport describes the result of an export
+type ParquetExport struct {
+	Files []string
+	Rows  int64
+}
+
+// partitionDefault is the directory name Hive-style readers use for records
+// whose partition value is empty
+const partitionDefault = "__HIVE_DEFAULT_PARTITION__"
+
+// ExportParquet streams the records of model matching opts.Query into Parquet
+// files. The schema is derived from fields_get: integers, floats, booleans and
+// texts map to the corresponding Parquet types, dates and datetimes to DATE and
+// TIMESTAMP_MILLIS (UTC), many2one fields to the referenced ID and x2many or
+// other structured fields to JSON strings. Odoo's false becomes null except
+// for boolean fields.
+func (c *Connector) ExportParquet(ctx context.Context, model string, opts ParquetOptions) (*ParquetExport, error) {
+	if opts.PartitionBy != "" && len(opts.Query.Fields) > 0 {
+		opts.Query.Fields = append(append([]string{}, opts.Query.Fields...), opts.PartitionBy)
+	}
+	sink := &parquetSink{
+		dir:         opts.Dir,
+		partitionBy: opts.PartitionBy,
+		codec:       parquet.Uncompressed,
+		size:        opts.RowGroupSize,
+		writers:     make(map[string]*parquetFile),
+	}
+	if opts.Compress {
+		sink.codec = parquet.Gzip
+	}
+
+	rows, err := c.Export(ctx, model, ExportOptions{Query: opts.Query, BatchSize: opts.RowGroupSize}, sink)
+	if err != nil {
+		return nil, err
+	}
+	return &ParquetExport{Files: sink.files, Rows: rows}, nil
+}
+
+func parquetKind(fieldType string) parquet.Kind {
+	switch fieldType {
+	case "integer", "many2one", "many2one_reference":
+		return parquet.Int64
+	case "float", "monetary":
+		return parquet.Double
+	case "boolean":
+		return parquet.Boolean
+	case "date":
+		return parquet.Date
+	case "datetime":
+		return parquet.Timestamp
+	}
+	return parquet.String
+}
+
+// parquetValue converts a value read from Odoo into the Go type expected by
+// the Parquet column of fieldType
+func parquetValue(fieldType string, value interface{}) (interface{}, error) {
+	if b, ok := value.(bool); ok && fieldType == "boolean" {
+		return b, nil
+	}
+	if value == nil || value == false {
+		if fieldType == "boolean" {
+			return false, nil
+		}
+		return nil, nil
+	}
+
+	switch parquetKind(fieldType) {
+	case parquet.Int64:
+		if id, ok := many2oneID(value); ok {
+			return id, nil
+		}
+	case parquet.Double:
+		switch n := value.(type) {
+		case float64:
+			return n, nil
+		case int64:
+			return float64(n), nil
+		}
+	case parquet.Date:
+		if s, ok := value.(string); ok {
+			t, err := time.Parse(time.DateOnly, s)
+			if err != nil {
+				return nil, err
+			}
+			return int32(t.Unix() / 86400), nil
+		}
+	case parquet.Timestamp:
+		if s, ok := value.(string); ok {
+			t, err := time.Parse(time.DateTime, s)
+			if err != nil {
+				return nil, err
+			}
+			return t.UnixMilli(), nil
+		}
+	case parquet.String:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(encoded), nil
+	}
+	return nil, fmt.Errorf("unexpected value %v (%T) for %s field", value, value, fieldType)
+}
+
+// partitionValue returns the directory value a record is partitioned under
+func partitionValue(fieldType string, value interface{}) string {
+	if value == nil || (value == false && fieldType != "boolean") {
+		return partitionDefault
+	}
+	switch fieldType {
+	case "datetime":
+		if s, ok := value.(string); ok && len(s) >= len(time.DateOnly) {
+			return s[:len(time.DateOnly)]
+		}
+	case "many2one":
+		if id, ok := many2oneID(value); ok {
+			return strconv.FormatInt(id, 10)
+		}
+	}
+	s := fmt.Sprint(value)
+	// Keep the value usable as a single directory name
+	return strings.NewReplacer("/", "%2F", "=", "%3D", "\\", "%5C").Replace(s)
+}
+
+// parquetSink writes records to Parquet files, one per partition
+type parquetSink struct {
+	dir         string
+	partitionBy string
+	codec       parquet.Codec
+	size        int
+
+	schema        SinkSchema
+	names         []string
+	partitionType string
+	columns       []parquet.Column
+	writers       map[string]*parquetFile
+	files         []string
+}
+
+type parquetFile struct {
+	f *os.File
+	w *parquet.Writer
+}
+
+func (s *parquetSink) Open(ctx context.Context, schema SinkSchema) error {
+	s.schema = schema
+	if s.partitionBy != "" {
+		typ, ok := schema.Types[s.partitionBy]
+		if !ok {
+			return fmt.Errorf("unknown partition field %s", s.partitionBy)
+		}
+		s.partitionType = typ
+	}
+
+	// The partition field is encoded in the directory names only
+	s.names = s.names[:0]
+	s.columns = s.columns[:0]
+	for _, name := range schema.Fields {
+		if name == s.partitionBy {
+			continue
+		}
+		s.names = append(s.names, name)
+		s.columns = append(s.columns, parquet.Column{Name: name, Kind: parquetKind(schema.Types[name])})
+	}
+	return nil
+}
+
+func (s *parquetSink) WriteBatch(ctx context.Context, batch []Change) error {
+	for _, change := range batch {
+		if change.Type == ChangeDeleted {
+			continue
+		}
+		partition := ""
+		if s.partitionBy != "" {
+			partition = s.partitionBy + "=" + partitionValue(s.partitionType, change.Record[s.partitionBy])
+		}
+		row := make([]interface{}, len(s.names))
+		for i, name := range s.names {
+			value, err := parquetValue(s.schema.Types[name], change.Record[name])
+			if err != nil {
+				return fmt.Errorf("field %s of record %d: %w", name, change.ID, err)
+			}
+			row[i] = value
+		}
+		if err := s.write(partition, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit does nothing: row groups are flushed by the writers as they fill up
+// and the files are only valid once closed
+func (s *parquetSink) Commit(ctx context.Context) error {
+	return nil
+}
+
+func (s *parquetSink) write(partition string, row []interface{}) error {
+	pf, ok := s.writers[partition]
+	if !ok {
+		dir := filepath.Join(s.dir, partition)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		path := filepath.Join(dir, "part-0.parquet")
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		w, err := parquet.NewWriter(f, s.columns, s.codec, s.size)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		pf = &parquetFile{f: f, w: w}
+		s.writers[partition] = pf
+		s.files = append(s.files, path)
+	}
+	return pf.w.Write(row)
+}
+
+func (s *parquetSink) Close() error {
+	var firstErr error
+	for _, pf := range s.writers {
+		err := pf.w.Close()
+		if closeErr := pf.f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,52 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+const partnerMergeWizard = "base.partner.merge.automatic.wizard"
+
+// MergePartners merges duplicateIDs into the partner keepID using Odoo's
+// contact merge wizard. References to the duplicates (invoices, orders,
+// followers, ...) are moved to keepID and the duplicates are removed.
+//
+// The wizard only accepts contacts sharing the same email unless the user is
+// an administrator, and merges at most three contacts at once: keepID and two
+// duplicates. Larger groups have to be merged in several calls.
+func (c *Connector) MergePartners(ctx context.Context, keepID int64, duplicateIDs []int64) error {
+	if len(duplicateIDs) == 0 {
+		return fmt.Errorf("partner merge failed for id %d: no duplicates given", keepID)
+	}
+	if len(duplicateIDs) > 2 {
+		return fmt.Errorf("partner merge failed for id %d: at most 2 duplicates can be merged at once, got %d", keepID, len(duplicateIDs))
+	}
+
+	partnerIDs := []int64{keepID}
+	for _, id := range duplicateIDs {
+		if id == keepID {
+			return fmt.Errorf("partner merge failed for id %d: partner cannot be merged into itself", keepID)
+		}
+		partnerIDs = append(partnerIDs, id)
+	}
+
+	// The wizard reads the partners to merge from the active context, exactly
+	// like the "Merge" action of the contacts list view.
+	_, err := c.RunWizard(ctx, partnerMergeWizard, "action_merge", WizardOptions{
+		Context: map[string]interface{}{
+			"active_model": "res.partner",
+			"active_id":    keepID,
+			"active_ids":   partnerIDs,
+		},
+		Values: map[string]interface{}{
+			"state":          "selection",
+			"partner_ids":    Commands(CommandSet(partnerIDs)),
+			"dst_partner_id": keepID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("partner merge failed for id %d: %w", keepID, err)
+	}
+
+	return nil
+}
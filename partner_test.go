@@ -0,0 +1,71 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMergePartners(t *testing.T) {
+	var calls []string
+	var created, callContext map[string]interface{}
+	var merged interface{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		if args[3] != partnerMergeWizard {
+			t.Errorf("call on model %v", args[3])
+		}
+		calls = append(calls, args[4].(string))
+		switch args[4] {
+		case "fields_get":
+			return map[string]interface{}{"state": map[string]interface{}{"type": "selection"}, "group_by_email": map[string]interface{}{"type": "boolean"}}
+		case "default_get":
+			return map[string]interface{}{"state": "option", "group_by_email": true}
+		case "create":
+			created = args[5].([]interface{})[0].(map[string]interface{})
+			return 9
+		case "action_merge":
+			merged = args[5].([]interface{})[0]
+			callContext = args[6].(map[string]interface{})["context"].(map[string]interface{})
+			return false
+		}
+		return false
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := c.MergePartners(ctx, 3, []int64{4, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"fields_get", "default_get", "create", "action_merge"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %v, want %v", calls, want)
+	}
+	want := map[string]interface{}{
+		"state":          "selection",
+		"group_by_email": true,
+		"partner_ids":    []interface{}{[]interface{}{int64(6), int64(0), []interface{}{int64(3), int64(4), int64(5)}}},
+		"dst_partner_id": int64(3),
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("wizard values %v, want %v", created, want)
+	}
+	if !reflect.DeepEqual(merged, []interface{}{int64(9)}) || !reflect.DeepEqual(callContext["active_ids"], []interface{}{int64(3), int64(4), int64(5)}) {
+		t.Errorf("merge called on %v with context %v", merged, callContext)
+	}
+
+	// Invalid merges never reach the server
+	calls = nil
+	for _, duplicates := range [][]int64{nil, {4, 5, 6}, {3}} {
+		if err := c.MergePartners(ctx, 3, duplicates); err == nil {
+			t.Errorf("merging %v into 3 did not fail", duplicates)
+		}
+	}
+	if len(calls) != 0 {
+		t.Errorf("invalid merges made calls %v", calls)
+	}
+}
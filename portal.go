@@ -0,0 +1,138 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// GrantPortalAccess gives the partners access to the customer portal through
+// the portal.wizard, which creates the portal users and sends Odoo's
+// invitation emails. Partners that already have portal access are skipped.
+func (c *Connector) GrantPortalAccess(ctx context.Context, partnerIDs []int64) error {
+	if len(partnerIDs) == 0 {
+		return nil
+	}
+
+	perUser, err := c.SupportsContext(ctx, FeaturePortalGrantPerUser)
+	if err != nil {
+		return fmt.Errorf("portal access grant failed: %w", err)
+	}
+
+	wizard, err := c.CreateWizard(ctx, "portal.wizard", WizardOptions{
+		Context: map[string]interface{}{
+			"active_model": "res.partner",
+			"active_ids":   partnerIDs,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("portal access grant failed: %w", err)
+	}
+
+	var wizards []map[string]interface{}
+	err = c.execute(ctx, "portal.wizard", "read", []interface{}{[]int64{wizard.ID}}, map[string]interface{}{
+		"fields": []string{"user_ids"},
+	}, &wizards)
+	if err != nil {
+		return fmt.Errorf("portal access grant failed: %w", err)
+	}
+	if len(wizards) == 0 {
+		return fmt.Errorf("portal access grant failed: wizard %d not found", wizard.ID)
+	}
+	lineIDs := toInt64s(wizards[0]["user_ids"])
+
+	// Up to Odoo 14 the lines are ticked and applied at once; since Odoo 15
+	// every line has its own grant button.
+	if !perUser {
+		err := c.execute(ctx, "portal.wizard.user", "write", []interface{}{lineIDs, map[string]interface{}{
+			"in_portal": true,
+		}}, nil, new(bool))
+		if err != nil {
+			return fmt.Errorf("portal access grant failed: %w", err)
+		}
+		if _, err := wizard.Call(ctx, "action_apply"); err != nil {
+			return fmt.Errorf("portal access grant failed: %w", err)
+		}
+		return nil
+	}
+
+	var lines []map[string]interface{}
+	err = c.execute(ctx, "portal.wizard.user", "read", []interface{}{lineIDs}, map[string]interface{}{
+		"fields": []string{"partner_id", "is_portal"},
+	}, &lines)
+	if err != nil {
+		return fmt.Errorf("portal access grant failed: %w", err)
+	}
+	for _, line := range lines {
+		if isPortal, _ := line["is_portal"].(bool); isPortal {
+			continue
+		}
+		lineID, _ := toInt64(line["id"])
+		partnerID, _ := many2oneID(line["partner_id"])
+		var result interface{}
+		err := c.execute(ctx, "portal.wizard.user", "action_grant_access", []interface{}{[]int64{lineID}}, map[string]interface{}{
+			"context": wizard.Context,
+		}, &result)
+		if err != nil {
+			return fmt.Errorf("portal access grant failed for partner %d: %w", partnerID, err)
+		}
+	}
+	return nil
+}
+
+// NewUser describes an internal user created by CreateInternalUser
+type NewUser struct {
+	Name  string
+	Login string
+	Email string
+	// CompanyID sets the default company (optional).
+	CompanyID int64
+	// Groups lists external identifiers of the groups to assign, e.g.
+	// "sales_team.group_sale_salesman". base.group_user is always added.
+	Groups []string
+	// SendInvitation makes Odoo email the user a link to set a password.
+	SendInvitation bool
+}
+
+// CreateInternalUser creates an internal (employee) user with the given
+// groups, for on-boarding automation driven by HR or identity systems.
+func (c *Connector) CreateInternalUser(ctx context.Context, user NewUser) (int64, error) {
+	groupIDs := make([]int64, 0, len(user.Groups)+1)
+	for _, xmlid := range append([]string{"base.group_user"}, user.Groups...) {
+		id, err := c.xmlidToResID(ctx, "res.groups", xmlid)
+		if err != nil {
+			return 0, fmt.Errorf("user creation failed for login %s: %w", user.Login, err)
+		}
+		groupIDs = append(groupIDs, id)
+	}
+
+	split, err := c.SupportsContext(ctx, FeatureSplitUserGroups)
+	if err != nil {
+		return 0, fmt.Errorf("user creation failed for login %s: %w", user.Login, err)
+	}
+	groupsField := "groups_id"
+	if split {
+		groupsField = "group_ids"
+	}
+
+	values := map[string]interface{}{
+		"name":      user.Name,
+		"login":     user.Login,
+		groupsField: Commands(CommandSet(groupIDs)),
+	}
+	if user.Email != "" {
+		values["email"] = user.Email
+	}
+	if user.CompanyID != 0 {
+		values["company_id"] = user.CompanyID
+		values["company_ids"] = Commands(CommandLink(user.CompanyID))
+	}
+
+	var id int64
+	err = c.execute(ctx, "res.users", "create", []interface{}{values}, map[string]interface{}{
+		"context": map[string]interface{}{"no_reset_password": !user.SendInvitation},
+	}, &id)
+	if err != nil {
+		return 0, fmt.Errorf("user creation failed for login %s: %w", user.Login, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,119 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGrantPortalAccess(t *testing.T) {
+	for _, tc := range []struct {
+		version int
+		want    []string
+	}{
+		// Lines are ticked and applied at once
+		{14, []string{"fields_get", "default_get", "create", "read", "portal.wizard.user.write", "action_apply"}},
+		// Each line without portal access is granted on its own
+		{16, []string{"fields_get", "default_get", "create", "read", "portal.wizard.user.read", "portal.wizard.user.action_grant_access [21]"}},
+	} {
+		var calls []string
+		c := userServer(t, tc.version, func(model, method string, args []interface{}) interface{} {
+			call := method
+			if model == "portal.wizard.user" {
+				call = model + "." + method
+			}
+			if method == "action_grant_access" {
+				call += fmt.Sprint(" ", args[5].([]interface{})[0])
+			}
+			calls = append(calls, call)
+
+			switch call {
+			case "fields_get", "default_get":
+				return map[string]interface{}{}
+			case "create":
+				return 5
+			case "read":
+				return []map[string]interface{}{{"id": 5, "user_ids": []interface{}{20, 21}}}
+			case "portal.wizard.user.read":
+				return []map[string]interface{}{
+					{"id": 20, "partner_id": []interface{}{7, "Azure"}, "is_portal": true},
+					{"id": 21, "partner_id": []interface{}{8, "Gemini"}, "is_portal": false},
+				}
+			case "portal.wizard.user.write":
+				if want := []interface{}{[]interface{}{int64(20), int64(21)}, map[string]interface{}{"in_portal": true}}; !reflect.DeepEqual(args[5], want) {
+					t.Errorf("Odoo %d: lines written with %v", tc.version, args[5])
+				}
+				return true
+			}
+			return false
+		})
+
+		if err := c.GrantPortalAccess(context.Background(), []int64{7, 8}); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(calls, tc.want) {
+			t.Errorf("Odoo %d: calls %q, want %q", tc.version, calls, tc.want)
+		}
+	}
+}
+
+func TestCreateInternalUser(t *testing.T) {
+	for _, tc := range []struct {
+		version     int
+		groupsField string
+	}{
+		{17, "groups_id"},
+		{19, "group_ids"},
+	} {
+		var created, createContext interface{}
+		c := userServer(t, tc.version, func(model, method string, args []interface{}) interface{} {
+			switch model + "." + method {
+			case "ir.model.data.search_read":
+				domain := args[5].([]interface{})[0].([]interface{})
+				switch domain[1].([]interface{})[2] {
+				case "group_user":
+					return []map[string]interface{}{{"res_id": 1}}
+				case "group_sale_salesman":
+					return []map[string]interface{}{{"res_id": 12}}
+				}
+				return []map[string]interface{}{}
+			case "res.users.create":
+				created = args[5].([]interface{})[0]
+				createContext = args[6].(map[string]interface{})["context"]
+				return 30
+			}
+			return false
+		})
+		ctx := context.Background()
+
+		id, err := c.CreateInternalUser(ctx, NewUser{
+			Name:      "Jane",
+			Login:     "jane@example.com",
+			Email:     "jane@example.com",
+			CompanyID: 4,
+			Groups:    []string{"sales_team.group_sale_salesman"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{
+			"name":         "Jane",
+			"login":        "jane@example.com",
+			"email":        "jane@example.com",
+			"company_id":   int64(4),
+			"company_ids":  []interface{}{[]interface{}{int64(4), int64(4), int64(0)}},
+			tc.groupsField: []interface{}{[]interface{}{int64(6), int64(0), []interface{}{int64(1), int64(12)}}},
+		}
+		if id != 30 || !reflect.DeepEqual(created, want) {
+			t.Errorf("Odoo %d: created %d with %v, want %v", tc.version, id, created, want)
+		}
+		if !reflect.DeepEqual(createContext, map[string]interface{}{"no_reset_password": true}) {
+			t.Errorf("Odoo %d: context %v", tc.version, createContext)
+		}
+
+		if _, err := c.CreateInternalUser(ctx, NewUser{Login: "x", Groups: []string{"base.group_unknown"}}); err == nil {
+			t.Errorf("Odoo %d: unknown group did not fail", tc.version)
+		}
+	}
+}
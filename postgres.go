@@ -0,0 +1,99 @@
+package odoo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// PostgresSink replicates models into PostgreSQL tables, giving a read
+// replica of chosen models. Tables are created from the field metadata and
+// gain columns when fields are added. Deleted records are kept and marked
+// with a _deleted_at timestamp.
+//
+// The database is opened by the caller with the driver of their choice (e.g.
+// lib/pq or pgx's stdlib package). PostgresSink implements Sink, so Export
+// and SyncTo can write into it directly.
+type PostgresSink struct {
+	*sqlReplica
+}
+
+// NewPostgresSink returns a sink replicating models into the given schema of
+// db; an empty schema means public
+func (c *Connector) NewPostgresSink(db *sql.DB, schema string, models ...MirrorModel) *PostgresSink {
+	if schema == "" {
+		schema = "public"
+	}
+	return &PostgresSink{&sqlReplica{
+		c:          c,
+		db:         db,
+		dialect:    postgresDialect{schema: schema},
+		models:     models,
+		softDelete: true,
+	}}
+}
+
+// Refresh applies the changes since the previous refresh to every table
+func (s *PostgresSink) Refresh(ctx context.Context) error {
+	if err := s.refresh(ctx); err != nil {
+		return fmt.Errorf("postgres sink refresh failed: %w", err)
+	}
+	return nil
+}
+
+type postgresDialect struct {
+	schema string
+}
+
+func (postgresDialect) placeholder(n int) string { return "$" + strconv.Itoa(n) }
+
+func (postgresDialect) columnType(fieldType string) string {
+	switch fieldType {
+	case "integer", "many2one", "many2one_reference":
+		return "bigint"
+	case "float":
+		return "double precision"
+	case "monetary":
+		return "numeric"
+	case "boolean":
+		return "boolean"
+	case "date":
+		return "date"
+	case "datetime":
+		// Odoo stores datetimes in UTC without a time zone
+		return "timestamp"
+	case "deleted_at":
+		return "timestamptz"
+	case "one2many", "many2many", "json", "properties", "properties_definition":
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (d postgresDialect) table(name string) string {
+	return quoteIdent(d.schema) + "." + quoteIdent(name)
+}
+
+func (d postgresDialect) prepare(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE SCHEMA IF NOT EXISTS `+quoteIdent(d.schema))
+	return err
+}
+
+func (d postgresDialect) columns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT column_name FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2`, d.schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
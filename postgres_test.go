@@ -0,0 +1,52 @@
+package odoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPostgresSink(t *testing.T) {
+	m := &syncModel{}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	m.set(2, "Gemini", "2024-01-01 11:00:00", "2024-01-01 11:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := newFakeSQL()
+	ctx := context.Background()
+
+	sink := c.NewPostgresSink(db.open(), "replica", MirrorModel{Model: "res.partner", Fields: []string{"name"}})
+	if err := sink.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	wantStatements := []string{
+		`CREATE SCHEMA IF NOT EXISTS "replica"`,
+		`CREATE TABLE IF NOT EXISTS "replica"."_odoo_sync" (model TEXT PRIMARY KEY, state TEXT NOT NULL)`,
+		`CREATE TABLE "replica"."res_partner" ("id" bigint PRIMARY KEY, "create_date" timestamp, "name" text, "write_date" timestamp, _deleted_at timestamptz)`,
+		`INSERT INTO "replica"."res_partner" ("id", "create_date", "name", "write_date") VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO UPDATE SET "create_date" = excluded."create_date", "name" = excluded."name", "write_date" = excluded."write_date", _deleted_at = NULL`,
+		`INSERT INTO "replica"."_odoo_sync" (model, state) VALUES ($1, $2) ON CONFLICT (model) DO UPDATE SET state = excluded.state`,
+	}
+	for _, want := range wantStatements {
+		if got := db.executed(want); len(got) == 0 {
+			t.Errorf("missing statement %s in %q", want, db.statements)
+		}
+	}
+
+	// Deleted records are kept and marked; new fields become columns
+	m.remove(2)
+	sink = c.NewPostgresSink(db.open(), "replica", MirrorModel{Model: "res.partner", Fields: []string{"name", "display"}})
+	if err := sink.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := db.executed(`ALTER TABLE "replica"."res_partner" ADD COLUMN "display" text`); len(got) != 1 {
+		t.Errorf("display column added %d times", len(got))
+	}
+	if row := db.row("replica.res_partner", 2); row == nil || row["_deleted_at"] == nil {
+		t.Errorf("row 2 = %v, want it marked deleted", row)
+	}
+	if row := db.row("replica.res_partner", 1); row["_deleted_at"] != nil {
+		t.Errorf("row 1 = %v, want it live", row)
+	}
+}
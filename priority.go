@@ -0,0 +1,102 @@
+package odoo
+
+import (
+	"context"
+	"sync"
+)
+
+// Priority classifies calls competing for the connector's concurrency slots
+type Priority int
+
+const (
+	// PriorityInteractive is for latency-sensitive, user-facing calls. It is
+	// the default.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is for background work such as bulk imports and syncs,
+	// which only gets a slot while no interactive call is waiting.
+	PriorityBatch
+
+	numPriorities
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose calls are dispatched with priority p.
+// Priorities only take effect together with WithMaxConcurrency.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= 0 && p < numPriorities {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// WithMaxConcurrency limits the number of requests the connector has in
+// flight. Further calls wait for a free slot; waiting interactive calls are
+// always served before batch calls, first come first served within a class.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Connector) {
+		if n > 0 {
+			c.dispatcher = &dispatcher{limit: n}
+		}
+	}
+}
+
+// dispatcher hands out a fixed number of request slots by priority
+type dispatcher struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiting [numPriorities][]chan struct{}
+}
+
+// acquire blocks until a slot is available for a call of priority p or ctx
+// is done
+func (d *dispatcher) acquire(ctx context.Context, p Priority) error {
+	d.mu.Lock()
+	if d.active < d.limit {
+		d.active++
+		d.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	d.waiting[p] = append(d.waiting[p], ready)
+	d.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		for i, ch := range d.waiting[p] {
+			if ch == ready {
+				d.waiting[p] = append(d.waiting[p][:i], d.waiting[p][i+1:]...)
+				d.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		d.mu.Unlock()
+		// The slot was handed over while giving up; pass it on.
+		d.release()
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it directly to the most urgent waiter
+func (d *dispatcher) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for p := range d.waiting {
+		if len(d.waiting[p]) > 0 {
+			ready := d.waiting[p][0]
+			d.waiting[p] = d.waiting[p][1:]
+			close(ready)
+			return
+		}
+	}
+	d.active--
+}
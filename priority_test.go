@@ -0,0 +1,136 @@
+package odoo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// queue starts a call of priority p waiting on d and returns the channel that
+// is closed once it holds a slot. It returns after the call is queued.
+func queue(t *testing.T, ctx context.Context, d *dispatcher, p Priority) <-chan error {
+	t.Helper()
+	d.mu.Lock()
+	queued := len(d.waiting[p])
+	d.mu.Unlock()
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- d.acquire(ctx, p) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		d.mu.Lock()
+		n := len(d.waiting[p])
+		d.mu.Unlock()
+		if n > queued {
+			return acquired
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("call was not queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// next releases the held slot and returns which of the calls got it
+func next(t *testing.T, d *dispatcher, calls map[string]<-chan error) string {
+	t.Helper()
+	d.release()
+	deadline := time.After(5 * time.Second)
+	for {
+		for name, acquired := range calls {
+			select {
+			case err := <-acquired:
+				if err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+				delete(calls, name)
+				return name
+			default:
+			}
+		}
+		select {
+		case <-deadline:
+			t.Fatal("no call got the slot")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestDispatcherOrder(t *testing.T) {
+	ctx := context.Background()
+	d := &dispatcher{limit: 1}
+	if err := d.acquire(ctx, PriorityBatch); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]<-chan error{}
+	calls["batch 1"] = queue(t, ctx, d, PriorityBatch)
+	calls["interactive 1"] = queue(t, ctx, d, PriorityInteractive)
+	calls["batch 2"] = queue(t, ctx, d, PriorityBatch)
+	calls["interactive 2"] = queue(t, ctx, d, PriorityInteractive)
+
+	// Interactive calls come first, each class first come first served
+	want := []string{"interactive 1", "interactive 2", "batch 1", "batch 2"}
+	for i, name := range want {
+		if i == 2 {
+			// An interactive call arriving later still overtakes batch calls
+			calls["interactive 3"] = queue(t, ctx, d, PriorityInteractive)
+			if got := next(t, d, calls); got != "interactive 3" {
+				t.Fatalf("got %s, want interactive 3", got)
+			}
+		}
+		if got := next(t, d, calls); got != name {
+			t.Fatalf("slot %d went to %s, want %s", i+1, got, name)
+		}
+	}
+
+	d.release()
+	if d.active != 0 {
+		t.Errorf("%d slots still active", d.active)
+	}
+}
+
+func TestDispatcherCancel(t *testing.T) {
+	d := &dispatcher{limit: 1}
+	if err := d.acquire(context.Background(), PriorityInteractive); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := queue(t, ctx, d, PriorityInteractive)
+	batch := queue(t, context.Background(), d, PriorityBatch)
+
+	cancel()
+	if err := <-cancelled; err != context.Canceled {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	d.mu.Lock()
+	waiting := len(d.waiting[PriorityInteractive])
+	d.mu.Unlock()
+	if waiting != 0 {
+		t.Errorf("%d cancelled calls still waiting", waiting)
+	}
+
+	// Without interactive calls waiting the batch call gets the slot
+	if got := next(t, d, map[string]<-chan error{"batch": batch}); got != "batch" {
+		t.Errorf("got %s", got)
+	}
+	d.release()
+	if d.active != 0 {
+		t.Errorf("%d slots still active", d.active)
+	}
+}
+
+func TestPriorityFrom(t *testing.T) {
+	ctx := context.Background()
+	if p := priorityFrom(ctx); p != PriorityInteractive {
+		t.Errorf("default: got %v", p)
+	}
+	if p := priorityFrom(WithPriority(ctx, PriorityBatch)); p != PriorityBatch {
+		t.Errorf("batch: got %v", p)
+	}
+	if p := priorityFrom(WithPriority(ctx, Priority(42))); p != PriorityInteractive {
+		t.Errorf("invalid: got %v", p)
+	}
+}
@@ -0,0 +1,108 @@
+package odoo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// Protocol is the RPC protocol of Odoo's external API
+type Protocol string
+
+const (
+	// XMLRPC calls the /xmlrpc/2 endpoints (the default)
+	XMLRPC Protocol = "xmlrpc"
+	// JSONRPC calls the /jsonrpc endpoint, which is faster to decode and the
+	// only one available on some hosted instances
+	JSONRPC Protocol = "jsonrpc"
+)
+
+// WithProtocol selects the protocol of the external API. Every method works
+// the same over either protocol and returns values of the same types.
+func WithProtocol(p Protocol) Option {
+	return func(c *Connector) {
+		c.protocol = p
+	}
+}
+
+// codec encodes calls and decodes responses of a protocol
+type codec interface {
+	// endpoint returns the URL of a service of the server at baseURL
+	endpoint(baseURL, service string) string
+	contentType() string
+	// encodeCall writes the request calling method on service to buf
+	encodeCall(buf encodeBuffer, service, method string, args []interface{}) error
+	// parse checks a complete response and returns its result; faults are
+	// reported as *Error
+	parse(data []byte) (response, error)
+	// decodeArray calls fn for every element of a response whose result is
+	// an array, while the response is read
+	decodeArray(r io.Reader, fn func(interface{}) error) error
+	// decodeStructArray decodes every element of a response whose result is
+	// an array of structs into record and calls fn after each, while the
+	// response is read
+	decodeStructArray(r io.Reader, record *structRecord, fn func() error) error
+	// decodeBinaryMember writes the decoded base64 value of field in a read
+	// response to w
+	decodeBinaryMember(r io.Reader, field string, w io.Writer) (int64, error)
+}
+
+// response is the result of a call, decoded into a Go value on demand.
+// Numbers decode as int64 or float64 for interface{} targets.
+type response interface {
+	Unmarshal(v interface{}) error
+}
+
+func (c *Connector) codec() codec {
+	if c.protocol == JSONRPC {
+		return jsonCodec{}
+	}
+	return xmlCodec{}
+}
+
+func validateProtocol(p Protocol) error {
+	switch p {
+	case "", XMLRPC, JSONRPC:
+		return nil
+	}
+	return fmt.Errorf("unknown protocol %q", p)
+}
+
+// xmlCodec speaks XML-RPC
+type xmlCodec struct{}
+
+func (xmlCodec) endpoint(baseURL, service string) string {
+	return fmt.Sprintf("%s/xmlrpc/2/%s", baseURL, service)
+}
+
+func (xmlCodec) contentType() string { return "text/xml" }
+
+func (xmlCodec) encodeCall(buf encodeBuffer, service, method string, args []interface{}) error {
+	return encodeMethodCall(buf, method, args...)
+}
+
+func (xmlCodec) parse(data []byte) (response, error) {
+	resp := xmlrpc.Response(data)
+	if err := resp.Err(); err != nil {
+		var fault xmlrpc.FaultError
+		if errors.As(err, &fault) {
+			return nil, newFault(fault.Code, fault.String)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (xmlCodec) decodeArray(r io.Reader, fn func(interface{}) error) error {
+	return decodeArrayStream(r, fn)
+}
+
+func (xmlCodec) decodeStructArray(r io.Reader, record *structRecord, fn func() error) error {
+	return decodeStructArrayStream(r, record, fn)
+}
+
+func (xmlCodec) decodeBinaryMember(r io.Reader, field string, w io.Writer) (int64, error) {
+	return decodeBinaryMember(r, field, w)
+}
@@ -0,0 +1,257 @@
+package odoo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// sqlDialect abstracts the SQL differences between replication targets
+type sqlDialect interface {
+	// placeholder returns the parameter placeholder at position n (1-based)
+	placeholder(n int) string
+	// columnType returns the column type of an Odoo field type
+	columnType(fieldType string) string
+	// table returns the quoted, possibly qualified name of a table
+	table(name string) string
+	// columns returns the columns of an existing table, or none
+	columns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error)
+	// prepare creates anything the tables live in, e.g. a schema
+	prepare(ctx context.Context, db *sql.DB) error
+}
+
+// sqlReplica is a Sink replicating models into SQL tables named after them:
+// res.partner becomes res_partner. Each batch is written in a transaction.
+// Its refresh method keeps the sync state of each model in the _odoo_sync
+// table.
+type sqlReplica struct {
+	c       *Connector
+	db      *sql.DB
+	dialect sqlDialect
+	models  []MirrorModel
+
+	// softDelete marks deleted records in a _deleted_at column instead of
+	// removing the rows
+	softDelete bool
+
+	schema SinkSchema
+	tx     *sql.Tx
+	upsert *sql.Stmt
+}
+
+func (r *sqlReplica) refresh(ctx context.Context) error {
+	if err := r.dialect.prepare(ctx, r.db); err != nil {
+		return err
+	}
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (model TEXT PRIMARY KEY, state TEXT NOT NULL)`, r.dialect.table("_odoo_sync")))
+	if err != nil {
+		return err
+	}
+
+	for _, model := range r.models {
+		state, err := r.loadState(ctx, model.Model)
+		if err != nil {
+			return fmt.Errorf("model %s: %w", model.Model, err)
+		}
+		state, err = r.c.SyncTo(ctx, model.Model, state, SyncOptions{
+			Fields:       model.Fields,
+			Domain:       model.Domain,
+			TrackDeletes: true,
+		}, r)
+		// Keep the progress of the committed batches after a failure
+		if saveErr := r.saveState(ctx, model.Model, state); err == nil {
+			err = saveErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open creates the table of the model, or adds columns for fields that
+// appeared since it was created
+func (r *sqlReplica) Open(ctx context.Context, schema SinkSchema) error {
+	r.schema = schema
+	table := tableName(schema.Model)
+	existing, err := r.dialect.columns(ctx, r.db, table)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		columns := make([]string, 0, len(schema.Fields)+1)
+		for _, name := range schema.Fields {
+			column := quoteIdent(name) + " " + r.dialect.columnType(schema.Types[name])
+			if name == "id" {
+				column += " PRIMARY KEY"
+			}
+			columns = append(columns, column)
+		}
+		if r.softDelete {
+			columns = append(columns, "_deleted_at "+r.dialect.columnType("deleted_at"))
+		}
+		_, err := r.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE %s (%s)`, r.dialect.table(table), strings.Join(columns, ", ")))
+		return err
+	}
+
+	for _, name := range schema.Fields {
+		if existing[name] {
+			continue
+		}
+		_, err := r.db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, r.dialect.table(table), quoteIdent(name), r.dialect.columnType(schema.Types[name])))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBatch upserts created and updated records and removes (or marks)
+// deleted ones
+func (r *sqlReplica) WriteBatch(ctx context.Context, batch []Change) error {
+	table := r.dialect.table(tableName(r.schema.Model))
+	if r.tx == nil {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		upsert, err := tx.PrepareContext(ctx, r.upsertStatement(table, r.schema.Fields))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		r.tx, r.upsert = tx, upsert
+	}
+
+	deleteStatement := fmt.Sprintf(`DELETE FROM %s WHERE id = %s`, table, r.dialect.placeholder(1))
+	if r.softDelete {
+		deleteStatement = fmt.Sprintf(`UPDATE %s SET _deleted_at = CURRENT_TIMESTAMP WHERE id = %s`, table, r.dialect.placeholder(1))
+	}
+
+	values := make([]interface{}, len(r.schema.Fields))
+	for _, change := range batch {
+		if change.Type == ChangeDeleted {
+			if _, err := r.tx.ExecContext(ctx, deleteStatement, change.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		for i, name := range r.schema.Fields {
+			value, err := sqlValue(r.schema.Types[name], change.Record[name])
+			if err != nil {
+				return fmt.Errorf("field %s of record %d: %w", name, change.ID, err)
+			}
+			values[i] = value
+		}
+		if _, err := r.upsert.ExecContext(ctx, values...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit commits the transaction of the written batches
+func (r *sqlReplica) Commit(ctx context.Context) error {
+	if r.tx == nil {
+		return nil
+	}
+	r.upsert.Close()
+	err := r.tx.Commit()
+	r.tx, r.upsert = nil, nil
+	return err
+}
+
+// Close rolls back uncommitted batches; the database stays open
+func (r *sqlReplica) Close() error {
+	if r.tx == nil {
+		return nil
+	}
+	r.upsert.Close()
+	err := r.tx.Rollback()
+	r.tx, r.upsert = nil, nil
+	return err
+}
+
+func (r *sqlReplica) loadState(ctx context.Context, model string) (SyncState, error) {
+	var state SyncState
+	var encoded string
+	query := fmt.Sprintf(`SELECT state FROM %s WHERE model = %s`, r.dialect.table("_odoo_sync"), r.dialect.placeholder(1))
+	err := r.db.QueryRowContext(ctx, query, model).Scan(&encoded)
+	if err == sql.ErrNoRows {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	return state, json.Unmarshal([]byte(encoded), &state)
+}
+
+func (r *sqlReplica) saveState(ctx context.Context, model string, state SyncState) error {
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (model, state) VALUES (%s, %s) ON CONFLICT (model) DO UPDATE SET state = excluded.state`,
+		r.dialect.table("_odoo_sync"), r.dialect.placeholder(1), r.dialect.placeholder(2)), model, string(encoded))
+	return err
+}
+
+func (r *sqlReplica) upsertStatement(table string, names []string) string {
+	columns := make([]string, len(names))
+	placeholders := make([]string, len(names))
+	var updates []string
+	for i, name := range names {
+		columns[i] = quoteIdent(name)
+		placeholders[i] = r.dialect.placeholder(i + 1)
+		if name != "id" {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", quoteIdent(name), quoteIdent(name)))
+		}
+	}
+	if r.softDelete {
+		// A record that reappears, e.g. back inside the domain, is live again
+		updates = append(updates, "_deleted_at = NULL")
+	}
+	return fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (id) DO UPDATE SET %s`,
+		table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "))
+}
+
+// sqlValue converts a value read from Odoo into a database/sql argument:
+// false becomes NULL except for booleans, many2one fields their ID and
+// x2many or other structured values JSON text
+func sqlValue(fieldType string, value interface{}) (interface{}, error) {
+	if fieldType == "boolean" {
+		b, _ := value.(bool)
+		return b, nil
+	}
+	if value == nil || value == false {
+		return nil, nil
+	}
+	switch fieldType {
+	case "many2one":
+		if id, ok := many2oneID(value); ok {
+			return id, nil
+		}
+		return nil, fmt.Errorf("unexpected many2one value %v", value)
+	}
+	switch value.(type) {
+	case string, int64, float64:
+		return value, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// tableName returns the SQL table name of a model
+func tableName(model string) string {
+	return strings.ReplaceAll(model, ".", "_")
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,253 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how failed calls are retried. Only transient
+// failures are retried: rate limiting (429), unavailable servers (503),
+// database serialization conflicts, and, for read-only methods, network and
+// gateway (502/504) errors, where a write might already have been applied.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// Delay is the constant pause between two attempts, used when Backoff is
+	// not set.
+	Delay time.Duration
+	// Backoff computes the pause between two attempts.
+	Backoff Backoff
+	// ClassBackoff overrides Backoff for specific error classes, e.g. a slow
+	// exponential backoff for rate limiting but quick jittered retries for
+	// serialization conflicts.
+	ClassBackoff map[ErrorClass]Backoff
+}
+
+// backoff returns the backoff strategy for failures of the given class
+func (p RetryPolicy) backoff(class ErrorClass) Backoff {
+	if b, ok := p.ClassBackoff[class]; ok {
+		return b
+	}
+	if p.Backoff != nil {
+		return p.Backoff
+	}
+	return ConstantBackoff{Delay: p.Delay}
+}
+
+// WithRetry enables retries of transient failures
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Connector) {
+		c.retry = policy
+	}
+}
+
+// WithRetryBudget caps the number of retries the whole connector may perform
+// per minute. Once the budget is spent, failures are returned immediately
+// instead of being retried, so a struggling server is not hit with a multiple
+// of the normal load. The budget refills continuously.
+func WithRetryBudget(perMinute int) Option {
+	return func(c *Connector) {
+		c.retryBudget = newRetryBudget(perMinute)
+	}
+}
+
+// RetryBudgetStats reports the consumption of the retry budget
+type RetryBudgetStats struct {
+	// PerMinute is the configured budget, zero when no budget is set.
+	PerMinute int
+	// Available is the number of retries that may currently be performed.
+	Available float64
+	// Granted and Denied count the retries allowed and refused so far.
+	Granted uint64
+	Denied  uint64
+}
+
+// RetryBudgetStats returns the current state of the retry budget, e.g. to
+// export it as metrics
+func (c *Connector) RetryBudgetStats() RetryBudgetStats {
+	if c.retryBudget == nil {
+		return RetryBudgetStats{}
+	}
+	return c.retryBudget.stats()
+}
+
+type retryBudget struct {
+	mu        sync.Mutex
+	perMinute float64
+	tokens    float64
+	last      time.Time
+	granted   uint64
+	denied    uint64
+	now       func() time.Time
+}
+
+func newRetryBudget(perMinute int) *retryBudget {
+	return &retryBudget{
+		perMinute: float64(perMinute),
+		tokens:    float64(perMinute),
+		last:      time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (b *retryBudget) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Minutes() * b.perMinute
+	if b.tokens > b.perMinute {
+		b.tokens = b.perMinute
+	}
+	b.last = now
+}
+
+func (b *retryBudget) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(b.now())
+	if b.tokens < 1 {
+		b.denied++
+		return false
+	}
+	b.tokens--
+	b.granted++
+	return true
+}
+
+func (b *retryBudget) stats() RetryBudgetStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(b.now())
+	return RetryBudgetStats{
+		PerMinute: int(b.perMinute),
+		Available: b.tokens,
+		Granted:   b.granted,
+		Denied:    b.denied,
+	}
+}
+
+// ErrorClass groups failures that call for the same retry behaviour
+type ErrorClass int
+
+const (
+	// ErrorClassPermanent covers failures that are never retried, such as
+	// access or validation errors.
+	ErrorClassPermanent ErrorClass = iota
+	// ErrorClassRateLimited is an HTTP 429 answer.
+	ErrorClassRateLimited
+	// ErrorClassUnavailable is an HTTP 503 answer, e.g. during maintenance.
+	ErrorClassUnavailable
+	// ErrorClassGateway is an HTTP 502 or 504 answer from a reverse proxy.
+	ErrorClassGateway
+	// ErrorClassSerialization is a database serialization conflict caused by
+	// concurrent updates of the same rows.
+	ErrorClassSerialization
+	// ErrorClassNetwork is a connection or timeout error.
+	ErrorClassNetwork
+	// ErrorClassAuthentication is a rejected API key or login, e.g. after
+	// the key was revoked or expired. It is never retried, but triggers
+	// re-authentication when enabled with WithReauthentication.
+	ErrorClassAuthentication
+)
+
+// ClassifyError returns the retry class of an error returned by the connector
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassPermanent
+	}
+
+	var fault *Error
+	if errors.As(err, &fault) {
+		switch {
+		case IsAccessDenied(err):
+			return ErrorClassAuthentication
+		case strings.Contains(fault.Message, "could not serialize access"):
+			return ErrorClassSerialization
+		}
+		return ErrorClassPermanent
+	}
+
+	var serverErr rpc.ServerError
+	if errors.As(err, &serverErr) {
+		msg := string(serverErr)
+		switch {
+		case strings.HasSuffix(msg, "bad status code - 429"):
+			return ErrorClassRateLimited
+		case strings.HasSuffix(msg, "bad status code - 503"):
+			return ErrorClassUnavailable
+		case strings.HasSuffix(msg, "bad status code - 502"), strings.HasSuffix(msg, "bad status code - 504"):
+			return ErrorClassGateway
+		case strings.HasSuffix(msg, "bad status code - 401"):
+			return ErrorClassAuthentication
+		}
+		return ErrorClassPermanent
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrorClassNetwork
+	}
+	return ErrorClassPermanent
+}
+
+// readOnlyMethods never change data and can be retried after any transient
+// failure
+var readOnlyMethods = map[string]bool{
+	"search":              true,
+	"search_read":         true,
+	"search_count":        true,
+	"read":                true,
+	"read_group":          true,
+	"fields_get":          true,
+	"name_get":            true,
+	"name_search":         true,
+	"default_get":         true,
+	"check_access_rights": true,
+}
+
+func (c *Connector) shouldRetry(ctx context.Context, model, method string, err error, attempt int) bool {
+	if attempt >= c.retry.MaxAttempts {
+		return false
+	}
+
+	switch ClassifyError(err) {
+	case ErrorClassPermanent, ErrorClassAuthentication:
+		return false
+	case ErrorClassGateway, ErrorClassNetwork:
+		if !readOnlyMethods[method] {
+			return false
+		}
+	}
+
+	if c.retryBudget != nil && !c.retryBudget.allow() {
+		c.log().LogAttrs(ctx, slog.LevelWarn, "odoo retry budget exhausted",
+			slog.String("model", model),
+			slog.String("method", method),
+		)
+		return false
+	}
+	return true
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget(t *testing.T) {
+	clock := newFakeClock()
+	b := newRetryBudget(60)
+	b.now, b.last = clock.now, clock.now()
+
+	for i := 0; i < 60; i++ {
+		if !b.allow() {
+			t.Fatalf("retry %d denied within the budget", i+1)
+		}
+	}
+	if b.allow() {
+		t.Fatal("retry allowed beyond the budget")
+	}
+
+	// The budget refills continuously, one retry per second here
+	clock.advance(500 * time.Millisecond)
+	if b.allow() {
+		t.Error("retry allowed before a token was refilled")
+	}
+	clock.advance(500 * time.Millisecond)
+	if !b.allow() {
+		t.Error("retry denied after a token was refilled")
+	}
+
+	// Refilling stops at the budget
+	clock.advance(time.Hour)
+	stats := b.stats()
+	want := RetryBudgetStats{PerMinute: 60, Available: 60, Granted: 61, Denied: 2}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	failures := map[string]httpStatus{}
+	calls := map[string]int{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		method = args[4].(string)
+		calls[method]++
+		if status, ok := failures[method]; ok && calls[method] < 3 {
+			return status
+		}
+		if method == "create" {
+			return 5
+		}
+		return []interface{}{}
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithRetry(RetryPolicy{MaxAttempts: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		method  string
+		status  httpStatus
+		calls   int
+		success bool
+	}{
+		{"unavailable read", "search_read", 503, 3, true},
+		{"unavailable write", "create", 503, 3, true},
+		{"gateway read", "search_read", 502, 3, true},
+		// The write may already have been applied behind the gateway
+		{"gateway write", "create", 502, 1, false},
+		{"permanent", "search_read", 403, 1, false},
+	} {
+		failures = map[string]httpStatus{tc.method: tc.status}
+		calls = map[string]int{}
+		if tc.method == "create" {
+			_, err = c.CreateRecord("res.partner", map[string]interface{}{"name": "Azure"})
+		} else {
+			_, err = c.SearchReadRecords("res.partner", SearchReadOptions{})
+		}
+		if (err == nil) != tc.success {
+			t.Errorf("%s: got error %v", tc.name, err)
+		}
+		if calls[tc.method] != tc.calls {
+			t.Errorf("%s: %d calls, want %d", tc.name, calls[tc.method], tc.calls)
+		}
+	}
+}
+
+func TestRetryBudgetExhausted(t *testing.T) {
+	calls := 0
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls++
+		return httpStatus(503)
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db",
+		WithRetry(RetryPolicy{MaxAttempts: 5}),
+		WithRetryBudget(2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first call spends the budget, the second one is not retried
+	for _, want := range []int{3, 1} {
+		calls = 0
+		if _, err := c.SearchReadRecords("res.partner", SearchReadOptions{}); err == nil {
+			t.Fatal("call did not fail")
+		}
+		if calls != want {
+			t.Errorf("%d calls, want %d", calls, want)
+		}
+	}
+	if stats := c.RetryBudgetStats(); stats.Granted != 2 || stats.Denied != 2 {
+		t.Errorf("stats = %+v, want 2 granted and 2 denied", stats)
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	calls := 0
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		calls++
+		return httpStatus(503)
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db",
+		WithRetry(RetryPolicy{MaxAttempts: 5, Delay: time.Hour}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.SearchReadRecordsContext(ctx, "res.partner", SearchReadOptions{})
+	if ClassifyError(err) != ErrorClassUnavailable || calls != 1 {
+		t.Errorf("got %v after %d calls, want the first failure", err, calls)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want ErrorClass
+	}{
+		{nil, ErrorClassPermanent},
+		{context.Canceled, ErrorClassPermanent},
+		{rpc.ServerError("request error: bad status code - 429"), ErrorClassRateLimited},
+		{rpc.ServerError("request error: bad status code - 503"), ErrorClassUnavailable},
+		{rpc.ServerError("request error: bad status code - 502"), ErrorClassGateway},
+		{rpc.ServerError("request error: bad status code - 504"), ErrorClassGateway},
+		{rpc.ServerError("request error: bad status code - 401"), ErrorClassAuthentication},
+		{rpc.ServerError("request error: bad status code - 500"), ErrorClassPermanent},
+		{fmt.Errorf("search_read failed: %w", newFault(1, "psycopg2.errors.SerializationFailure: could not serialize access due to concurrent update")), ErrorClassSerialization},
+		{newFault(3, "Access Denied"), ErrorClassAuthentication},
+		{newFault(4, "You are not allowed to access this document"), ErrorClassPermanent},
+		{io.ErrUnexpectedEOF, ErrorClassNetwork},
+		{errors.New("other"), ErrorClassPermanent},
+	} {
+		if got := ClassifyError(tc.err); got != tc.want {
+			t.Errorf("ClassifyError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Modeler is implemented by structs mapped to an Odoo model with `odoo` tags,
+// naming the model they map to
+type Modeler interface {
+	OdooModel() string
+}
+
+// SchemaIssue is a difference between a struct and the live model
+type SchemaIssue struct {
+	Model   string
+	Struct  string
+	GoField string
+	Field   string
+	// Message describes the difference
+	Message string
+	// Suggestion is a field of the model the struct field may have been
+	// renamed to, if one looks likely
+	Suggestion string
+}
+
+func (i SchemaIssue) String() string {
+	s := fmt.Sprintf("%s.%s (%s.%s): %s", i.Struct, i.GoField, i.Model, i.Field, i.Message)
+	if i.Suggestion != "" {
+		s += fmt.Sprintf(", renamed to %s?", i.Suggestion)
+	}
+	return s
+}
+
+// SchemaDriftError lists the differences found by VerifySchema
+type SchemaDriftError struct {
+	Issues []SchemaIssue
+}
+
+func (e *SchemaDriftError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return "schema drift detected:\n  " + strings.Join(lines, "\n  ")
+}
+
+// VerifySchema compares structs mapped with `odoo` tags against the live
+// fields_get of their models and reports fields that no longer exist and Go
+// types that no longer fit the field type, as a *SchemaDriftError. Run it in
+// CI or at service startup to catch breakage after Odoo upgrades:
+//
+//	if err := c.VerifySchema(ctx, Lead{}, Partner{}); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The structs (or pointers or slices of them) must implement Modeler.
+func (c *Connector) VerifySchema(ctx context.Context, models ...interface{}) error {
+	var issues []SchemaIssue
+	fieldsByModel := make(map[string]map[string]map[string]interface{})
+
+	for _, v := range models {
+		t := reflect.TypeOf(v)
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return fmt.Errorf("schema verification failed: %T is not a struct", v)
+		}
+		modeler, ok := reflect.New(t).Interface().(Modeler)
+		if !ok {
+			return fmt.Errorf("schema verification failed: %s does not implement Modeler", t)
+		}
+		model := modeler.OdooModel()
+
+		fields, ok := fieldsByModel[model]
+		if !ok {
+			err := c.execute(ctx, model, "fields_get", []interface{}{}, map[string]interface{}{
+				"attributes": []string{"type"},
+			}, &fields)
+			if err != nil {
+				return fmt.Errorf("schema verification failed for model %s: %w", model, err)
+			}
+			fieldsByModel[model] = fields
+		}
+
+		issues = append(issues, structIssues(t, model, fields)...)
+	}
+
+	if len(issues) > 0 {
+		return &SchemaDriftError{Issues: issues}
+	}
+	return nil
+}
+
+// FieldsGet returns the field definitions of model keyed by field name,
+// limited to the given attributes (e.g. "type", "relation") when any are given
+func (c *Connector) FieldsGet(ctx context.Context, model string, attributes ...string) (map[string]map[string]interface{}, error) {
+	kwargs := map[string]interface{}{}
+	if len(attributes) > 0 {
+		kwargs["attributes"] = attributes
+	}
+	var fields map[string]map[string]interface{}
+	if err := c.execute(ctx, model, "fields_get", []interface{}{}, kwargs, &fields); err != nil {
+		return nil, fmt.Errorf("fields_get failed for model %s: %w", model, err)
+	}
+	return fields, nil
+}
+
+// structIssues compares the mapped fields of struct type t with fields
+func structIssues(t reflect.Type, model string, fields map[string]map[string]interface{}) []SchemaIssue {
+	mapped := structFieldsOf(t)
+	used := make(map[string]bool, len(mapped))
+	for _, f := range mapped {
+		used[f.name] = true
+	}
+
+	var issues []SchemaIssue
+	for _, f := range mapped {
+		goField := t.FieldByIndex(f.index)
+		issue := SchemaIssue{Model: model, Struct: t.Name(), GoField: goField.Name, Field: f.name}
+
+		def, ok := fields[f.name]
+		if !ok {
+			issue.Message = "field does not exist"
+			issue.Suggestion = renameCandidate(f.name, goField.Type, fields, used)
+			issues = append(issues, issue)
+			continue
+		}
+		fieldType, _ := def["type"].(string)
+		if !goTypeFits(goField.Type, fieldType) {
+			issue.Message = fmt.Sprintf("Go type %s does not fit %s field", goField.Type, fieldType)
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// goTypeFits reports whether values of an Odoo field type can be held by a Go
+// type. Types it does not know, such as interfaces, fit any field.
+func goTypeFits(t reflect.Type, fieldType string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return fieldType == "date" || fieldType == "datetime"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fieldType == "integer" || fieldType == "many2one" || fieldType == "many2one_reference"
+	case reflect.Float32, reflect.Float64:
+		return fieldType == "float" || fieldType == "monetary"
+	case reflect.Bool:
+		return fieldType == "boolean"
+	case reflect.String:
+		switch fieldType {
+		case "char", "text", "html", "selection", "date", "datetime", "reference", "binary":
+			return true
+		}
+		return false
+	case reflect.Slice, reflect.Array:
+		switch t.Elem().Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			return fieldType == "one2many" || fieldType == "many2many"
+		case reflect.Uint8:
+			return fieldType == "binary"
+		}
+	}
+	return true
+}
+
+// renameCandidate returns an unmapped field that fits goType and whose name
+// is close to name, e.g. groups_id and group_ids
+func renameCandidate(name string, goType reflect.Type, fields map[string]map[string]interface{}, used map[string]bool) string {
+	best, bestDistance := "", 3
+	candidates := make([]string, 0, len(fields))
+	for candidate := range fields {
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+
+	for _, candidate := range candidates {
+		if used[candidate] {
+			continue
+		}
+		fieldType, _ := fields[candidate]["type"].(string)
+		if !goTypeFits(goType, fieldType) {
+			continue
+		}
+		distance := editDistance(name, candidate)
+		if fieldStem(candidate) == fieldStem(name) {
+			distance = 0
+		}
+		if distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// fieldStem strips the relational suffixes and plurals from a field name
+func fieldStem(name string) string {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "_ids"), "_id")
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		parts[i] = strings.TrimSuffix(part, "s")
+	}
+	return strings.Join(parts, "_")
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
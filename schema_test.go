@@ -0,0 +1,36 @@
+package odoo
+
+import (
+	"reflect"
+	"testing"
+)
+
+type schemaUser struct {
+	ID       int64   `odoo:"id"`
+	Name     string  `odoo:"name"`
+	Active   string  `odoo:"active"`
+	GroupIDs []int64 `odoo:"groups_id"`
+	Gone     string  `odoo:"x_gone"`
+}
+
+func (schemaUser) OdooModel() string { return "res.users" }
+
+func TestStructIssues(t *testing.T) {
+	fields := map[string]map[string]interface{}{
+		"id":        {"type": "integer"},
+		"name":      {"type": "char"},
+		"active":    {"type": "boolean"},
+		"group_ids": {"type": "many2many"},
+		"login":     {"type": "char"},
+	}
+	issues := structIssues(reflect.TypeOf(schemaUser{}), "res.users", fields)
+
+	want := []SchemaIssue{
+		{Model: "res.users", Struct: "schemaUser", GoField: "Active", Field: "active", Message: "Go type string does not fit boolean field"},
+		{Model: "res.users", Struct: "schemaUser", GoField: "GroupIDs", Field: "groups_id", Message: "field does not exist", Suggestion: "group_ids"},
+		{Model: "res.users", Struct: "schemaUser", GoField: "Gone", Field: "x_gone", Message: "field does not exist"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("got %+v\nwant %+v", issues, want)
+	}
+}
@@ -0,0 +1,184 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+)
+
+// SessionInfo is the session description the web client bootstraps from
+// (/web/session/get_session_info)
+type SessionInfo struct {
+	UID               int64
+	DB                string
+	Username          string
+	Name              string
+	PartnerID         int64
+	CompanyID         int64
+	IsAdmin           bool
+	IsSystem          bool
+	ServerVersion     string
+	ServerVersionInfo []interface{}
+	UserContext       map[string]interface{}
+	WebBaseURL        string
+	Raw               map[string]interface{}
+}
+
+// webSession is an authenticated web client session
+type webSession struct {
+	client *http.Client
+}
+
+// errSessionExpired is reported by the server when the session cookie is no
+// longer valid
+var errSessionExpired = errors.New("session expired")
+
+// SessionInfo returns the web session information of the integration user.
+// It requires WithSessionAuth; the session is established on first use and
+// re-established once if the server reports it expired.
+func (c *Connector) SessionInfo(ctx context.Context) (*SessionInfo, error) {
+	var raw map[string]interface{}
+	err := c.sessionCall(ctx, "/web/session/get_session_info", map[string]interface{}{}, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("session info failed: %w", err)
+	}
+	return parseSessionInfo(raw), nil
+}
+
+// sessionCall posts a JSON-RPC request to a web controller using the web
+// session, logging in first if needed
+func (c *Connector) sessionCall(ctx context.Context, path string, params interface{}, result interface{}) error {
+	if c.sessionPassword == "" {
+		return fmt.Errorf("session authentication is not enabled")
+	}
+
+	session, err := c.webSession(ctx, false)
+	if err != nil {
+		return err
+	}
+	err = session.call(ctx, c.URL+path, params, result)
+	if errors.Is(err, errSessionExpired) {
+		if session, err = c.webSession(ctx, true); err != nil {
+			return err
+		}
+		err = session.call(ctx, c.URL+path, params, result)
+	}
+	return err
+}
+
+func (c *Connector) webSession(ctx context.Context, renew bool) (*webSession, error) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session != nil && !renew {
+		return c.session, nil
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	session := &webSession{client: &http.Client{Jar: jar, Transport: c.httpClient.Transport}}
+
+	var info map[string]interface{}
+	err = session.call(ctx, c.URL+"/web/session/authenticate", map[string]interface{}{
+		"db":       c.DB,
+		"login":    c.Username,
+		"password": c.sessionPassword,
+	}, &info)
+	if err != nil {
+		return nil, fmt.Errorf("session authentication failed: %w", err)
+	}
+	if uid, ok := toInt64(info["uid"]); !ok || uid == 0 {
+		return nil, fmt.Errorf("session authentication failed: invalid credentials")
+	}
+
+	c.session = session
+	return session, nil
+}
+
+func (s *webSession) call(ctx context.Context, url string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "call",
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("request error: bad status code - %d", resp.StatusCode)
+	}
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    struct {
+				Name    string `json:"name"`
+				Message string `json:"message"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("invalid JSON-RPC response: %w", err)
+	}
+	if envelope.Error != nil {
+		if envelope.Error.Code == 100 {
+			return errSessionExpired
+		}
+		return fmt.Errorf("%s: %s", envelope.Error.Data.Name, envelope.Error.Data.Message)
+	}
+
+	// Decode numbers like the XML-RPC decoder does so shared helpers work on
+	// either result.
+	dec := json.NewDecoder(bytes.NewReader(envelope.Result))
+	dec.UseNumber()
+	if err := dec.Decode(result); err != nil {
+		return err
+	}
+	normalizeJSONNumbers(result)
+	return nil
+}
+
+func parseSessionInfo(raw map[string]interface{}) *SessionInfo {
+	info := &SessionInfo{Raw: raw}
+	info.UID, _ = toInt64(raw["uid"])
+	info.DB, _ = raw["db"].(string)
+	info.Username, _ = raw["username"].(string)
+	info.Name, _ = raw["name"].(string)
+	info.PartnerID, _ = toInt64(raw["partner_id"])
+	info.IsAdmin, _ = raw["is_admin"].(bool)
+	info.IsSystem, _ = raw["is_system"].(bool)
+	info.ServerVersion, _ = raw["server_version"].(string)
+	info.ServerVersionInfo, _ = raw["server_version_info"].([]interface{})
+	info.UserContext, _ = raw["user_context"].(map[string]interface{})
+	info.WebBaseURL, _ = raw["web.base.url"].(string)
+
+	// Odoo 13 to 15 report the current company as an [id, name] pair,
+	// Odoo 16 and later as a plain ID.
+	if companies, ok := raw["user_companies"].(map[string]interface{}); ok {
+		info.CompanyID, _ = many2oneID(companies["current_company"])
+	} else {
+		info.CompanyID, _ = toInt64(raw["company_id"])
+	}
+	return info
+}
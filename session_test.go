@@ -0,0 +1,115 @@
+package odoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSessionInfo(t *testing.T) {
+	logins, session := 0, ""
+	reply := func(w http.ResponseWriter, result interface{}, errorCode int) {
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": nil, "result": result}
+		if errorCode != 0 {
+			resp = map[string]interface{}{"jsonrpc": "2.0", "id": nil, "error": map[string]interface{}{
+				"code": errorCode, "message": "Odoo Session Expired",
+				"data": map[string]interface{}{"name": "odoo.http.SessionExpiredException", "message": "Session expired"},
+			}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/xmlrpc/2/", xmlRPCHandler(t, func(service, method string, args []interface{}) interface{} { return 2 }))
+	mux.HandleFunc("/web/session/authenticate", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params map[string]interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Params["db"] != "db" || req.Params["login"] != "admin" || req.Params["password"] != "secret" {
+			reply(w, map[string]interface{}{"uid": false}, 0)
+			return
+		}
+		logins++
+		session = fmt.Sprint("s", logins)
+		http.SetCookie(w, &http.Cookie{Name: "session_id", Value: session, Path: "/"})
+		reply(w, map[string]interface{}{"uid": 2}, 0)
+	})
+	mux.HandleFunc("/web/session/get_session_info", func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session_id"); err != nil || cookie.Value != session {
+			reply(w, nil, 100)
+			return
+		}
+		reply(w, map[string]interface{}{
+			"uid": 2, "db": "db", "username": "admin", "name": "Mitchell Admin", "partner_id": 3,
+			"is_admin": true, "is_system": true, "server_version": "17.0",
+			"user_companies": map[string]interface{}{"current_company": 1},
+			"user_context":   map[string]interface{}{"lang": "en_US"},
+		}, 0)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithSessionAuth("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		info, err := c.SessionInfo(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.UID != 2 || info.Name != "Mitchell Admin" || info.CompanyID != 1 || !info.IsSystem || info.UserContext["lang"] != "en_US" {
+			t.Errorf("got %+v", info)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("%d logins for two calls, want the session reused", logins)
+	}
+
+	// An expired session is re-established once
+	session = "expired"
+	if _, err := c.SessionInfo(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if logins != 2 {
+		t.Errorf("%d logins after the session expired, want 2", logins)
+	}
+
+	c, err = NewConnector(srv.URL, "admin", "key", "db", WithSessionAuth("wrong"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SessionInfo(ctx); err == nil {
+		t.Error("wrong password did not fail")
+	}
+	c, err = NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SessionInfo(ctx); err == nil {
+		t.Error("session info without session authentication did not fail")
+	}
+}
+
+func TestParseSessionInfo(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		raw  map[string]interface{}
+		want int64
+	}{
+		{"Odoo 15", map[string]interface{}{"user_companies": map[string]interface{}{"current_company": []interface{}{int64(4), "Branch"}}}, 4},
+		{"Odoo 16", map[string]interface{}{"user_companies": map[string]interface{}{"current_company": int64(4)}}, 4},
+		{"without companies", map[string]interface{}{"company_id": int64(4)}, 4},
+	} {
+		info := parseSessionInfo(tc.raw)
+		if info.CompanyID != tc.want || !reflect.DeepEqual(info.Raw, tc.raw) {
+			t.Errorf("%s: got %+v", tc.name, info)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package odoo
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Sink is a destination of export and sync pipelines. For each model stream
+// the pipeline calls Open once, then WriteBatch and Commit for every batch and
+// finally Close. A batch counts as delivered once Commit returns, which is
+// when sync checkpoints advance.
+type Sink interface {
+	Open(ctx context.Context, schema SinkSchema) error
+	WriteBatch(ctx context.Context, batch []Change) error
+	Commit(ctx context.Context) error
+	Close() error
+}
+
+// SinkSchema describes the records written to a sink
+type SinkSchema struct {
+	Model string
+	// Fields are the record fields, id first
+	Fields []string
+	// Types maps each field to its Odoo type from fields_get
+	Types map[string]string
+}
+
+// ExportOptions configures Export
+type ExportOptions struct {
+	// Query selects the records to export. When Query.Fields is empty all
+	// stored, non-binary fields are exported.
+	Query SearchReadOptions
+	// BatchSize is the number of records per batch (default 500)
+	BatchSize int
+}
+
+// Export streams the records of model matching opts.Query into sink as
+// ChangeCreated changes and returns the number of records written
+func (c *Connector) Export(ctx context.Context, model string, opts ExportOptions, sink Sink) (int64, error) {
+	schema, err := c.sinkSchema(ctx, model, opts.Query.Fields)
+	if err != nil {
+		return 0, fmt.Errorf("export failed for model %s: %w", model, err)
+	}
+	query := opts.Query
+	query.Fields = schema.Fields
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	if err := sink.Open(ctx, schema); err != nil {
+		return 0, fmt.Errorf("export failed for model %s: %w", model, err)
+	}
+
+	var written int64
+	batch := make([]Change, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.WriteBatch(ctx, batch); err != nil {
+			return err
+		}
+		if err := sink.Commit(ctx); err != nil {
+			return err
+		}
+		written += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	err = c.SearchReadStream(ctx, model, query, func(record map[string]interface{}) error {
+		id, _ := toInt64(record["id"])
+		batch = append(batch, Change{Type: ChangeCreated, Model: model, ID: id, Record: record})
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return written, fmt.Errorf("export failed for model %s: %w", model, err)
+	}
+	return written, nil
+}
+
+// SyncTo writes the changes of model since state to sink and returns the new
+// state. Each batch of opts.BatchSize changes is committed before the state
+// advances past it; on failure the state of the last committed batch is
+// returned with the error. When opts.Fields is empty all stored, non-binary
+// fields are synchronized.
+func (c *Connector) SyncTo(ctx context.Context, model string, state SyncState, opts SyncOptions, sink Sink) (SyncState, error) {
+	schema, err := c.sinkSchema(ctx, model, opts.Fields)
+	if err != nil {
+		return state, fmt.Errorf("sync failed for model %s: %w", model, err)
+	}
+	opts.Fields = schema.Fields
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	if err := sink.Open(ctx, schema); err != nil {
+		return state, fmt.Errorf("sync failed for model %s: %w", model, err)
+	}
+
+	aliases := c.aliasesOf(model)
+	committed := state
+	pending := state
+	batch := make([]Change, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.WriteBatch(ctx, batch); err != nil {
+			return err
+		}
+		if err := sink.Commit(ctx); err != nil {
+			return err
+		}
+		committed = pending
+		batch = batch[:0]
+		return nil
+	}
+
+	next, err := c.SyncChanges(ctx, model, state, opts, func(change Change) error {
+		batch = append(batch, change)
+		if change.Type != ChangeDeleted {
+			pending.WriteDate, _ = change.Record[aliases.alias("write_date")].(string)
+			pending.LastID = change.ID
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		if err = flush(); err == nil {
+			committed = next
+		}
+	}
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return committed, fmt.Errorf("sync failed for model %s: %w", model, err)
+	}
+	return committed, nil
+}
+
+// sinkSchema resolves the fields written to a sink and their types
+func (c *Connector) sinkSchema(ctx context.Context, model string, fields []string) (SinkSchema, error) {
+	aliases := c.aliasesOf(model)
+	fieldTypes, err := c.exportFieldTypes(ctx, model, aliases.fields(fields))
+	if err != nil {
+		return SinkSchema{}, err
+	}
+	fieldTypes["id"] = "integer"
+	if len(fields) > 0 {
+		// Sync pipelines rely on these
+		fieldTypes["create_date"] = "datetime"
+		fieldTypes["write_date"] = "datetime"
+	}
+
+	// Sinks see the records as read, with aliased fields
+	types := make(map[string]string, len(fieldTypes))
+	names := make([]string, 0, len(fieldTypes))
+	for field, typ := range fieldTypes {
+		name := aliases.alias(field)
+		types[name] = typ
+		names = append(names, name)
+	}
+	sortFieldNames(names)
+	return SinkSchema{Model: model, Fields: names, Types: types}, nil
+}
+
+// CSVSink writes records as CSV with a header row of the schema fields.
+// Many2one fields are written as their ID, x2many and other structured values
+// as JSON and empty values as empty cells. CSV describes snapshots, so
+// deletions are skipped.
+type CSVSink struct {
+	w      *bufio.Writer
+	csv    *csv.Writer
+	closer io.Closer
+	schema SinkSchema
+}
+
+// NewCSVSink returns a CSV sink writing to w. Close closes w if it is an
+// io.Closer.
+func NewCSVSink(w io.Writer) *CSVSink {
+	bw := bufio.NewWriter(w)
+	closer, _ := w.(io.Closer)
+	return &CSVSink{w: bw, csv: csv.NewWriter(bw), closer: closer}
+}
+
+// Open writes the header row
+func (s *CSVSink) Open(ctx context.Context, schema SinkSchema) error {
+	s.schema = schema
+	return s.csv.Write(schema.Fields)
+}
+
+// WriteBatch writes a row per created or updated record
+func (s *CSVSink) WriteBatch(ctx context.Context, batch []Change) error {
+	row := make([]string, len(s.schema.Fields))
+	for _, change := range batch {
+		if change.Type == ChangeDeleted {
+			continue
+		}
+		for i, name := range s.schema.Fields {
+			cell, err := csvCell(s.schema.Types[name], change.Record[name])
+			if err != nil {
+				return fmt.Errorf("field %s of record %d: %w", name, change.ID, err)
+			}
+			row[i] = cell
+		}
+		if err := s.csv.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit flushes the written rows
+func (s *CSVSink) Commit(ctx context.Context) error {
+	s.csv.Flush()
+	if err := s.csv.Error(); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// Close flushes and closes the underlying writer
+func (s *CSVSink) Close() error {
+	err := s.Commit(context.Background())
+	if s.closer != nil {
+		if closeErr := s.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func csvCell(fieldType string, value interface{}) (string, error) {
+	v, err := sqlValue(fieldType, value)
+	if err != nil {
+		return "", err
+	}
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case int64:
+		return strconv.FormatInt(t, 10), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case string:
+		return t, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// JSONLSink writes one JSON object per line: the record as read from Odoo for
+// created and updated records and {"id": <id>, "_deleted": true} for
+// deletions
+type JSONLSink struct {
+	w      *bufio.Writer
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewJSONLSink returns a JSON Lines sink writing to w. Close closes w if it
+// is an io.Closer.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	bw := bufio.NewWriter(w)
+	closer, _ := w.(io.Closer)
+	return &JSONLSink{w: bw, enc: json.NewEncoder(bw), closer: closer}
+}
+
+// StdoutSink returns a JSON Lines sink writing to standard output, which it
+// leaves open
+func StdoutSink() *JSONLSink {
+	s := NewJSONLSink(os.Stdout)
+	s.closer = nil
+	return s
+}
+
+// Open does nothing; JSON Lines need no header
+func (s *JSONLSink) Open(ctx context.Context, schema SinkSchema) error {
+	return nil
+}
+
+// WriteBatch writes a line per change
+func (s *JSONLSink) WriteBatch(ctx context.Context, batch []Change) error {
+	for _, change := range batch {
+		var v interface{} = change.Record
+		if change.Type == ChangeDeleted {
+			v = map[string]interface{}{"id": change.ID, "_deleted": true}
+		}
+		if err := s.enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit flushes the written lines
+func (s *JSONLSink) Commit(ctx context.Context) error {
+	return s.w.Flush()
+}
+
+// Close flushes and closes the underlying writer
+func (s *JSONLSink) Close() error {
+	err := s.w.Flush()
+	if s.closer != nil {
+		if closeErr := s.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// exportFieldTypes returns the Odoo type of each exported field
+func (c *Connector) exportFieldTypes(ctx context.Context, model string, fields []string) (map[string]string, error) {
+	args := []interface{}{}
+	if len(fields) > 0 {
+		args = append(args, fields)
+	}
+	var defs map[string]map[string]interface{}
+	err := c.execute(ctx, model, "fields_get", args, map[string]interface{}{
+		"attributes": []string{"type", "store"},
+	}, &defs)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]string, len(defs))
+	for name, def := range defs {
+		typ, _ := def["type"].(string)
+		if len(fields) == 0 {
+			if stored, _ := def["store"].(bool); !stored || typ == "binary" {
+				continue
+			}
+		}
+		types[name] = typ
+	}
+	for _, name := range fields {
+		if _, ok := types[name]; !ok {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+	}
+	return types, nil
+}
+
+// sortFieldNames sorts names alphabetically with id first
+func sortFieldNames(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "id" || names[j] == "id" {
+			return names[i] == "id"
+		}
+		return names[i] < names[j]
+	})
+}
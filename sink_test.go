@@ -0,0 +1,75 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestFileSinks(t *testing.T) {
+	schema := SinkSchema{
+		Model:  "res.partner",
+		Fields: []string{"id", "active", "category_id", "name", "parent_id"},
+		Types: map[string]string{
+			"id":          "integer",
+			"active":      "boolean",
+			"category_id": "many2many",
+			"name":        "char",
+			"parent_id":   "many2one",
+		},
+	}
+	batch := []Change{
+		{Type: ChangeCreated, ID: 7, Record: map[string]interface{}{
+			"id": int64(7), "active": true, "category_id": []interface{}{int64(1), int64(2)},
+			"name": "Azure, Inc.", "parent_id": []interface{}{int64(3), "Parent"},
+		}},
+		{Type: ChangeUpdated, ID: 8, Record: map[string]interface{}{
+			"id": int64(8), "active": false, "category_id": []interface{}{},
+			"name": false, "parent_id": false,
+		}},
+		{Type: ChangeDeleted, ID: 9},
+	}
+
+	tests := []struct {
+		name string
+		sink func(*bytes.Buffer) Sink
+		want string
+	}{
+		{
+			name: "csv",
+			sink: func(buf *bytes.Buffer) Sink { return NewCSVSink(buf) },
+			want: "id,active,category_id,name,parent_id\n" +
+				"7,true,\"[1,2]\",\"Azure, Inc.\",3\n" +
+				"8,false,[],,\n",
+		},
+		{
+			name: "jsonl",
+			sink: func(buf *bytes.Buffer) Sink { return NewJSONLSink(buf) },
+			want: `{"active":true,"category_id":[1,2],"id":7,"name":"Azure, Inc.","parent_id":[3,"Parent"]}` + "\n" +
+				`{"active":false,"category_id":[],"id":8,"name":false,"parent_id":false}` + "\n" +
+				`{"_deleted":true,"id":9}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			sink := tt.sink(&buf)
+			ctx := context.Background()
+			if err := sink.Open(ctx, schema); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.WriteBatch(ctx, batch); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.Commit(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,519 @@
+package odoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// SearchReadStream runs a search_read like SearchReadRecords but decodes the
+// response while it is received and hands the records to fn one by one, so
+// large results never exist as a whole in memory. Iteration stops at the
+// first error returned by fn.
+//
+// Streamed calls are not retried or hedged: records may already have been
+// handed to fn when a failure occurs.
+func (c *Connector) SearchReadStream(ctx context.Context, model string, opts SearchReadOptions, fn func(record map[string]interface{}) error) error {
+	aliases := c.aliasesOf(model)
+	args, kwargs := searchReadStreamCall(aliases.query(opts))
+
+	err := c.stream(ctx, model, "search_read", args, kwargs, func(response io.Reader) error {
+		return c.codec().decodeArray(response, func(value interface{}) error {
+			record, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected record type %T", value)
+			}
+			return fn(aliases.record(record))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("search_read failed for model %s: %w", model, err)
+	}
+	return nil
+}
+
+// SearchReadStreamInto streams a search_read like SearchReadStream, but
+// decodes every record straight into dest, a pointer to a struct mapped with
+// `odoo` tags, and calls fn after each one. No record map is built in
+// between. dest is reset before every record, so fn has to copy it to keep
+// it. Without opts.Fields only the tagged fields are read, as with
+// SearchReadInto.
+//
+//	var lead Lead
+//	err := c.SearchReadStreamInto(ctx, "crm.lead", odoo.SearchReadOptions{}, &lead, func() error {
+//		return w.Write(lead)
+//	})
+func (c *Connector) SearchReadStreamInto(ctx context.Context, model string, opts SearchReadOptions, dest interface{}, fn func() error) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("search_read failed for model %s: %T is not a pointer to a struct", model, dest)
+	}
+	if len(opts.Fields) == 0 && !opts.AllFields {
+		opts.Fields = FieldsOf(dest)
+	}
+	aliases := c.aliasesOf(model)
+	args, kwargs := searchReadStreamCall(aliases.query(opts))
+	record := &structRecord{
+		value:   rv.Elem(),
+		fields:  structFieldsByName(rv.Elem().Type()),
+		aliases: aliases,
+	}
+
+	err := c.stream(ctx, model, "search_read", args, kwargs, func(response io.Reader) error {
+		return c.codec().decodeStructArray(response, record, fn)
+	})
+	if err != nil {
+		return fmt.Errorf("search_read failed for model %s: %w", model, err)
+	}
+	return nil
+}
+
+// searchReadStreamCall returns the arguments of a streamed search_read
+func searchReadStreamCall(opts SearchReadOptions) ([]interface{}, map[string]interface{}) {
+	if opts.Domain == nil {
+		opts.Domain = []interface{}{}
+	}
+	return []interface{}{opts.Domain}, map[string]interface{}{
+		"fields": opts.Fields,
+		"offset": opts.Offset,
+		"limit":  opts.Limit,
+		"order":  opts.Order,
+	}
+}
+
+// structRecord is the struct a streamed record is decoded into, with its
+// mapped fields by Odoo field name
+type structRecord struct {
+	value   reflect.Value
+	fields  map[string]structField
+	aliases *aliasMap
+}
+
+// field returns the struct field mapped to the Odoo field name
+func (r *structRecord) field(name string) (reflect.Value, structField, bool) {
+	f, ok := r.fields[r.aliases.alias(name)]
+	if !ok {
+		return reflect.Value{}, f, false
+	}
+	return r.value.FieldByIndex(f.index), f, true
+}
+
+// stream calls a method returning a list and decodes the response with
+// decode while it is received
+func (c *Connector) stream(ctx context.Context, model, method string, args []interface{}, kwargs map[string]interface{}, decode func(io.Reader) error) error {
+	release, err := c.admit(ctx, method, args)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, done := c.startCall(ctx, model, method)
+	err = c.streamOnce(ctx, c.executeArgs(model, method, args, kwargs), decode)
+	done(err)
+	return err
+}
+
+func (c *Connector) streamOnce(ctx context.Context, callArgs []interface{}, decode func(io.Reader) error) error {
+	buf := getBuffer()
+	if err := c.codec().encodeCall(buf, serviceObject, "execute_kw", callArgs); err != nil {
+		putBuffer(buf)
+		return err
+	}
+	response, err := c.postStream(ctx, c.URL, serviceObject, buf.Bytes(), func() { putBuffer(buf) })
+	if err != nil {
+		return err
+	}
+	defer response.Close()
+
+	return decode(response)
+}
+
+// decodeArrayStream reads a methodResponse whose value is an array and calls
+// fn for every element. A fault response is returned as *Error,
+// like the buffered path does.
+func decodeArrayStream(r io.Reader, fn func(interface{}) error) error {
+	return scanArrayStream(r, func(dec *valueDecoder) error {
+		return dec.arrayElements(fn)
+	})
+}
+
+// decodeStructArrayStream reads a methodResponse whose value is an array of
+// structs, decodes each struct into record and calls fn after it
+func decodeStructArrayStream(r io.Reader, record *structRecord, fn func() error) error {
+	return scanArrayStream(r, func(dec *valueDecoder) error {
+		return dec.arrayValues(func() error {
+			if err := dec.structValue(record); err != nil {
+				return err
+			}
+			return fn()
+		})
+	})
+}
+
+// scanArrayStream reads a methodResponse up to the start of its array and
+// calls elements to decode the array from dec. A fault response is returned
+// as *Error.
+func scanArrayStream(r io.Reader, elements func(dec *valueDecoder) error) error {
+	dec := &valueDecoder{xml.NewDecoder(r)}
+	dec.CharsetReader = xmlrpc.CharsetReader
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "fault":
+			return dec.fault()
+		case "array":
+			return elements(dec)
+		case "struct", "string", "int", "i4", "i8", "boolean", "double", "dateTime.iso8601", "base64", "nil":
+			return fmt.Errorf("xmlrpc: expected array response, got %s", start.Name.Local)
+		}
+	}
+}
+
+// valueDecoder decodes XML-RPC values from a token stream into the types the
+// xmlrpc package produces for interface{} targets: int64, float64, bool,
+// string, time.Time, map[string]interface{} and []interface{}. <nil/> is
+// decoded as nil.
+type valueDecoder struct {
+	*xml.Decoder
+}
+
+var errInvalidXML = errors.New("xmlrpc: invalid xml")
+
+// value decodes a <value> element whose start tag has been consumed,
+// including its end tag
+func (d *valueDecoder) value() (interface{}, error) {
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			v, err := d.typed(t)
+			if err != nil {
+				return nil, err
+			}
+			return v, d.end("value")
+		case xml.EndElement:
+			// A value without type element is a string.
+			return text.String(), nil
+		}
+	}
+}
+
+func (d *valueDecoder) typed(start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "struct":
+		return d.structMembers()
+	case "array":
+		var items []interface{}
+		err := d.arrayElements(func(v interface{}) error {
+			items = append(items, v)
+			return nil
+		})
+		if items == nil {
+			items = []interface{}{}
+		}
+		return items, err
+	case "nil":
+		return nil, d.Skip()
+	}
+
+	data, err := d.text()
+	if err != nil {
+		return nil, err
+	}
+	switch start.Name.Local {
+	case "string", "base64":
+		return data, nil
+	case "int", "i4", "i8":
+		return strconv.ParseInt(strings.TrimSpace(data), 10, 64)
+	case "double":
+		return strconv.ParseFloat(strings.TrimSpace(data), 64)
+	case "boolean":
+		return strconv.ParseBool(strings.TrimSpace(data))
+	case "dateTime.iso8601":
+		return parseXMLRPCTime(strings.TrimSpace(data))
+	}
+	return nil, fmt.Errorf("xmlrpc: unsupported type %s", start.Name.Local)
+}
+
+// structMembers decodes the members of a <struct> up to its end tag
+func (d *valueDecoder) structMembers() (map[string]interface{}, error) {
+	members := make(map[string]interface{})
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "member" {
+				return nil, errInvalidXML
+			}
+			name, value, err := d.member()
+			if err != nil {
+				return nil, err
+			}
+			members[name] = value
+		case xml.EndElement:
+			return members, nil
+		}
+	}
+}
+
+// member decodes the name and value of a <member> up to its end tag
+func (d *valueDecoder) member() (string, interface{}, error) {
+	var name string
+	var value interface{}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "name":
+				if name, err = d.text(); err != nil {
+					return "", nil, err
+				}
+			case "value":
+				if value, err = d.value(); err != nil {
+					return "", nil, err
+				}
+			default:
+				return "", nil, errInvalidXML
+			}
+		case xml.EndElement:
+			return name, value, nil
+		}
+	}
+}
+
+// arrayElements decodes the elements of an <array> whose start tag has been
+// consumed, calling fn for each, up to the array's end tag
+func (d *valueDecoder) arrayElements(fn func(interface{}) error) error {
+	return d.arrayValues(func() error {
+		v, err := d.value()
+		if err != nil {
+			return err
+		}
+		return fn(v)
+	})
+}
+
+// arrayValues calls value for every <value> of an <array> whose start tag
+// has been consumed, up to the array's end tag. value decodes the element
+// including its end tag.
+func (d *valueDecoder) arrayValues(value func() error) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "data":
+			case "value":
+				if err := value(); err != nil {
+					return err
+				}
+			default:
+				return errInvalidXML
+			}
+		case xml.EndElement:
+			if t.Name.Local == "array" {
+				return nil
+			}
+		}
+	}
+}
+
+// structValue decodes a <value> holding a <struct>, whose start tag has been
+// consumed, into record. The struct is reset first; members without a mapped
+// field are skipped without being decoded.
+func (d *valueDecoder) structValue(record *structRecord) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "struct" {
+				return fmt.Errorf("xmlrpc: expected struct, got %s", t.Name.Local)
+			}
+			record.value.Set(reflect.Zero(record.value.Type()))
+			if err := d.structInto(record); err != nil {
+				return err
+			}
+			return d.end("value")
+		case xml.EndElement:
+			return errors.New("xmlrpc: expected struct, got string")
+		}
+	}
+}
+
+// structInto decodes the members of a <struct> into record up to the
+// struct's end tag
+func (d *valueDecoder) structInto(record *structRecord) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "member" {
+				return errInvalidXML
+			}
+			if err := d.memberInto(record); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// memberInto decodes a <member> into its field of record up to the member's
+// end tag. Odoo sends the name before the value.
+func (d *valueDecoder) memberInto(record *structRecord) error {
+	var dst reflect.Value
+	var field structField
+	var mapped bool
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "name":
+				name, err := d.text()
+				if err != nil {
+					return err
+				}
+				dst, field, mapped = record.field(name)
+			case "value":
+				if !mapped {
+					if err := d.Skip(); err != nil {
+						return err
+					}
+					continue
+				}
+				v, err := d.value()
+				if err != nil {
+					return err
+				}
+				if err := decodeValue(dst, v); err != nil {
+					return fmt.Errorf("decoding field %s: %w", field.name, err)
+				}
+			default:
+				return errInvalidXML
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// fault decodes the value of a <fault> element into the error returned for
+// server faults
+func (d *valueDecoder) fault() error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "value" {
+			v, err := d.value()
+			if err != nil {
+				return err
+			}
+			members, _ := v.(map[string]interface{})
+			code, _ := toInt64(members["faultCode"])
+			msg, _ := members["faultString"].(string)
+			return newFault(int(code), msg)
+		}
+	}
+}
+
+// text returns the character data up to the end of the current element
+func (d *valueDecoder) text() (string, error) {
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			return text.String(), nil
+		case xml.StartElement:
+			return "", errInvalidXML
+		}
+	}
+}
+
+// end skips whitespace up to the end tag of the named element
+func (d *valueDecoder) end(name string) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			if t.Name.Local == name {
+				return nil
+			}
+			return errInvalidXML
+		case xml.StartElement:
+			return errInvalidXML
+		}
+	}
+}
+
+var xmlrpcTimeLayouts = []string{
+	"20060102T15:04:05",
+	"20060102T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+}
+
+func parseXMLRPCTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range xmlrpcTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,239 @@
+package odoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// searchReadResponse builds a search_read response with n partner-like records
+func searchReadResponse(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><methodResponse><params><param><value><array><data>`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, `<value><struct>`+
+			`<member><name>id</name><value><int>%d</int></value></member>`+
+			`<member><name>name</name><value><string>Partner %d</string></value></member>`+
+			`<member><name>email</name><value><string>partner%d@example.com</string></value></member>`+
+			`<member><name>active</name><value><boolean>1</boolean></value></member>`+
+			`<member><name>credit_limit</name><value><double>1250.5</double></value></member>`+
+			`<member><name>parent_id</name><value><array><data><value><int>1</int></value><value><string>Company</string></value></data></array></value></member>`+
+			`<member><name>category_id</name><value><array><data></data></array></value></member>`+
+			`</struct></value>`, i, i, i)
+	}
+	b.WriteString(`</data></array></value></param></params></methodResponse>`)
+	return []byte(b.String())
+}
+
+func TestDecodeArrayStreamMatchesBufferedDecoding(t *testing.T) {
+	data := searchReadResponse(3)
+
+	var buffered []map[string]interface{}
+	if err := xmlrpc.Response(data).Unmarshal(&buffered); err != nil {
+		t.Fatal(err)
+	}
+
+	var streamed []map[string]interface{}
+	err := decodeArrayStream(bytes.NewReader(data), func(v interface{}) error {
+		streamed = append(streamed, v.(map[string]interface{}))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(buffered, streamed) {
+		t.Errorf("streamed records differ:\nbuffered: %v\nstreamed: %v", buffered, streamed)
+	}
+}
+
+func TestDecodeArrayStreamFault(t *testing.T) {
+	data := `<?xml version="1.0"?><methodResponse><fault><value><struct>` +
+		`<member><name>faultCode</name><value><int>1</int></value></member>` +
+		`<member><name>faultString</name><value><string>Access Denied</string></value></member>` +
+		`</struct></value></fault></methodResponse>`
+
+	err := decodeArrayStream(strings.NewReader(data), func(interface{}) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "Access Denied") {
+		t.Errorf("expected fault error, got %v", err)
+	}
+}
+
+// streamedPartner maps the records of searchReadResponse
+type streamedPartner struct {
+	ID          int64    `odoo:"id"`
+	Name        string   `odoo:"name"`
+	Email       string   `odoo:"email"`
+	Active      bool     `odoo:"active"`
+	CreditLimit float64  `odoo:"credit_limit"`
+	Parent      Many2one `odoo:"parent_id"`
+	CategoryIDs []int64  `odoo:"category_id"`
+}
+
+func TestDecodeStructArrayStreamMatchesDecodeRecord(t *testing.T) {
+	data := searchReadResponse(3)
+
+	var records []map[string]interface{}
+	if err := xmlrpc.Response(data).Unmarshal(&records); err != nil {
+		t.Fatal(err)
+	}
+	want := make([]streamedPartner, len(records))
+	for i, record := range records {
+		if err := DecodeRecord(record, &want[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var partner streamedPartner
+	record := &structRecord{value: reflect.ValueOf(&partner).Elem(), fields: structFieldsByName(reflect.TypeOf(partner))}
+	var got []streamedPartner
+	err := decodeStructArrayStream(bytes.NewReader(data), record, func() error {
+		got = append(got, partner)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDecodeStructArrayStreamResetsRecords(t *testing.T) {
+	data := `<?xml version="1.0"?><methodResponse><params><param><value><array><data>` +
+		`<value><struct><member><name>id</name><value><int>1</int></value></member>` +
+		`<member><name>email</name><value><string>a@example.com</string></value></member></struct></value>` +
+		`<value><struct><member><name>id</name><value><int>2</int></value></member></struct></value>` +
+		`</data></array></value></param></params></methodResponse>`
+
+	var partner streamedPartner
+	record := &structRecord{value: reflect.ValueOf(&partner).Elem(), fields: structFieldsByName(reflect.TypeOf(partner))}
+	var got []streamedPartner
+	err := decodeStructArrayStream(strings.NewReader(data), record, func() error {
+		got = append(got, partner)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []streamedPartner{{ID: 1, Email: "a@example.com"}, {ID: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	notStructs := `<?xml version="1.0"?><methodResponse><params><param><value><array><data>` +
+		`<value><int>1</int></value></data></array></value></param></params></methodResponse>`
+	if err := decodeStructArrayStream(strings.NewReader(notStructs), record, func() error { return nil }); err == nil {
+		t.Error("an array of integers did not fail")
+	}
+}
+
+func TestSearchReadStreamInto(t *testing.T) {
+	var fields interface{}
+	srv := jsonRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		fields = args[6].(map[string]interface{})["fields"]
+		return json.RawMessage(typedRecordsJSON)
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db", WithProtocol(JSONRPC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []mappedLead
+	if err := c.SearchReadIntoContext(context.Background(), "crm.lead", SearchReadOptions{}, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	var lead mappedLead
+	var got []mappedLead
+	err = c.SearchReadStreamInto(context.Background(), "crm.lead", SearchReadOptions{}, &lead, func() error {
+		got = append(got, lead)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+	if want := []interface{}{"id", "name", "email_from", "partner_id", "user_id", "stage_id", "expected_revenue", "active", "date_deadline", "create_date", "tag_ids"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields %v, want %v", fields, want)
+	}
+
+	stop := errors.New("stop")
+	var calls int
+	err = c.SearchReadStreamInto(context.Background(), "crm.lead", SearchReadOptions{}, &lead, func() error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("got %v after %d records, want stop after 1", err, calls)
+	}
+
+	if err := c.SearchReadStreamInto(context.Background(), "crm.lead", SearchReadOptions{}, lead, func() error { return nil }); err == nil {
+		t.Error("a struct value did not fail")
+	}
+}
+
+func BenchmarkSearchReadBuffered(b *testing.B) {
+	data := searchReadResponse(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var records []map[string]interface{}
+		if err := xmlrpc.Response(data).Unmarshal(&records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchReadStream(b *testing.B) {
+	data := searchReadResponse(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := decodeArrayStream(bytes.NewReader(data), func(interface{}) error { return nil })
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchReadStreamDecodeRecord(b *testing.B) {
+	data := searchReadResponse(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var partner streamedPartner
+		err := decodeArrayStream(bytes.NewReader(data), func(v interface{}) error {
+			return DecodeRecord(v.(map[string]interface{}), &partner)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchReadStreamInto(b *testing.B) {
+	data := searchReadResponse(2000)
+	var partner streamedPartner
+	record := &structRecord{value: reflect.ValueOf(&partner).Elem(), fields: structFieldsByName(reflect.TypeOf(partner))}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := decodeStructArrayStream(bytes.NewReader(data), record, func() error { return nil })
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package odoo
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// syncModel is a model served by syncServer for the sync pipelines. Its
+// records carry id, name, create_date and write_date.
+type syncModel struct {
+	mu      sync.Mutex
+	records []map[string]interface{}
+	// omit lists fields left out of the records read
+	omit []string
+	// reads are the domains of the search_read calls
+	reads [][]interface{}
+}
+
+func (m *syncModel) set(id int64, name, createDate, writeDate string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record := map[string]interface{}{"id": id, "name": name, "create_date": createDate, "write_date": writeDate}
+	for i, r := range m.records {
+		if r["id"] == id {
+			m.records[i] = record
+			return
+		}
+	}
+	m.records = append(m.records, record)
+}
+
+func (m *syncModel) remove(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, r := range m.records {
+		if r["id"] == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return
+		}
+	}
+}
+
+var syncFields = map[string]interface{}{
+	"id":          map[string]interface{}{"type": "integer", "store": true},
+	"name":        map[string]interface{}{"type": "char", "store": true},
+	"create_date": map[string]interface{}{"type": "datetime", "store": true},
+	"write_date":  map[string]interface{}{"type": "datetime", "store": true},
+	"image":       map[string]interface{}{"type": "binary", "store": true},
+	"display":     map[string]interface{}{"type": "char", "store": false},
+}
+
+// syncServer serves m as res.partner. search_read understands the
+// write_date, id checkpoint domain of SyncChanges.
+func syncServer(t *testing.T, m *syncModel) *httptest.Server {
+	return xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		params := args[5].([]interface{})
+		kwargs, _ := args[6].(map[string]interface{})
+
+		switch args[4] {
+		case "fields_get":
+			if len(params) == 0 {
+				return syncFields
+			}
+			fields := map[string]interface{}{}
+			for _, name := range params[0].([]interface{}) {
+				if def, ok := syncFields[name.(string)]; ok {
+					fields[name.(string)] = def
+				}
+			}
+			return fields
+		case "search":
+			ids := []int64{}
+			for _, r := range m.records {
+				ids = append(ids, r["id"].(int64))
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			return ids
+		case "search_read":
+			domain := params[0].([]interface{})
+			m.reads = append(m.reads, domain)
+			var writeDate string
+			var lastID int64
+			for _, term := range domain {
+				if cond, ok := term.([]interface{}); ok && cond[1] == ">" {
+					if cond[0] == "write_date" {
+						writeDate = cond[2].(string)
+					} else {
+						lastID, _ = toInt64(cond[2])
+					}
+				}
+			}
+
+			var records []map[string]interface{}
+			for _, r := range m.records {
+				wd, id := r["write_date"].(string), r["id"].(int64)
+				if writeDate == "" || wd > writeDate || (wd == writeDate && id > lastID) {
+					records = append(records, r)
+				}
+			}
+			sort.Slice(records, func(i, j int) bool {
+				a, b := records[i], records[j]
+				if a["write_date"] != b["write_date"] {
+					return a["write_date"].(string) < b["write_date"].(string)
+				}
+				return a["id"].(int64) < b["id"].(int64)
+			})
+			if limit, _ := toInt64(kwargs["limit"]); limit > 0 && int(limit) < len(records) {
+				records = records[:limit]
+			}
+
+			result := []map[string]interface{}{}
+			for _, r := range records {
+				record := map[string]interface{}{}
+				for k, v := range r {
+					record[k] = v
+				}
+				for _, field := range m.omit {
+					delete(record, field)
+				}
+				result = append(result, record)
+			}
+			return result
+		}
+		t.Errorf("unexpected call %v", args[4])
+		return false
+	})
+}
+
+func TestSyncChanges(t *testing.T) {
+	m := &syncModel{}
+	m.set(1, "Azure", "2024-01-01 10:00:00", "2024-01-01 10:00:00")
+	m.set(2, "Gemini", "2024-01-01 11:00:00", "2024-01-01 11:00:00")
+	srv := syncServer(t, m)
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type event struct {
+		Type ChangeType
+		ID   int64
+	}
+	run := func(state SyncState) (SyncState, []event) {
+		t.Helper()
+		var events []event
+		state, err := c.SyncChanges(context.Background(), "res.partner", state, SyncOptions{Fields: []string{"name"}, BatchSize: 1, TrackDeletes: true}, func(change Change) error {
+			events = append(events, event{change.Type, change.ID})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return state, events
+	}
+
+	state, events := run(SyncState{})
+	if want := []event{{ChangeCreated, 1}, {ChangeCreated, 2}}; !reflect.DeepEqual(events, want) {
+		t.Errorf("first sync: got %v, want %v", events, want)
+	}
+	want := SyncState{WriteDate: "2024-01-01 11:00:00", LastID: 2, IDs: []int64{1, 2}}
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("state = %+v, want %+v", state, want)
+	}
+
+	m.set(1, "Azure Interior", "2024-01-01 10:00:00", "2024-01-02 09:00:00")
+	m.set(3, "Deco", "2024-01-02 10:00:00", "2024-01-02 10:00:00")
+	m.remove(2)
+	_, events = run(state)
+	if want := []event{{ChangeUpdated, 1}, {ChangeCreated, 3}, {ChangeDeleted, 2}}; !reflect.DeepEqual(events, want) {
+		t.Errorf("second sync: got %v, want %v", events, want)
+	}
+}
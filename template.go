@@ -0,0 +1,192 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// Currency holds the formatting rules of a res.currency record
+type Currency struct {
+	Name   string
+	Symbol string
+	// SymbolAfter places the symbol after the amount (position "after")
+	SymbolAfter   bool
+	DecimalPlaces int
+}
+
+// Currencies returns the active currencies keyed by their code, e.g. "EUR"
+func (c *Connector) Currencies(ctx context.Context) (map[string]Currency, error) {
+	var records []map[string]interface{}
+	err := c.execute(ctx, "res.currency", "search_read", []interface{}{[]interface{}{}}, map[string]interface{}{
+		"fields": []string{"name", "symbol", "position", "decimal_places"},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("reading currencies failed: %w", err)
+	}
+
+	currencies := make(map[string]Currency, len(records))
+	for _, record := range records {
+		currency := Currency{SymbolAfter: record["position"] == "after", DecimalPlaces: 2}
+		currency.Name, _ = record["name"].(string)
+		currency.Symbol, _ = record["symbol"].(string)
+		if places, ok := toInt64(record["decimal_places"]); ok {
+			currency.DecimalPlaces = int(places)
+		}
+		currencies[currency.Name] = currency
+	}
+	return currencies, nil
+}
+
+// TemplateOptions configures TemplateFuncs
+type TemplateOptions struct {
+	// Location is the time zone datetimes are shown in (default time.Local)
+	Location *time.Location
+	// DateLayout and DatetimeLayout format dates and datetimes (defaults
+	// "2006-01-02" and "2006-01-02 15:04")
+	DateLayout     string
+	DatetimeLayout string
+	// DecimalSeparator and ThousandsSeparator format amounts (defaults "."
+	// and ",")
+	DecimalSeparator   string
+	ThousandsSeparator string
+	// Grouping lists the sizes of digit groups like Language.Grouping
+	// (default [3, 0], groups of three)
+	Grouping []int
+	// Currencies are the currencies known to money, as returned by
+	// Connector.Currencies
+	Currencies map[string]Currency
+}
+
+// TemplateFuncs returns functions formatting Odoo values in html/template or
+// text/template templates:
+//
+//	m2o        display name of a many2one value: {{m2o .partner_id}}
+//	m2oID      record ID of a many2one value
+//	date       an Odoo date or datetime as a date: {{date .date_order}}
+//	datetime   an Odoo datetime in opts.Location: {{datetime .create_date}}
+//	money      an amount in a currency, given as code or many2one value:
+//	           {{money .amount_total .currency_id}} renders "1,234.50 €"
+//
+// The layout of date and datetime can be overridden per call:
+// {{date .date_order "02.01.2006"}}. Empty values (Odoo's false) render as "".
+func TemplateFuncs(opts TemplateOptions) template.FuncMap {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.DateLayout == "" {
+		opts.DateLayout = time.DateOnly
+	}
+	if opts.DatetimeLayout == "" {
+		opts.DatetimeLayout = "2006-01-02 15:04"
+	}
+	if opts.DecimalSeparator == "" {
+		opts.DecimalSeparator = "."
+	}
+	if opts.ThousandsSeparator == "" {
+		opts.ThousandsSeparator = ","
+	}
+	if opts.Grouping == nil {
+		opts.Grouping = []int{3, 0}
+	}
+
+	return template.FuncMap{
+		"m2o": func(v interface{}) string {
+			if pair, ok := v.([]interface{}); ok && len(pair) == 2 {
+				name, _ := pair[1].(string)
+				return name
+			}
+			return ""
+		},
+		"m2oID": func(v interface{}) int64 {
+			id, _ := many2oneID(v)
+			return id
+		},
+		"date": func(v interface{}, layout ...string) (string, error) {
+			t, ok, err := templateTime(v, opts.Location)
+			if !ok || err != nil {
+				return "", err
+			}
+			return t.Format(layoutOr(layout, opts.DateLayout)), nil
+		},
+		"datetime": func(v interface{}, layout ...string) (string, error) {
+			t, ok, err := templateTime(v, opts.Location)
+			if !ok || err != nil {
+				return "", err
+			}
+			return t.Format(layoutOr(layout, opts.DatetimeLayout)), nil
+		},
+		"money": func(amount, currency interface{}) (string, error) {
+			return opts.formatMoney(amount, currency)
+		},
+	}
+}
+
+func layoutOr(layout []string, fallback string) string {
+	if len(layout) > 0 && layout[0] != "" {
+		return layout[0]
+	}
+	return fallback
+}
+
+// templateTime parses an Odoo date or datetime value. Datetimes are stored in
+// UTC and converted to loc; dates have no time zone and are kept as they are.
+func templateTime(v interface{}, loc *time.Location) (time.Time, bool, error) {
+	switch value := v.(type) {
+	case time.Time:
+		return value.In(loc), true, nil
+	case string:
+		if value == "" {
+			return time.Time{}, false, nil
+		}
+		if t, err := time.Parse(time.DateOnly, value); err == nil {
+			return t, true, nil
+		}
+		t, err := time.Parse(time.DateTime, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%q is not an Odoo date or datetime", value)
+		}
+		return t.In(loc), true, nil
+	case nil, bool:
+		return time.Time{}, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("unexpected date value %v (%T)", v, v)
+}
+
+// formatMoney formats amount with the rules of currency, which is a currency
+// code or a many2one value of res.currency
+func (opts TemplateOptions) formatMoney(amount, currency interface{}) (string, error) {
+	var value float64
+	switch n := amount.(type) {
+	case float64:
+		value = n
+	case int64:
+		value = float64(n)
+	case int:
+		value = float64(n)
+	case nil, bool:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected amount %v (%T)", amount, amount)
+	}
+
+	code, _ := currency.(string)
+	if pair, ok := currency.([]interface{}); ok && len(pair) == 2 {
+		code, _ = pair[1].(string)
+	}
+	cur, ok := opts.Currencies[code]
+	if !ok {
+		cur = Currency{Name: code, Symbol: code, DecimalPlaces: 2}
+	}
+
+	// Like Odoo, keep the symbol and the amount together with a no-break space
+	formatted := formatNumber(value, cur.DecimalPlaces, opts.DecimalSeparator, opts.ThousandsSeparator, opts.Grouping)
+	switch {
+	case cur.Symbol == "":
+		return formatted, nil
+	case cur.SymbolAfter:
+		return formatted + "\u00a0" + cur.Symbol, nil
+	}
+	return cur.Symbol + "\u00a0" + formatted, nil
+}
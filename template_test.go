@@ -0,0 +1,42 @@
+package odoo
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	funcs := TemplateFuncs(TemplateOptions{
+		Location: berlin,
+		Currencies: map[string]Currency{
+			"EUR": {Name: "EUR", Symbol: "€", SymbolAfter: true, DecimalPlaces: 2},
+			"JPY": {Name: "JPY", Symbol: "¥", DecimalPlaces: 0},
+		},
+	})
+	tmpl := template.Must(template.New("order").Funcs(funcs).Parse(
+		`{{m2o .partner_id}} #{{m2oID .partner_id}}|{{m2o .user_id}}|{{date .date_order}}|{{datetime .create_date}}|` +
+			`{{date .create_date "02.01.2006"}}|{{money .amount_total .currency_id}}|{{money 1234567.5 "JPY"}}|{{money -0.001 "EUR"}}|{{money 12 "CHF"}}`))
+
+	var b strings.Builder
+	err = tmpl.Execute(&b, map[string]interface{}{
+		"partner_id":   []interface{}{int64(7), "Azure <Interior>"},
+		"user_id":      false,
+		"date_order":   "2024-03-31",
+		"create_date":  "2024-03-30 23:30:00",
+		"amount_total": 1234.5,
+		"currency_id":  []interface{}{int64(1), "EUR"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Azure &lt;Interior&gt; #7||2024-03-31|2024-03-31 00:30|31.03.2024|1,234.50\u00a0€|¥\u00a01,234,568|0.00\u00a0€|CHF\u00a012.00"
+	if b.String() != want {
+		t.Errorf("got  %s\nwant %s", b.String(), want)
+	}
+}
@@ -0,0 +1,135 @@
+package odoo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Throttle paces heavy jobs so they can run during business hours without
+// degrading the server for interactive users. Attach it to the context of a
+// job with WithThrottle; every call made with that context then waits for
+// the throttle first.
+//
+//	throttle := odoo.NewThrottle(5, 200) // 5 calls and 200 records per second
+//	ctx = odoo.WithThrottle(ctx, throttle)
+type Throttle struct {
+	calls   *rateLimiter
+	records *rateLimiter
+}
+
+// NewThrottle returns a throttle allowing callsPerSecond calls and
+// recordsPerSecond written records per second. A zero rate is unlimited.
+func NewThrottle(callsPerSecond, recordsPerSecond float64) *Throttle {
+	t := &Throttle{}
+	if callsPerSecond > 0 {
+		t.calls = newRateLimiter(callsPerSecond)
+	}
+	if recordsPerSecond > 0 {
+		t.records = newRateLimiter(recordsPerSecond)
+	}
+	return t
+}
+
+// Wait blocks until one call processing the given number of records is
+// allowed, or ctx is done
+func (t *Throttle) Wait(ctx context.Context, records int) error {
+	if t.calls != nil {
+		if err := t.calls.wait(ctx, 1); err != nil {
+			return err
+		}
+	}
+	if t.records != nil && records > 0 {
+		return t.records.wait(ctx, float64(records))
+	}
+	return nil
+}
+
+type throttleKey struct{}
+
+// WithThrottle returns a context whose calls are paced by t
+func WithThrottle(ctx context.Context, t *Throttle) context.Context {
+	return context.WithValue(ctx, throttleKey{}, t)
+}
+
+func throttleFrom(ctx context.Context) *Throttle {
+	t, _ := ctx.Value(throttleKey{}).(*Throttle)
+	return t
+}
+
+// recordCount estimates how many records a call writes from its arguments:
+// the value dicts of create and the IDs of write and unlink. Reads count as
+// zero records as their size is only known afterwards.
+func recordCount(method string, args []interface{}) int {
+	if len(args) == 0 {
+		return 0
+	}
+	switch method {
+	case "create":
+		if values, ok := args[0].([]interface{}); ok {
+			return len(values)
+		}
+		if values, ok := args[0].([]map[string]interface{}); ok {
+			return len(values)
+		}
+		return 1
+	case "write", "unlink":
+		switch ids := args[0].(type) {
+		case []int64:
+			return len(ids)
+		case []int:
+			return len(ids)
+		case []interface{}:
+			return len(ids)
+		}
+		return 1
+	}
+	return 0
+}
+
+// rateLimiter is a token bucket holding at most one second worth of tokens.
+// Requests larger than the bucket are granted on credit and delay the
+// following ones.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: rate, last: time.Now(), now: time.Now}
+}
+
+func (l *rateLimiter) wait(ctx context.Context, n float64) error {
+	if err := sleep(ctx, l.reserve(n)); err != nil {
+		l.cancel(n)
+		return err
+	}
+	return nil
+}
+
+// reserve takes n tokens and returns how long to wait until they are covered
+func (l *rateLimiter) reserve(n float64) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	l.tokens -= n
+	if l.tokens < 0 {
+		return time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return 0
+}
+
+// cancel gives back a reservation that will not be used
+func (l *rateLimiter) cancel(n float64) {
+	l.mu.Lock()
+	l.tokens += n
+	l.mu.Unlock()
+}
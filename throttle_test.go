@@ -0,0 +1,137 @@
+package odoo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(rate float64, clock *fakeClock) *rateLimiter {
+	l := newRateLimiter(rate)
+	l.now, l.last = clock.now, clock.now()
+	return l
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	l := newTestRateLimiter(10, clock)
+
+	// The full bucket covers one second worth of calls, then every call waits
+	// one more tenth of a second
+	for i := 0; i < 10; i++ {
+		if d := l.reserve(1); d != 0 {
+			t.Fatalf("call %d: delay %v within the bucket", i+1, d)
+		}
+	}
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		if d := l.reserve(1); d != want {
+			t.Errorf("call %d over the bucket: delay %v, want %v", i+1, d, want)
+		}
+	}
+
+	// Time pays off the debt first
+	clock.advance(300 * time.Millisecond)
+	if d := l.reserve(1); d != 100*time.Millisecond {
+		t.Errorf("after the debt: delay %v, want 100ms", d)
+	}
+
+	// The bucket never holds more than one second worth of tokens
+	clock.advance(time.Hour)
+	if d := l.reserve(10); d != 0 {
+		t.Errorf("full bucket: delay %v", d)
+	}
+	if d := l.reserve(5); d != 500*time.Millisecond {
+		t.Errorf("after a full bucket: delay %v, want 500ms", d)
+	}
+
+	// A cancelled reservation no longer delays the following ones
+	l.cancel(5)
+	if d := l.reserve(1); d != 100*time.Millisecond {
+		t.Errorf("after cancelling: delay %v, want 100ms", d)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	const rate, n = 50, 200
+	l := newTestRateLimiter(rate, newFakeClock())
+
+	delays := make(chan time.Duration, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delays <- l.reserve(1)
+		}()
+	}
+	wg.Wait()
+	close(delays)
+
+	// Every reservation gets its own slot: rate of them are free, the others
+	// are spread one tick apart
+	seen := map[time.Duration]bool{}
+	immediate := 0
+	var max time.Duration
+	for d := range delays {
+		if d == 0 {
+			immediate++
+		} else if seen[d] {
+			t.Errorf("delay %v handed out twice", d)
+		}
+		seen[d] = true
+		if d > max {
+			max = d
+		}
+	}
+	if immediate != rate {
+		t.Errorf("%d immediate reservations, want %d", immediate, rate)
+	}
+	if want := time.Duration((n - rate) / rate * float64(time.Second)); max != want {
+		t.Errorf("max delay %v, want %v", max, want)
+	}
+}
+
+func TestThrottleWait(t *testing.T) {
+	clock := newFakeClock()
+	throttle := NewThrottle(1, 0)
+	throttle.calls.now, throttle.calls.last = clock.now, clock.now()
+
+	ctx := context.Background()
+	if err := throttle.Wait(ctx, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call would wait a second; giving up returns the reservation
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := throttle.Wait(ctx, 0); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	clock.advance(time.Second)
+	if d := throttle.calls.reserve(1); d != 0 {
+		t.Errorf("delay %v after a cancelled wait", d)
+	}
+}
+
+func TestRecordCount(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		args   []interface{}
+		want   int
+	}{
+		{"create", []interface{}{map[string]interface{}{"name": "a"}}, 1},
+		{"create", []interface{}{[]interface{}{map[string]interface{}{}, map[string]interface{}{}}}, 2},
+		{"create", []interface{}{[]map[string]interface{}{{}, {}, {}}}, 3},
+		{"write", []interface{}{[]int64{1, 2}, map[string]interface{}{}}, 2},
+		{"write", []interface{}{[]int{1, 2, 3}, map[string]interface{}{}}, 3},
+		{"unlink", []interface{}{[]interface{}{1}}, 1},
+		{"unlink", []interface{}{int64(1)}, 1},
+		{"search_read", []interface{}{[]interface{}{}}, 0},
+		{"create", nil, 0},
+	} {
+		if got := recordCount(tc.method, tc.args); got != tc.want {
+			t.Errorf("recordCount(%s, %v) = %d, want %d", tc.method, tc.args, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package odoo
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TLSOptions configures the TLS connection to the server, for deployments
+// behind reverse proxies enforcing mutual TLS or with pinned certificates.
+// In a config file it is the "tls" object.
+type TLSOptions struct {
+	// PinnedSPKI are the base64 SHA-256 hashes of the subject public key
+	// info of accepted certificates, optionally prefixed with "sha256/" as
+	// printed by e.g. `openssl x509 -pubkey | openssl pkey -pubin -outform
+	// der | openssl dgst -sha256 -binary | base64`. The connection is
+	// refused unless a certificate of the verified chain matches one of
+	// them.
+	PinnedSPKI []string `json:"pinned_spki,omitempty"`
+	// CAFile is a PEM file of CAs to trust instead of the system roots,
+	// e.g. for an internal CA
+	CAFile string `json:"ca_file,omitempty"`
+	// ClientCertFile and ClientKeyFile are PEM files of the client
+	// certificate presented to the server
+	ClientCertFile string `json:"client_cert_file,omitempty"`
+	ClientKeyFile  string `json:"client_key_file,omitempty"`
+	// ClientCertificate is the client certificate when it is not read from
+	// files
+	ClientCertificate *tls.Certificate `json:"-"`
+}
+
+// WithTLS configures the TLS connection to the server
+func WithTLS(opts TLSOptions) Option {
+	return func(c *Connector) {
+		c.tlsOptions = &opts
+	}
+}
+
+// newTransport returns the HTTP transport of a connector, applying opts if
+// set
+func newTransport(opts *TLSOptions) (*http.Transport, error) {
+	if opts == nil {
+		return &http.Transport{}, nil
+	}
+	config, err := opts.config()
+	if err != nil {
+		return nil, fmt.Errorf("TLS configuration failed: %w", err)
+	}
+	return &http.Transport{TLSClientConfig: config}, nil
+}
+
+func (opts *TLSOptions) config() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", opts.CAFile)
+		}
+	}
+
+	switch {
+	case opts.ClientCertificate != nil:
+		config.Certificates = []tls.Certificate{*opts.ClientCertificate}
+	case opts.ClientCertFile != "" || opts.ClientKeyFile != "":
+		if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+			return nil, errors.New("client_cert_file and client_key_file must be given together")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(opts.PinnedSPKI) > 0 {
+		pins := make([][]byte, 0, len(opts.PinnedSPKI))
+		for _, pin := range opts.PinnedSPKI {
+			hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, "sha256/"))
+			if err != nil || len(hash) != sha256.Size {
+				return nil, fmt.Errorf("invalid SPKI pin %q", pin)
+			}
+			pins = append(pins, hash)
+		}
+		// Runs after the chain has been verified. Only the verified chains
+		// count: the server may send any certificate along with them.
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, chain := range state.VerifiedChains {
+				for _, cert := range chain {
+					hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+					for _, pin := range pins {
+						if subtle.ConstantTimeCompare(hash[:], pin) == 1 {
+							return nil
+						}
+					}
+				}
+			}
+			return errors.New("server certificate does not match any pinned key")
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,111 @@
+package odoo
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTLSOptions(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(srv.Certificate().RawSubjectPublicKeyInfo)
+	pin := "sha256/" + base64.StdEncoding.EncodeToString(hash[:])
+	otherPin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	client := clientCertificate(t, "integration")
+
+	get := func(opts TLSOptions) (string, error) {
+		transport, err := newTransport(&opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		var body [64]byte
+		n, _ := resp.Body.Read(body[:])
+		return string(body[:n]), nil
+	}
+
+	if _, err := get(TLSOptions{CAFile: caFile, PinnedSPKI: []string{otherPin, pin}}); err != nil {
+		t.Errorf("matching pin: %v", err)
+	}
+	if _, err := get(TLSOptions{CAFile: caFile, PinnedSPKI: []string{otherPin}}); err == nil {
+		t.Error("mismatching pin was accepted")
+	}
+	if name, err := get(TLSOptions{CAFile: caFile, ClientCertificate: &client}); err != nil || name != "integration" {
+		t.Errorf("client certificate: got %q, %v", name, err)
+	}
+
+	// A pinned certificate sent along with the chain but not part of it
+	pinned := clientCertificate(t, "pinned")
+	srv.TLS.Certificates[0].Certificate = append(srv.TLS.Certificates[0].Certificate, pinned.Certificate[0])
+	pinnedCert, err := x509.ParseCertificate(pinned.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash = sha256.Sum256(pinnedCert.RawSubjectPublicKeyInfo)
+	if _, err := get(TLSOptions{CAFile: caFile, PinnedSPKI: []string{base64.StdEncoding.EncodeToString(hash[:])}}); err == nil {
+		t.Error("pin matching a certificate outside the verified chain was accepted")
+	}
+	if _, err := get(TLSOptions{CAFile: caFile, PinnedSPKI: []string{pin}}); err != nil {
+		t.Errorf("matching pin with an extra certificate: %v", err)
+	}
+
+	if _, err := newTransport(&TLSOptions{PinnedSPKI: []string{"not a pin"}}); err == nil {
+		t.Error("invalid pin was accepted")
+	}
+	if _, err := newTransport(&TLSOptions{ClientCertFile: "cert.pem"}); err == nil {
+		t.Error("certificate without key was accepted")
+	}
+}
+
+func clientCertificate(t *testing.T, name string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
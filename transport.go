@@ -0,0 +1,132 @@
+package odoo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/rpc"
+	"sync"
+)
+
+// Services of the external API
+const (
+	serviceCommon = "common"
+	serviceObject = "object"
+	serviceDB     = "db"
+)
+
+// call invokes method on a service of the primary server and decodes the
+// response into result
+func (c *Connector) call(ctx context.Context, service, method string, args []interface{}, result interface{}) error {
+	buf := getBuffer()
+	if err := c.codec().encodeCall(buf, service, method, args); err != nil {
+		putBuffer(buf)
+		return err
+	}
+	resp, err := c.post(ctx, c.URL, service, buf.Bytes(), func() { putBuffer(buf) })
+	if err != nil {
+		return err
+	}
+	return resp.Unmarshal(result)
+}
+
+// post sends an encoded request to a service of the server at baseURL and
+// returns the complete response. HTTP errors are reported as
+// rpc.ServerError like the xmlrpc client does, faults as *Error. release, if
+// not nil, is called once the request body is no longer used.
+func (c *Connector) post(ctx context.Context, baseURL, service string, body []byte, release func()) (response, error) {
+	stream, err := c.postStream(ctx, baseURL, service, body, release)
+	if err != nil {
+		return nil, err
+	}
+	return c.readResponse(stream)
+}
+
+// postEncoding sends a call to a service of the primary server that is
+// encoded while it is sent instead of into a buffer first. Calls carrying
+// binary content use it, so files are never held in memory as a whole.
+func (c *Connector) postEncoding(ctx context.Context, service, method string, args []interface{}) (response, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w := bufio.NewWriterSize(pw, 32<<10)
+		err := c.codec().encodeCall(w, service, method, args)
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	stream, err := c.postBody(ctx, c.URL, service, pr, -1)
+	// Stops the encoding when the request failed before its body was sent,
+	// and waits for it, as it reads from the caller's reader
+	pr.Close()
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	return c.readResponse(stream)
+}
+
+// readResponse reads and closes a response body and returns the result
+func (c *Connector) readResponse(stream io.ReadCloser) (response, error) {
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.codec().parse(data)
+}
+
+// postStream sends an encoded request and returns the response body for the
+// caller to decode incrementally
+func (c *Connector) postStream(ctx context.Context, baseURL, service string, body []byte, release func()) (io.ReadCloser, error) {
+	reqBody := &requestBody{Reader: bytes.NewReader(body), release: release}
+	return c.postBody(ctx, baseURL, service, reqBody, int64(len(body)))
+}
+
+// postBody sends a request body of the given length, -1 if unknown, and
+// returns the response body
+func (c *Connector) postBody(ctx context.Context, baseURL, service string, body io.ReadCloser, length int64) (io.ReadCloser, error) {
+	url := c.codec().endpoint(baseURL, service)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.ContentLength = length
+	req.Header.Set("Content-Type", c.codec().contentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, rpc.ServerError(fmt.Sprintf("request error: bad status code - %d", resp.StatusCode))
+	}
+	return resp.Body, nil
+}
+
+// requestBody calls release when the HTTP transport closes it, which may
+// happen after the round trip returned. Only then may a pooled buffer holding
+// the body be reused.
+type requestBody struct {
+	*bytes.Reader
+	release func()
+	once    sync.Once
+}
+
+func (b *requestBody) Close() error {
+	if b.release != nil {
+		b.once.Do(b.release)
+	}
+	return nil
+}
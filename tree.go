@@ -0,0 +1,220 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Traversal selects the order in which a TreeIterator visits records.
+type Traversal int
+
+const (
+	// DepthFirst visits each record followed by its whole subtree.
+	DepthFirst Traversal = iota
+	// BreadthFirst visits all records of one depth before the next depth.
+	BreadthFirst
+)
+
+// TreeOptions configures a TreeIterator
+type TreeOptions struct {
+	// ParentField links a record to its parent. Defaults to "parent_id".
+	ParentField string
+	// Fields to read for every record; ID and ParentField are always included.
+	Fields []string
+	// Domain restricts the records taking part in the tree (optional).
+	Domain []interface{}
+	// RootID starts the traversal at a single record. When zero, every record
+	// without a parent in the result set is treated as a root. With a
+	// ParentField other than parent_id the subtree is read one level per call,
+	// as child_of only follows the parent field declared by the model.
+	RootID int64
+	// Order sorts siblings, e.g. "sequence, name". Defaults to "id".
+	Order string
+	// Traversal selects depth-first (default) or breadth-first order.
+	Traversal Traversal
+}
+
+// TreeNode is a record visited by a TreeIterator. Roots have depth 0.
+type TreeNode struct {
+	Record map[string]interface{}
+	Depth  int
+}
+
+// TreeIterator walks a hierarchical model such as product.category or
+// hr.employee. The records are fetched with a single search_read on the first
+// call to Next and traversed in memory.
+//
+//	it := c.TreeIterator(ctx, "product.category", odoo.TreeOptions{Fields: []string{"name"}})
+//	for it.Next() {
+//		node := it.Node()
+//		fmt.Println(strings.Repeat("  ", node.Depth), node.Record["name"])
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type TreeIterator struct {
+	c       *Connector
+	ctx     context.Context
+	model   string
+	opts    TreeOptions
+	loaded  bool
+	pending []TreeNode
+	current TreeNode
+	err     error
+}
+
+// TreeIterator returns an iterator over the hierarchy of model
+func (c *Connector) TreeIterator(ctx context.Context, model string, opts TreeOptions) *TreeIterator {
+	if opts.ParentField == "" {
+		opts.ParentField = "parent_id"
+	}
+	if opts.Order == "" {
+		opts.Order = "id"
+	}
+	return &TreeIterator{c: c, ctx: ctx, model: model, opts: opts}
+}
+
+// Next advances to the next record and reports whether there is one
+func (it *TreeIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.loaded {
+		it.loaded = true
+		if it.err = it.load(); it.err != nil {
+			return false
+		}
+	}
+	if len(it.pending) == 0 {
+		return false
+	}
+
+	it.current = it.pending[0]
+	it.pending = it.pending[1:]
+	return true
+}
+
+// Node returns the record the iterator currently points at
+func (it *TreeIterator) Node() TreeNode {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TreeIterator) Err() error {
+	return it.err
+}
+
+func (it *TreeIterator) load() error {
+	fields := append([]string{"id", it.opts.ParentField}, it.opts.Fields...)
+	var records []map[string]interface{}
+	var err error
+	switch {
+	case it.opts.RootID == 0:
+		records, err = it.read(it.opts.Domain, fields)
+	case it.opts.ParentField == "parent_id":
+		records, err = it.read(append(append([]interface{}{}, it.opts.Domain...),
+			[]interface{}{"id", "child_of", []int64{it.opts.RootID}}), fields)
+	default:
+		records, err = it.readSubtree(fields)
+	}
+	if err != nil {
+		return fmt.Errorf("tree traversal failed for model %s: %w", it.model, err)
+	}
+
+	present := make(map[int64]bool, len(records))
+	for _, record := range records {
+		if id, ok := toInt64(record["id"]); ok {
+			present[id] = true
+		}
+	}
+
+	children := make(map[int64][]map[string]interface{})
+	var roots []map[string]interface{}
+	for _, record := range records {
+		id, _ := toInt64(record["id"])
+		parentID, hasParent := many2oneID(record[it.opts.ParentField])
+		switch {
+		case it.opts.RootID != 0 && id == it.opts.RootID:
+			roots = append(roots, record)
+		case hasParent && present[parentID]:
+			children[parentID] = append(children[parentID], record)
+		case it.opts.RootID == 0:
+			roots = append(roots, record)
+		}
+	}
+
+	visited := make(map[int64]bool, len(records))
+	var walk func(record map[string]interface{}, depth int)
+	walk = func(record map[string]interface{}, depth int) {
+		id, _ := toInt64(record["id"])
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		it.pending = append(it.pending, TreeNode{Record: record, Depth: depth})
+		for _, child := range children[id] {
+			walk(child, depth+1)
+		}
+	}
+
+	if it.opts.Traversal == BreadthFirst {
+		level := roots
+		for depth := 0; len(level) > 0; depth++ {
+			var next []map[string]interface{}
+			for _, record := range level {
+				id, _ := toInt64(record["id"])
+				if visited[id] {
+					continue
+				}
+				visited[id] = true
+				it.pending = append(it.pending, TreeNode{Record: record, Depth: depth})
+				next = append(next, children[id]...)
+			}
+			level = next
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return nil
+}
+
+func (it *TreeIterator) read(domain []interface{}, fields []string) ([]map[string]interface{}, error) {
+	if domain == nil {
+		domain = []interface{}{}
+	}
+	var records []map[string]interface{}
+	err := it.c.execute(it.ctx, it.model, "search_read", []interface{}{domain}, map[string]interface{}{
+		"fields": fields,
+		"order":  it.opts.Order,
+	}, &records)
+	return records, err
+}
+
+// readSubtree reads RootID and the records below it, one level per call
+func (it *TreeIterator) readSubtree(fields []string) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	seen := make(map[int64]bool)
+	condition := []interface{}{"id", "=", it.opts.RootID}
+	for {
+		level, err := it.read(append(append([]interface{}{}, it.opts.Domain...), condition), fields)
+		if err != nil {
+			return nil, err
+		}
+		var ids []int64
+		for _, record := range level {
+			id, _ := toInt64(record["id"])
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+				records = append(records, record)
+			}
+		}
+		if len(ids) == 0 {
+			return records, nil
+		}
+		condition = []interface{}{it.opts.ParentField, "in", ids}
+	}
+}
@@ -0,0 +1,126 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// treeServer serves records linked by parent_id and manager_id, evaluating
+// the id child_of, id = and <field> in conditions of the tree iterator
+func treeServer(t *testing.T, records []map[string]interface{}, domains *[]interface{}) *Connector {
+	t.Helper()
+	parent := func(record map[string]interface{}, field string) int64 {
+		id, _ := many2oneID(record[field])
+		return id
+	}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		domain := args[5].([]interface{})[0].([]interface{})
+		*domains = append(*domains, domain)
+
+		result := []map[string]interface{}{}
+		for _, record := range records {
+			match := true
+			for _, term := range domain {
+				cond := term.([]interface{})
+				switch cond[1] {
+				case "child_of":
+					root, _ := toInt64(cond[2].([]interface{})[0])
+					below := false
+					for id := record["id"].(int64); id != 0; id = parent(recordByID(records, id), "parent_id") {
+						below = below || id == root
+					}
+					match = match && below
+				case "=":
+					id, _ := toInt64(cond[2])
+					match = match && record["id"] == id
+				case "in":
+					in := false
+					for _, v := range cond[2].([]interface{}) {
+						id, _ := toInt64(v)
+						in = in || parent(record, cond[0].(string)) == id
+					}
+					match = match && in
+				}
+			}
+			if match {
+				result = append(result, record)
+			}
+		}
+		return result
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func recordByID(records []map[string]interface{}, id int64) map[string]interface{} {
+	for _, record := range records {
+		if record["id"] == id {
+			return record
+		}
+	}
+	return nil
+}
+
+func TestTreeIterator(t *testing.T) {
+	// parent_id: 1 > 2 > 4, 1 > 3, 5
+	// manager_id: 5 > 3 > 4
+	records := []map[string]interface{}{
+		{"id": int64(1), "parent_id": false, "manager_id": false},
+		{"id": int64(2), "parent_id": []interface{}{1, "1"}, "manager_id": false},
+		{"id": int64(3), "parent_id": []interface{}{1, "1"}, "manager_id": []interface{}{5, "5"}},
+		{"id": int64(4), "parent_id": []interface{}{2, "2"}, "manager_id": []interface{}{3, "3"}},
+		{"id": int64(5), "parent_id": false, "manager_id": false},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		opts  TreeOptions
+		want  string
+		calls int
+	}{
+		{"depth first", TreeOptions{}, "[1@0 2@1 4@2 3@1 5@0]", 1},
+		{"breadth first", TreeOptions{Traversal: BreadthFirst}, "[1@0 5@0 2@1 3@1 4@2]", 1},
+		{"root", TreeOptions{RootID: 2}, "[2@0 4@1]", 1},
+		{"other parent field", TreeOptions{ParentField: "manager_id"}, "[1@0 2@0 5@0 3@1 4@2]", 1},
+		// The subtree is read level by level: 5, then 3, then 4, then nothing
+		{"other parent field root", TreeOptions{ParentField: "manager_id", RootID: 5}, "[5@0 3@1 4@2]", 4},
+	} {
+		var domains []interface{}
+		c := treeServer(t, records, &domains)
+		it := c.TreeIterator(context.Background(), "res.partner", tc.opts)
+		var visited []string
+		for it.Next() {
+			node := it.Node()
+			visited = append(visited, fmt.Sprintf("%v@%d", node.Record["id"], node.Depth))
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(visited); got != tc.want {
+			t.Errorf("%s: visited %s, want %s", tc.name, got, tc.want)
+		}
+		if len(domains) != tc.calls {
+			t.Errorf("%s: %d calls, want %d", tc.name, len(domains), tc.calls)
+		}
+	}
+}
+
+func TestTreeIteratorRootDomain(t *testing.T) {
+	var domains []interface{}
+	c := treeServer(t, nil, &domains)
+	it := c.TreeIterator(context.Background(), "product.category", TreeOptions{RootID: 7})
+	for it.Next() {
+	}
+	want := []interface{}{[]interface{}{[]interface{}{"id", "child_of", []interface{}{int64(7)}}}}
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("domains %v, want %v", domains, want)
+	}
+}
@@ -0,0 +1,107 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// User describes the user the connector is authenticated as
+type User struct {
+	ID         int64
+	Name       string
+	Login      string
+	PartnerID  int64
+	CompanyID  int64
+	CompanyIDs []int64
+	Lang       string
+	TZ         string
+	GroupIDs   []int64
+}
+
+// CurrentUser returns the integration user's partner, companies, language,
+// timezone and groups read in a single call, so applications can adapt to the
+// user's settings (e.g. use its default company).
+func (c *Connector) CurrentUser(ctx context.Context) (*User, error) {
+	groupsField, err := c.userGroupsField(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("current user lookup failed: %w", err)
+	}
+
+	_, uid, _ := c.credentials()
+	var records []map[string]interface{}
+	err = c.execute(ctx, "res.users", "read", []interface{}{[]int{uid}}, map[string]interface{}{
+		"fields": []string{"name", "login", "partner_id", "company_id", "company_ids", "lang", "tz", groupsField},
+	}, &records)
+	if err != nil {
+		return nil, fmt.Errorf("current user lookup failed: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("current user lookup failed: user %d not found", uid)
+	}
+
+	record := records[0]
+	user := &User{ID: int64(uid)}
+	user.Name, _ = record["name"].(string)
+	user.Login, _ = record["login"].(string)
+	user.PartnerID, _ = many2oneID(record["partner_id"])
+	user.CompanyID, _ = many2oneID(record["company_id"])
+	user.CompanyIDs = toInt64s(record["company_ids"])
+	user.Lang, _ = record["lang"].(string)
+	user.TZ, _ = record["tz"].(string)
+	user.GroupIDs = toInt64s(record[groupsField])
+	return user, nil
+}
+
+// userGroupsField returns the res.users field holding all effective groups of
+// a user. Up to Odoo 18 groups_id includes implied groups; Odoo 19 split it
+// into group_ids and all_group_ids.
+func (c *Connector) userGroupsField(ctx context.Context) (string, error) {
+	split, err := c.SupportsContext(ctx, FeatureSplitUserGroups)
+	if err != nil {
+		return "", err
+	}
+	if split {
+		return "all_group_ids", nil
+	}
+	return "groups_id", nil
+}
+
+// SendResetPassword makes Odoo email the user with the given login a link to
+// reset their password (auth_signup's action_reset_password). Without the
+// auth_signup module it fails with ErrUnsupported, as does
+// SendInvitationEmail.
+func (c *Connector) SendResetPassword(ctx context.Context, login string) error {
+	return c.sendPasswordEmail(ctx, login, false)
+}
+
+// SendInvitationEmail makes Odoo email the user with the given login its
+// account invitation, asking the user to set a first password.
+func (c *Connector) SendInvitationEmail(ctx context.Context, login string) error {
+	return c.sendPasswordEmail(ctx, login, true)
+}
+
+func (c *Connector) sendPasswordEmail(ctx context.Context, login string, invite bool) error {
+	if err := c.requireFeature(ctx, FeaturePasswordEmails); err != nil {
+		return fmt.Errorf("password email failed for login %s: %w", login, err)
+	}
+	var ids []int64
+	err := c.execute(ctx, "res.users", "search", []interface{}{[]interface{}{
+		[]interface{}{"login", "=", login},
+	}}, map[string]interface{}{"limit": 1}, &ids)
+	if err != nil {
+		return fmt.Errorf("password email failed for login %s: %w", login, err)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("password email failed for login %s: user not found", login)
+	}
+
+	// create_user selects the invitation template instead of the reset one.
+	var result interface{}
+	err = c.execute(ctx, "res.users", "action_reset_password", []interface{}{ids}, map[string]interface{}{
+		"context": map[string]interface{}{"create_user": invite},
+	}, &result)
+	if err != nil {
+		return fmt.Errorf("password email failed for login %s: %w", login, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// userServer serves the integration user (uid 2) of an Odoo version, with
+// groups 10 and 11 from the base module. handle answers any other call.
+func userServer(t *testing.T, version int, handle func(model, method string, args []interface{}) interface{}) *Connector {
+	t.Helper()
+	return versionServer(t, version, func(model, method string, args []interface{}) interface{} {
+		if model != "res.users" || method != "read" {
+			return handle(model, method, args)
+		}
+		// Odoo 19 split groups_id into group_ids and all_group_ids
+		groupsField := "groups_id"
+		if version >= 19 {
+			groupsField = "all_group_ids"
+		}
+		return []map[string]interface{}{{
+			"id":          2,
+			"name":        "Integration",
+			"login":       "integration@example.com",
+			"partner_id":  []interface{}{3, "Integration"},
+			"company_id":  []interface{}{1, "YourCompany"},
+			"company_ids": []interface{}{1, 4},
+			"lang":        "de_DE",
+			"tz":          false,
+			groupsField:   []interface{}{10, 11},
+		}}
+	})
+}
+
+func TestCurrentUser(t *testing.T) {
+	want := &User{
+		ID:         2,
+		Name:       "Integration",
+		Login:      "integration@example.com",
+		PartnerID:  3,
+		CompanyID:  1,
+		CompanyIDs: []int64{1, 4},
+		Lang:       "de_DE",
+		GroupIDs:   []int64{10, 11},
+	}
+	for _, version := range []int{16, 19} {
+		c := userServer(t, version, nil)
+		user, err := c.CurrentUser(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(user, want) {
+			t.Errorf("Odoo %d: got %+v, want %+v", version, user, want)
+		}
+	}
+}
+
+func TestPasswordEmails(t *testing.T) {
+	var reset []interface{}
+	var contexts []interface{}
+	c := userServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		switch method {
+		case "search_count":
+			// auth_signup is installed
+			return 1
+		case "search":
+			domain := args[5].([]interface{})[0].([]interface{})
+			if domain[0].([]interface{})[2] == "jane@example.com" {
+				return []int64{30}
+			}
+			return []int64{}
+		case "action_reset_password":
+			reset = append(reset, args[5].([]interface{})[0])
+			contexts = append(contexts, args[6].(map[string]interface{})["context"])
+			return true
+		}
+		return false
+	})
+	ctx := context.Background()
+
+	if err := c.SendResetPassword(ctx, "jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SendInvitationEmail(ctx, "jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{[]interface{}{int64(30)}, []interface{}{int64(30)}}; !reflect.DeepEqual(reset, want) {
+		t.Errorf("reset %v, want %v", reset, want)
+	}
+	// create_user selects the invitation template
+	want := []interface{}{
+		map[string]interface{}{"create_user": false},
+		map[string]interface{}{"create_user": true},
+	}
+	if !reflect.DeepEqual(contexts, want) {
+		t.Errorf("contexts %v, want %v", contexts, want)
+	}
+
+	if err := c.SendResetPassword(ctx, "nobody@example.com"); err == nil {
+		t.Error("unknown login did not fail")
+	}
+}
+
+func TestPasswordEmailsWithoutAuthSignup(t *testing.T) {
+	var sent bool
+	c := userServer(t, 17, func(model, method string, args []interface{}) interface{} {
+		if method == "search_count" {
+			return 0
+		}
+		sent = true
+		return []int64{30}
+	})
+
+	err := c.SendInvitationEmail(context.Background(), "jane@example.com")
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("got %v, want ErrUnsupported", err)
+	}
+	if sent {
+		t.Error("email sent without auth_signup")
+	}
+}
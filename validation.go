@@ -0,0 +1,281 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Rules are client-side validation rules checked before create and write
+// calls, so bad payloads are rejected locally instead of after a server round
+// trip. Rules are registered per model:
+//
+//	rules := odoo.NewRules().
+//		Required("res.partner", "name").
+//		Pattern("res.partner", "ref", `^C\d{6}$`).
+//		Selections("res.partner")
+//	connector, err := odoo.NewConnector(url, username, apiKey, db, odoo.WithRules(rules))
+//
+// Violations are reported as a *ValidationError.
+type Rules struct {
+	mu     sync.Mutex
+	models map[string]*modelRules
+}
+
+type modelRules struct {
+	required   []string
+	patterns   map[string]*regexp.Regexp
+	selections bool
+	funcs      []RuleFunc
+
+	// options holds the allowed values of selection fields once fetched
+	options map[string]map[string]bool
+}
+
+// RuleFunc is a custom rule. It is called with the method ("create" or
+// "write") and the values sent; a non-nil error rejects the call.
+type RuleFunc func(method string, values map[string]interface{}) error
+
+// NewRules returns an empty rule set
+func NewRules() *Rules {
+	return &Rules{models: make(map[string]*modelRules)}
+}
+
+// WithRules validates create and write calls against rules
+func WithRules(rules *Rules) Option {
+	return func(c *Connector) {
+		c.rules = rules
+	}
+}
+
+func (r *Rules) model(model string) *modelRules {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.models[model]
+	if !ok {
+		m = &modelRules{patterns: make(map[string]*regexp.Regexp)}
+		r.models[model] = m
+	}
+	return m
+}
+
+// Required requires fields to be set on create and not to be cleared on write
+func (r *Rules) Required(model string, fields ...string) *Rules {
+	m := r.model(model)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.required = append(m.required, fields...)
+	return r
+}
+
+// Pattern requires the text value of field to match pattern. Empty values are
+// left to Required. It panics if pattern does not compile.
+func (r *Rules) Pattern(model, field, pattern string) *Rules {
+	re := regexp.MustCompile(pattern)
+	m := r.model(model)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.patterns[field] = re
+	return r
+}
+
+// Selections requires the values of selection fields to be among the options
+// the server declares for them. The options are fetched on first use.
+func (r *Rules) Selections(model string) *Rules {
+	m := r.model(model)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.selections = true
+	return r
+}
+
+// Func registers a custom rule for model
+func (r *Rules) Func(model string, fn RuleFunc) *Rules {
+	m := r.model(model)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.funcs = append(m.funcs, fn)
+	return r
+}
+
+// FieldViolation is a rule violated by a field
+type FieldViolation struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is returned when values sent to create or write violate the
+// registered rules
+type ValidationError struct {
+	Model      string
+	Method     string
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		if v.Field == "" {
+			parts[i] = v.Message
+		} else {
+			parts[i] = v.Field + ": " + v.Message
+		}
+	}
+	return fmt.Sprintf("validation failed for %s on model %s: %s", e.Method, e.Model, strings.Join(parts, "; "))
+}
+
+// Is makes errors.Is match ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// validate checks the values of create and write calls against the rules
+func (c *Connector) validate(ctx context.Context, model, method string, args []interface{}) error {
+	if c.rules == nil || (method != "create" && method != "write") {
+		return nil
+	}
+	c.rules.mu.Lock()
+	m, ok := c.rules.models[model]
+	c.rules.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	var records []map[string]interface{}
+	switch method {
+	case "create":
+		if len(args) > 0 {
+			records = valueMaps(args[0])
+		}
+	case "write":
+		if len(args) > 1 {
+			records = valueMaps(args[1])
+		}
+	}
+
+	options, err := c.rules.selectionOptions(ctx, c, model, m)
+	if err != nil {
+		return fmt.Errorf("validation failed for model %s: %w", model, err)
+	}
+
+	verr := &ValidationError{Model: model, Method: method}
+	for _, values := range records {
+		verr.Violations = append(verr.Violations, m.check(method, values, options)...)
+	}
+	if len(verr.Violations) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// check returns the violations of one set of values
+func (m *modelRules) check(method string, values map[string]interface{}, options map[string]map[string]bool) []FieldViolation {
+	var violations []FieldViolation
+	for _, field := range m.required {
+		value, present := values[field]
+		if (method == "create" && !present) || (present && isEmptyValue(value)) {
+			violations = append(violations, FieldViolation{Field: field, Message: "value is required"})
+		}
+	}
+
+	fields := make([]string, 0, len(m.patterns))
+	for field := range m.patterns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		s, ok := values[field].(string)
+		if ok && s != "" && !m.patterns[field].MatchString(s) {
+			violations = append(violations, FieldViolation{Field: field, Message: fmt.Sprintf("%q does not match %s", s, m.patterns[field])})
+		}
+	}
+
+	for field, allowed := range options {
+		s, ok := values[field].(string)
+		if ok && !allowed[s] {
+			violations = append(violations, FieldViolation{Field: field, Message: fmt.Sprintf("%q is not an allowed value", s)})
+		}
+	}
+
+	for _, fn := range m.funcs {
+		if err := fn(method, values); err != nil {
+			violations = append(violations, FieldViolation{Message: err.Error()})
+		}
+	}
+	return violations
+}
+
+// selectionOptions returns the allowed values of the selection fields of
+// model, fetching them once if the model's rules check selections
+func (r *Rules) selectionOptions(ctx context.Context, c *Connector, model string, m *modelRules) (map[string]map[string]bool, error) {
+	r.mu.Lock()
+	enabled, options := m.selections, m.options
+	r.mu.Unlock()
+	if !enabled || options != nil {
+		return options, nil
+	}
+
+	var fields map[string]map[string]interface{}
+	err := c.execute(ctx, model, "fields_get", []interface{}{}, map[string]interface{}{
+		"attributes": []string{"type", "selection"},
+	}, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	options = make(map[string]map[string]bool)
+	for name, def := range fields {
+		selection, ok := def["selection"].([]interface{})
+		if def["type"] != "selection" || !ok {
+			continue
+		}
+		allowed := make(map[string]bool, len(selection))
+		for _, option := range selection {
+			if pair, ok := option.([]interface{}); ok && len(pair) > 0 {
+				allowed[fmt.Sprint(pair[0])] = true
+			}
+		}
+		options[name] = allowed
+	}
+
+	r.mu.Lock()
+	m.options = options
+	r.mu.Unlock()
+	return options, nil
+}
+
+// valueMaps returns the value dictionaries of a create or write argument,
+// which is a dictionary or a list of them
+func valueMaps(arg interface{}) []map[string]interface{} {
+	switch v := arg.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{v}
+	case []map[string]interface{}:
+		return v
+	case []interface{}:
+		var maps []map[string]interface{}
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				maps = append(maps, m)
+			}
+		}
+		return maps
+	}
+	return nil
+}
+
+// isEmptyValue reports whether a field value clears the field
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package odoo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	rules := NewRules().
+		Required("res.partner", "name").
+		Pattern("res.partner", "ref", `^C\d{3}$`).
+		Selections("res.partner")
+	// Pretend the selection options have been fetched
+	rules.models["res.partner"].options = map[string]map[string]bool{
+		"type": {"contact": true, "invoice": true},
+	}
+	c := &Connector{rules: rules}
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		method     string
+		args       []interface{}
+		violations int
+	}{
+		{"valid create", "create", []interface{}{map[string]interface{}{"name": "Azure", "ref": "C001", "type": "invoice"}}, 0},
+		{"missing name", "create", []interface{}{map[string]interface{}{"ref": "C001"}}, 1},
+		{"bad ref and type", "create", []interface{}{map[string]interface{}{"name": "Azure", "ref": "X1", "type": "other"}}, 2},
+		{"batch create", "create", []interface{}{[]interface{}{
+			map[string]interface{}{"name": "Azure"},
+			map[string]interface{}{"name": ""},
+		}}, 1},
+		{"write without name", "write", []interface{}{[]int64{1}, map[string]interface{}{"ref": "C002"}}, 0},
+		{"write clearing name", "write", []interface{}{[]int64{1}, map[string]interface{}{"name": false}}, 1},
+		{"other model", "create", []interface{}{map[string]interface{}{}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := "res.partner"
+			if tt.name == "other model" {
+				model = "res.company"
+			}
+			err := c.validate(ctx, model, tt.method, tt.args)
+			var verr *ValidationError
+			if tt.violations == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if len(verr.Violations) != tt.violations {
+				t.Errorf("got %d violations, want %d: %v", len(verr.Violations), tt.violations, err)
+			}
+		})
+	}
+}
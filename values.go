@@ -0,0 +1,85 @@
+package odoo
+
+import "encoding/json"
+
+// toInt64 converts the numeric representations produced by the XML-RPC
+// decoder into an int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// many2oneID extracts the record ID from a many2one value, which Odoo returns
+// as an [id, display_name] pair or false when the field is empty.
+func many2oneID(v interface{}) (int64, bool) {
+	switch t := v.(type) {
+	case []interface{}:
+		if len(t) == 0 {
+			return 0, false
+		}
+		return toInt64(t[0])
+	default:
+		return toInt64(v)
+	}
+}
+
+// toInt64s converts an x2many value (a list of IDs) into a slice of int64
+func toInt64s(v interface{}) []int64 {
+	items, _ := v.([]interface{})
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		if id, ok := toInt64(item); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// normalizeJSONNumbers walks a value decoded with json.Decoder.UseNumber and
+// replaces every json.Number by an int64 or float64, matching the types the
+// XML-RPC decoder produces.
+func normalizeJSONNumbers(v interface{}) {
+	switch t := v.(type) {
+	case *map[string]interface{}:
+		normalizeJSONNumbers(*t)
+	case *[]interface{}:
+		normalizeJSONNumbers(*t)
+	case *[]map[string]interface{}:
+		for _, m := range *t {
+			normalizeJSONNumbers(m)
+		}
+	case *interface{}:
+		*t = normalizeJSONValue(*t)
+	case map[string]interface{}:
+		for k, item := range t {
+			t[k] = normalizeJSONValue(item)
+		}
+	case []interface{}:
+		for i, item := range t {
+			t[i] = normalizeJSONValue(item)
+		}
+	}
+}
+
+func normalizeJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]interface{}, []interface{}:
+		normalizeJSONNumbers(t)
+	}
+	return v
+}
@@ -0,0 +1,43 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// majorVersion returns the major server version (e.g. 16 or 17), fetched
+// once per connector from the common endpoint. Several RPC methods changed
+// their signature between releases and callers branch on it.
+func (c *Connector) majorVersion(ctx context.Context) (int, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.serverVersion != 0 {
+		return c.serverVersion, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var info map[string]interface{}
+	if err := c.call(ctx, serviceCommon, "version", nil, &info); err != nil {
+		return 0, fmt.Errorf("version lookup failed: %w", err)
+	}
+
+	parts, _ := info["server_version_info"].([]interface{})
+	if len(parts) == 0 {
+		return 0, fmt.Errorf("version lookup failed: missing server_version_info")
+	}
+	// SaaS releases report their major version as e.g. "saas~17".
+	major, ok := toInt64(parts[0])
+	if !ok {
+		var saas int
+		if _, err := fmt.Sscanf(fmt.Sprint(parts[0]), "saas~%d", &saas); err != nil {
+			return 0, fmt.Errorf("version lookup failed: unexpected server_version_info %v", parts)
+		}
+		major = int64(saas)
+	}
+
+	c.serverVersion = int(major)
+	return c.serverVersion, nil
+}
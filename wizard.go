@@ -0,0 +1,146 @@
+package odoo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Action types returned by Odoo button methods
+const (
+	ActionClose  = "ir.actions.act_window_close"
+	ActionWindow = "ir.actions.act_window"
+	ActionReport = "ir.actions.report"
+	ActionURL    = "ir.actions.act_url"
+	ActionClient = "ir.actions.client"
+)
+
+// Action is the interpreted result of a button or wizard method. Methods that
+// return nothing (False/None) are reported as a close action.
+type Action struct {
+	Type       string
+	Name       string
+	ResModel   string
+	ResID      int64
+	ReportName string
+	ReportType string
+	URL        string
+	Context    map[string]interface{}
+	Raw        map[string]interface{}
+}
+
+// IsClose reports whether the action just closes the dialog
+func (a *Action) IsClose() bool {
+	return a.Type == ActionClose
+}
+
+// IsWindow reports whether the action opens another view or wizard
+func (a *Action) IsWindow() bool {
+	return a.Type == ActionWindow
+}
+
+// IsReport reports whether the action prints a report
+func (a *Action) IsReport() bool {
+	return a.Type == ActionReport
+}
+
+func parseAction(result interface{}) *Action {
+	raw, ok := result.(map[string]interface{})
+	if !ok {
+		return &Action{Type: ActionClose}
+	}
+
+	action := &Action{Raw: raw}
+	action.Type, _ = raw["type"].(string)
+	action.Name, _ = raw["name"].(string)
+	action.ResModel, _ = raw["res_model"].(string)
+	action.ResID, _ = toInt64(raw["res_id"])
+	action.ReportName, _ = raw["report_name"].(string)
+	action.ReportType, _ = raw["report_type"].(string)
+	action.URL, _ = raw["url"].(string)
+	action.Context, _ = raw["context"].(map[string]interface{})
+	if action.Type == "" {
+		action.Type = ActionClose
+	}
+	return action
+}
+
+// WizardOptions configures the creation of a wizard record
+type WizardOptions struct {
+	// Context is passed to every wizard call, typically active_model,
+	// active_id and active_ids as the web client would set them.
+	Context map[string]interface{}
+	// Values override the defaults computed by default_get.
+	Values map[string]interface{}
+}
+
+// Wizard is a transient record created through CreateWizard
+type Wizard struct {
+	Model   string
+	ID      int64
+	Context map[string]interface{}
+	c       *Connector
+}
+
+// CreateWizard creates a record of the transient model, starting from the
+// server defaults (default_get with opts.Context) and applying opts.Values on
+// top, just like opening the wizard in the web client.
+func (c *Connector) CreateWizard(ctx context.Context, model string, opts WizardOptions) (*Wizard, error) {
+	kwargs := map[string]interface{}{"context": contextOrEmpty(opts.Context)}
+
+	var fields map[string]interface{}
+	err := c.execute(ctx, model, "fields_get", []interface{}{}, map[string]interface{}{
+		"attributes": []string{"type"},
+		"context":    contextOrEmpty(opts.Context),
+	}, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("wizard creation failed for model %s: %w", model, err)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	values := make(map[string]interface{})
+	if err := c.execute(ctx, model, "default_get", []interface{}{names}, kwargs, &values); err != nil {
+		return nil, fmt.Errorf("wizard creation failed for model %s: %w", model, err)
+	}
+	for name, value := range opts.Values {
+		values[name] = value
+	}
+
+	var id int64
+	if err := c.execute(ctx, model, "create", []interface{}{values}, kwargs, &id); err != nil {
+		return nil, fmt.Errorf("wizard creation failed for model %s: %w", model, err)
+	}
+
+	return &Wizard{Model: model, ID: id, Context: opts.Context, c: c}, nil
+}
+
+// Call invokes a button method such as action_apply on the wizard and
+// interprets the returned action
+func (w *Wizard) Call(ctx context.Context, method string) (*Action, error) {
+	var result interface{}
+	err := w.c.execute(ctx, w.Model, method, []interface{}{[]int64{w.ID}}, map[string]interface{}{
+		"context": contextOrEmpty(w.Context),
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("wizard method %s.%s failed: %w", w.Model, method, err)
+	}
+	return parseAction(result), nil
+}
+
+// RunWizard creates a wizard with CreateWizard and immediately calls method
+func (c *Connector) RunWizard(ctx context.Context, model, method string, opts WizardOptions) (*Action, error) {
+	wizard, err := c.CreateWizard(ctx, model, opts)
+	if err != nil {
+		return nil, err
+	}
+	return wizard.Call(ctx, method)
+}
+
+func contextOrEmpty(ctx map[string]interface{}) map[string]interface{} {
+	if ctx == nil {
+		return map[string]interface{}{}
+	}
+	return ctx
+}
@@ -0,0 +1,100 @@
+package odoo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseAction(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		result interface{}
+		want   Action
+	}{
+		{"nothing", false, Action{Type: ActionClose}},
+		{"untyped", map[string]interface{}{}, Action{Type: ActionClose, Raw: map[string]interface{}{}}},
+		{
+			name:   "window",
+			result: map[string]interface{}{"type": ActionWindow, "name": "Invoice", "res_model": "account.move", "res_id": int64(7), "context": map[string]interface{}{"lang": "en_US"}},
+			want:   Action{Type: ActionWindow, Name: "Invoice", ResModel: "account.move", ResID: 7, Context: map[string]interface{}{"lang": "en_US"}},
+		},
+		{
+			name:   "report",
+			result: map[string]interface{}{"type": ActionReport, "report_name": "account.report_invoice", "report_type": "qweb-pdf"},
+			want:   Action{Type: ActionReport, ReportName: "account.report_invoice", ReportType: "qweb-pdf"},
+		},
+		{
+			name:   "url",
+			result: map[string]interface{}{"type": ActionURL, "url": "/web/content/12"},
+			want:   Action{Type: ActionURL, URL: "/web/content/12"},
+		},
+	} {
+		got := parseAction(tc.result)
+		if raw, ok := tc.result.(map[string]interface{}); ok {
+			tc.want.Raw = raw
+		}
+		if !reflect.DeepEqual(*got, tc.want) {
+			t.Errorf("%s: got %+v, want %+v", tc.name, *got, tc.want)
+		}
+	}
+}
+
+func TestRunWizard(t *testing.T) {
+	var calls []string
+	var created, defaultsContext, callContext interface{}
+	srv := xmlRPCServer(t, func(service, method string, args []interface{}) interface{} {
+		if method == "authenticate" {
+			return 2
+		}
+		if args[3] != "account.payment.register" {
+			t.Errorf("call on model %v", args[3])
+		}
+		calls = append(calls, args[4].(string))
+		kwargs, _ := args[6].(map[string]interface{})
+		switch args[4] {
+		case "fields_get":
+			return map[string]interface{}{"amount": map[string]interface{}{}, "journal_id": map[string]interface{}{}}
+		case "default_get":
+			defaultsContext = kwargs["context"]
+			return map[string]interface{}{"amount": 100.0, "journal_id": 3}
+		case "create":
+			created = args[5].([]interface{})[0]
+			return 11
+		case "action_create_payments":
+			callContext = kwargs["context"]
+			if !reflect.DeepEqual(args[5], []interface{}{[]interface{}{int64(11)}}) {
+				t.Errorf("method called on %v", args[5])
+			}
+			return map[string]interface{}{"type": ActionWindow, "res_model": "account.payment", "res_id": 21}
+		}
+		return false
+	})
+	c, err := NewConnector(srv.URL, "admin", "key", "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wizardContext := map[string]interface{}{"active_model": "account.move", "active_ids": []int64{5}}
+	action, err := c.RunWizard(context.Background(), "account.payment.register", "action_create_payments", WizardOptions{
+		Context: wizardContext,
+		Values:  map[string]interface{}{"amount": 80.0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"fields_get", "default_get", "create", "action_create_payments"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %v, want %v", calls, want)
+	}
+	// Values override the defaults
+	if want := map[string]interface{}{"amount": 80.0, "journal_id": int64(3)}; !reflect.DeepEqual(created, want) {
+		t.Errorf("created %v, want %v", created, want)
+	}
+	wantContext := map[string]interface{}{"active_model": "account.move", "active_ids": []interface{}{int64(5)}}
+	if !reflect.DeepEqual(defaultsContext, wantContext) || !reflect.DeepEqual(callContext, wantContext) {
+		t.Errorf("contexts %v and %v, want %v", defaultsContext, callContext, wantContext)
+	}
+	if !action.IsWindow() || action.ResModel != "account.payment" || action.ResID != 21 {
+		t.Errorf("action %+v", action)
+	}
+}